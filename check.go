@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/ratelimit"
+)
+
+// configReport config check 的检查结果
+type configReport struct {
+	failed bool
+}
+
+// ok 输出通过的检查项
+func (r *configReport) ok(format string, args ...interface{}) {
+	fmt.Printf("[OK]   "+format+"\n", args...)
+}
+
+// warn 输出不影响启动但需要关注的问题
+func (r *configReport) warn(format string, args ...interface{}) {
+	fmt.Printf("[WARN] "+format+"\n", args...)
+}
+
+// fail 输出会导致启动失败或功能异常的问题
+func (r *configReport) fail(format string, args ...interface{}) {
+	r.failed = true
+	fmt.Printf("[FAIL] "+format+"\n", args...)
+}
+
+// checkConfig 读取全部配置并检查常见错误与数据库连接，不修改任何数据，存在错误时返回 1
+func checkConfig() int {
+	report := &configReport{}
+
+	// 读取全部配置，格式错误的值会回退为默认值并被记录
+	serverConfig := config.GetServerConfig()
+	logConfig := config.GetLogConfig()
+	tracingConfig := config.GetTracingConfig()
+	rateLimitConfig := config.GetRateLimitConfig()
+	corsConfig := config.GetCORSConfig()
+	config.GetCORSGroups()
+	metricsConfig := config.GetMetricsConfig()
+	config.GetIdempotencyConfig()
+	config.GetBatchConfig()
+	config.GetOutboxConfig()
+	config.GetWebhookConfig()
+	config.GetJobsConfig()
+	config.GetSettingsConfig()
+	config.GetStreamConfig()
+	config.GetGraphQLConfig()
+	grpcConfig := config.GetGRPCConfig()
+
+	if invalid := config.InvalidValues(); len(invalid) > 0 {
+		report.fail("以下环境变量格式错误，已回退为默认值: %s", strings.Join(invalid, ", "))
+	} else {
+		report.ok("环境变量格式正确")
+	}
+
+	if modules, err := module.Resolve(config.GetModuleConfig()); err != nil {
+		report.fail("模块配置错误: %v", err)
+	} else {
+		report.ok("已启用模块: %s", moduleNames(modules))
+	}
+
+	switch strings.ToLower(logConfig.Format) {
+	case "json", "text":
+	default:
+		report.warn("LOG_FORMAT=%s 不受支持，将使用 json", logConfig.Format)
+	}
+
+	switch strings.ToLower(tracingConfig.Exporter) {
+	case "", "none", "otlp", "stdout":
+	default:
+		report.fail("TRACING_EXPORTER=%s 不受支持，可选 otlp、stdout、none", tracingConfig.Exporter)
+	}
+
+	if (serverConfig.TLSCertFile == "") != (serverConfig.TLSKeyFile == "") {
+		report.fail("TLS_CERT_FILE 与 TLS_KEY_FILE 需同时设置")
+	}
+	for _, file := range []string{serverConfig.TLSCertFile, serverConfig.TLSKeyFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			report.fail("TLS 文件不可读: %v", err)
+		}
+	}
+
+	if rateLimitConfig.Enabled {
+		switch strings.ToLower(rateLimitConfig.Store) {
+		case "", "memory", "postgres":
+		default:
+			report.fail("RATE_LIMIT_STORE=%s 不受支持，可选 memory、postgres", rateLimitConfig.Store)
+		}
+		for name, value := range map[string]string{
+			ratelimit.PolicyDefault: rateLimitConfig.Default,
+			ratelimit.PolicyLogin:   rateLimitConfig.Login,
+			ratelimit.PolicyBulk:    rateLimitConfig.Bulk,
+		} {
+			if _, err := ratelimit.ParsePolicy(name, value); err != nil {
+				report.fail("限流额度 %s 配置错误: %v", name, err)
+			}
+		}
+	}
+
+	if corsConfig.AllowCredentials {
+		for _, origin := range corsConfig.AllowedOrigins {
+			if origin == "*" {
+				report.warn("CORS_ALLOW_CREDENTIALS=true 时 CORS_ALLOWED_ORIGINS 不应包含 *")
+			}
+		}
+	}
+
+	if metricsConfig.Enabled && metricsConfig.Addr == "" && metricsConfig.Token == "" {
+		report.fail("已开启监控指标但未设置 METRICS_TOKEN 或 METRICS_ADDR，指标不会挂载")
+	}
+
+	if grpcConfig.Enabled && len(grpcConfig.APIKeys) == 0 {
+		report.warn("已开启 gRPC 服务但未设置 GRPC_API_KEYS")
+	}
+
+	if os.Getenv("JWT_SECRET") == "" {
+		report.warn("未设置 JWT_SECRET，正在使用默认密钥")
+	}
+
+	if err := pingDB(); err != nil {
+		report.fail("数据库连接失败: %v", err)
+	} else {
+		report.ok("数据库连接正常")
+	}
+
+	if report.failed {
+		return 1
+	}
+	return 0
+}
+
+// pingDB 连接数据库并执行一次 ping
+func pingDB() error {
+	db, err := database.Connect()
+	if err != nil {
+		return err
+	}
+	defer database.CloseDatabase()
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/logger"
+	"erp_backend/pkg/module"
+)
+
+// builtinCommands 内置命令，其余运维命令由启用的模块通过 module.CommandModule 提供
+// serve 与 config check 自行完成初始化，其余命令执行前统一初始化日志并连接数据库，NoDB 的命令不连接数据库
+func builtinCommands() []module.Command {
+	return []module.Command{
+		{Name: "serve", Description: "启动服务（默认命令）"},
+		{Name: "migrate", Description: "执行数据库迁移", Run: runMigrate},
+		{Name: "seed", Description: "初始化种子数据", Run: runSeed},
+		{Name: "config check", Description: "检查配置与数据库连接，存在错误时以非零状态退出"},
+		{Name: "routes", Usage: "[-json]", Description: "打印路由表及调用各路由所需的权限", Run: runRoutes, NoDB: true},
+	}
+}
+
+// run 解析并执行子命令，返回进程退出码；不带参数时启动服务
+func run(args []string) int {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+
+	if hasPrefix(args, "config check") {
+		return checkConfig()
+	}
+
+	// 按配置确定启用的模块，依赖的模块排在前面
+	modules, err := module.Resolve(config.GetModuleConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "模块配置错误: %v\n", err)
+		return 1
+	}
+	commands := append(builtinCommands(), module.Commands()...)
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage(os.Stdout, commands)
+		return 0
+	}
+
+	cmd, ok := findCommand(commands, args)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", strings.Join(args, " "))
+		printUsage(os.Stderr, commands)
+		return 2
+	}
+	if cmd.Name == "serve" {
+		serve(modules)
+		return 0
+	}
+
+	if err := runCommand(cmd, args[len(strings.Fields(cmd.Name)):]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s 失败: %v\n", cmd.Name, err)
+		return 1
+	}
+	return 0
+}
+
+// runCommand 初始化日志与数据库后执行命令，日志输出到标准错误，标准输出只包含命令结果
+// NoDB 的命令使用未连接的数据库句柄，数据库不可用时也能执行
+func runCommand(cmd module.Command, args []string) error {
+	logConfig := config.GetLogConfig()
+	logConfig.Stderr = true
+	logCloser, err := logger.Init(logConfig)
+	if err != nil {
+		return fmt.Errorf("日志初始化失败: %w", err)
+	}
+	defer logCloser.Close()
+
+	if cmd.NoDB {
+		db, err := database.Handle()
+		if err != nil {
+			return err
+		}
+		return cmd.Run(context.Background(), &module.App{DB: db, Settings: newSettings(db, module.Enabled())}, args)
+	}
+
+	db, err := connectDB()
+	if err != nil {
+		return err
+	}
+	defer database.CloseDatabase()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return cmd.Run(ctx, &module.App{DB: db, Settings: newSettings(db, module.Enabled())}, args)
+}
+
+// findCommand 按命令名匹配参数，多级命令（如 user create）优先于同前缀的较短命令
+func findCommand(commands []module.Command, args []string) (module.Command, bool) {
+	var found module.Command
+	ok := false
+	for _, cmd := range commands {
+		if hasPrefix(args, cmd.Name) && (!ok || len(cmd.Name) > len(found.Name)) {
+			found, ok = cmd, true
+		}
+	}
+	return found, ok
+}
+
+// hasPrefix 参数是否以命令名的各级开头
+func hasPrefix(args []string, name string) bool {
+	words := strings.Fields(name)
+	if len(args) < len(words) {
+		return false
+	}
+	for i, word := range words {
+		if args[i] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// printUsage 打印命令列表
+func printUsage(w io.Writer, commands []module.Command) {
+	fmt.Fprintf(w, "用法: %s <命令> [参数]，不带命令时启动服务\n\n命令:\n", os.Args[0])
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s\n      %s\n", strings.TrimSpace(cmd.Name+" "+cmd.Usage), cmd.Description)
+	}
+}
+
+// runMigrate 执行数据库迁移
+func runMigrate(_ context.Context, app *module.App, _ []string) error {
+	return autoMigrate(app.DB, module.Enabled())
+}
+
+// runSeed 初始化种子数据
+func runSeed(_ context.Context, app *module.App, _ []string) error {
+	return seedData(app.DB, module.Enabled())
+}
+
+// runRoutes 按服务相同的方式注册路由，打印路由表；注册路由不执行查询，无需连接数据库
+func runRoutes(_ context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("routes", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	app.Router = r
+	app.Relay = events.NewRelay(app.DB, config.GetOutboxConfig())
+	routes := setupRoutes(r, app, module.Enabled()).Routes()
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(routes)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tPERMISSION\tUSER TYPES\tHANDLER")
+	for _, route := range routes {
+		userTypes := "-"
+		if len(route.UserTypes) > 0 {
+			userTypes = strings.Join(route.UserTypes, "|")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", route.Method, route.Path, route.Permission, userTypes, route.Handler)
+	}
+	return tw.Flush()
+}
@@ -25,6 +25,11 @@ const docTemplate = `{
     "paths": {
         "/attributes": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "获取所有属性的列表",
                 "consumes": [
                     "application/json"
@@ -48,6 +53,36 @@ const docTemplate = `{
                         "description": "是否启用",
                         "name": "is_enabled",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -62,16 +97,46 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/attribute.Attribute"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/attribute.Attribute"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -81,6 +146,11 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "创建新的属性",
                 "consumes": [
                     "application/json"
@@ -101,6 +171,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/attribute.Attribute"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -128,6 +204,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -139,6 +227,11 @@ const docTemplate = `{
         },
         "/attributes/{id}": {
             "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "根据ID获取属性信息",
                 "consumes": [
                     "application/json"
@@ -184,6 +277,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
                         "description": "属性不存在",
                         "schema": {
@@ -193,6 +298,11 @@ const docTemplate = `{
                 }
             },
             "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "更新属性信息",
                 "consumes": [
                     "application/json"
@@ -247,6 +357,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
                         "description": "属性不存在",
                         "schema": {
@@ -262,6 +384,11 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "删除属性",
                 "consumes": [
                     "application/json"
@@ -295,6 +422,122 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改属性，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "属性管理"
+                ],
+                "summary": "部分更新属性",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "属性ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/attribute.Attribute"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "属性不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -306,6 +549,11 @@ const docTemplate = `{
         },
         "/attributes/{id}/toggle": {
             "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "启用/禁用属性",
                 "consumes": [
                     "application/json"
@@ -324,6 +572,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -351,6 +605,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
                         "description": "属性不存在",
                         "schema": {
@@ -420,13 +686,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
+                    },
+                    "423": {
+                        "description": "登录失败次数过多，账号已锁定",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
                     }
                 }
             }
         },
         "/auth/register": {
             "post": {
-                "description": "注册新用户",
+                "description": "注册新用户，注册的用户为默认类型",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,7 +722,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.CreateUserRequest"
+                            "$ref": "#/definitions/user.RegisterRequest"
                         }
                     }
                 ],
@@ -473,6 +751,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "409": {
+                        "description": "用户名或邮箱已存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -482,9 +772,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
-            "get": {
-                "description": "获取所有分类的列表",
+        "/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按顺序执行一组子请求，每个子请求与单独调用对应接口完全一致（认证、校验、错误码相同）。\n操作可设置 ref 名称，后续操作的路径和请求体中用 {{ref.字段}} 引用其响应 data 中的值，如 {{p1.id}}；请求体中整个字符串为一个引用时保留原类型。\natomic 为 true 时所有操作在同一事务中执行，首个失败（状态码 \u003e= 400）后回滚并跳过剩余操作；否则各操作独立执行，引用了失败操作的操作返回 424。\n批量请求本身计入 bulk 限流额度并支持幂等键，每个子请求再按对应接口的额度计数，超出额度的子请求返回 429",
                 "consumes": [
                     "application/json"
                 ],
@@ -492,12 +787,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "分类管理"
+                    "批量操作"
+                ],
+                "summary": "批量操作",
+                "parameters": [
+                    {
+                        "description": "批量请求",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/batch.Request"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "获取分类列表",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "执行完成，各操作结果见 results",
                         "schema": {
                             "allOf": [
                                 {
@@ -507,26 +819,60 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/category.Category"
-                                            }
+                                            "$ref": "#/definitions/batch.Response"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建新的分类",
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取分类列表，回收站中的分类不包含在内",
                 "consumes": [
                     "application/json"
                 ],
@@ -536,21 +882,42 @@ const docTemplate = `{
                 "tags": [
                     "分类管理"
                 ],
-                "summary": "创建分类",
+                "summary": "获取分类列表",
                 "parameters": [
                     {
-                        "description": "分类信息",
-                        "name": "category",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/category.Category"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -560,7 +927,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/category.Category"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/category.Category"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -573,6 +955,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -580,11 +974,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/categories/{id}": {
-            "get": {
-                "description": "根据ID获取分类信息",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建新的分类",
                 "consumes": [
                     "application/json"
                 ],
@@ -594,19 +991,27 @@ const docTemplate = `{
                 "tags": [
                     "分类管理"
                 ],
-                "summary": "获取单个分类",
+                "summary": "创建分类",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "分类ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "分类信息",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -629,16 +1034,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "分类不存在",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新分类信息",
+            }
+        },
+        "/categories/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取已删除的分类，查询参数同列表接口",
                 "consumes": [
                     "application/json"
                 ],
@@ -648,28 +1072,42 @@ const docTemplate = `{
                 "tags": [
                     "分类管理"
                 ],
-                "summary": "更新分类",
+                "summary": "获取回收站中的分类",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "分类ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "分类信息",
-                        "name": "category",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/category.Category"
-                        }
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -679,7 +1117,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/category.Category"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/category.Category"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -692,8 +1145,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "分类不存在",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -705,9 +1164,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "删除分类",
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取分类信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -717,7 +1183,7 @@ const docTemplate = `{
                 "tags": [
                     "分类管理"
                 ],
-                "summary": "删除分类",
+                "summary": "获取单个分类",
                 "parameters": [
                     {
                         "type": "integer",
@@ -729,9 +1195,21 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "获取成功",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/category.Category"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -740,18 +1218,33 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分类不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            }
-        },
-        "/categories/{id}/toggle": {
-            "patch": {
-                "description": "启用/禁用分类",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "全量更新分类信息，主键与创建时间不可修改",
                 "consumes": [
                     "application/json"
                 ],
@@ -761,7 +1254,7 @@ const docTemplate = `{
                 "tags": [
                     "分类管理"
                 ],
-                "summary": "切换分类状态",
+                "summary": "更新分类",
                 "parameters": [
                     {
                         "type": "integer",
@@ -769,11 +1262,20 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "分类信息",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "切换成功",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -796,6 +1298,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
                         "description": "分类不存在",
                         "schema": {
@@ -809,11 +1323,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "检查服务是否正常运行",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将分类移入回收站，可通过恢复接口恢复；分类不存在时同样返回成功",
                 "consumes": [
                     "application/json"
                 ],
@@ -821,67 +1338,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "系统"
+                    "分类管理"
                 ],
-                "summary": "健康检查",
-                "responses": {
-                    "200": {
-                        "description": "服务运行正常",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                "summary": "删除分类",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "分类ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
-                }
-            }
-        },
-        "/links": {
-            "get": {
-                "description": "获取所有链接的列表",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "链接管理"
                 ],
-                "summary": "获取链接列表",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "删除成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/link.Link"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "该分类下存在子分类",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
@@ -892,8 +1389,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "创建新的链接",
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改分类，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
                 "consumes": [
                     "application/json"
                 ],
@@ -901,23 +1403,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "链接管理"
+                    "分类管理"
                 ],
-                "summary": "创建链接",
+                "summary": "部分更新分类",
                 "parameters": [
                     {
-                        "description": "链接信息",
-                        "name": "link",
+                        "type": "integer",
+                        "description": "分类ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/link.Link"
+                            "type": "object"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -927,7 +1442,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/link.Link"
+                                            "$ref": "#/definitions/category.Category"
                                         }
                                     }
                                 }
@@ -940,6 +1455,36 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "分类不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -949,9 +1494,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/links/{id}": {
+        "/categories/{id}/children": {
             "get": {
-                "description": "根据ID获取链接信息",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取分类的直接子分类，回收站中的分类不包含在内",
                 "consumes": [
                     "application/json"
                 ],
@@ -959,13 +1509,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "链接管理"
+                    "分类管理"
                 ],
-                "summary": "获取单个链接",
+                "summary": "获取子分类",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "链接ID",
+                        "description": "分类ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -983,7 +1533,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/link.Link"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/category.Category"
+                                            }
                                         }
                                     }
                                 }
@@ -996,16 +1549,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "链接不存在",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新链接信息",
+            }
+        },
+        "/categories/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将回收站中的分类恢复",
                 "consumes": [
                     "application/json"
                 ],
@@ -1013,30 +1585,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "链接管理"
+                    "分类管理"
                 ],
-                "summary": "更新链接",
+                "summary": "恢复分类",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "链接ID",
+                        "description": "分类ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "链接信息",
-                        "name": "link",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/link.Link"
-                        }
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "恢复成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1046,7 +1615,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/link.Link"
+                                            "$ref": "#/definitions/category.Category"
                                         }
                                     }
                                 }
@@ -1059,50 +1628,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "链接不存在",
+                    "401": {
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "删除链接",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "链接管理"
-                ],
-                "summary": "删除链接",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "链接ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "删除成功",
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "404": {
+                        "description": "分类不存在或不在回收站中",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1116,9 +1655,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/links/{id}/toggle": {
+        "/categories/{id}/toggle": {
             "patch": {
-                "description": "启用/禁用链接",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "启用/禁用分类",
                 "consumes": [
                     "application/json"
                 ],
@@ -1126,21 +1670,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "链接管理"
+                    "分类管理"
                 ],
-                "summary": "切换链接状态",
+                "summary": "切换分类状态",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "链接ID",
+                        "description": "分类ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "切换成功",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1150,7 +1700,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/link.Link"
+                                            "$ref": "#/definitions/category.Category"
                                         }
                                     }
                                 }
@@ -1163,68 +1713,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "链接不存在",
+                    "401": {
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/product-attributes": {
-            "get": {
-                "description": "获取所有商品属性值的列表",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "商品属性值管理"
-                ],
-                "summary": "获取商品属性值列表",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "商品ID",
-                        "name": "product_id",
-                        "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "属性ID",
-                        "name": "attribute_id",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "获取成功",
+                    "404": {
+                        "description": "分类不存在",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/attribute.ProductAttribute"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
@@ -1234,9 +1738,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建新的商品属性值",
+            }
+        },
+        "/error-codes": {
+            "get": {
+                "description": "列出全部业务错误码。错误响应中的 error_code 字段取值于此列表，校验失败（VALIDATION_FAILED）时 details 给出字段级详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -1244,23 +1750,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品属性值管理"
-                ],
-                "summary": "创建商品属性值",
-                "parameters": [
-                    {
-                        "description": "商品属性值信息",
-                        "name": "productAttribute",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/attribute.ProductAttribute"
-                        }
-                    }
+                    "系统"
                 ],
+                "summary": "错误码列表",
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1270,100 +1765,101 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/attribute.ProductAttribute"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.ErrorCodeInfo"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
                     }
                 }
             }
         },
-        "/product-attributes/{id}": {
-            "put": {
-                "description": "更新商品属性值信息",
-                "consumes": [
-                    "application/json"
+        "/graphql": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "与 POST /graphql 相同，但只能执行查询，便于缓存；variables 为 JSON 字符串",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "商品属性值管理"
+                    "GraphQL"
                 ],
-                "summary": "更新商品属性值",
+                "summary": "GraphQL（GET）",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "商品属性值ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "查询语句",
+                        "name": "query",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "商品属性值信息",
-                        "name": "productAttribute",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/attribute.ProductAttribute"
-                        }
+                        "type": "string",
+                        "description": "操作名称",
+                        "name": "operationName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "变量，JSON 格式",
+                        "name": "variables",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "执行完成，字段级错误见 errors",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/attribute.ProductAttribute"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "语法错误、校验失败或超过深度、复杂度上限",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
                         }
                     },
-                    "404": {
-                        "description": "商品属性值不存在",
+                    "401": {
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "无权限或内省查询已禁用",
+                        "schema": {
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
+                        }
+                    },
+                    "405": {
+                        "description": "GET 请求不能执行变更",
+                        "schema": {
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "删除商品属性值",
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "以 GraphQL 查询供应商、店铺、商品、分类、链接、属性与商品属性值及其关联关系，变更与对应 REST 接口一致。\n顶层查询与变更经由对应的 REST 接口执行，认证、权限、校验与错误码完全相同，错误码见 errors[].extensions.code；\n关联字段（如 product.supplier、shop.links）按层批量加载，不会产生 N+1 查询。\n查询深度或复杂度（列表字段按每页条数成倍计算）超过上限时拒绝执行。请求本身计入 bulk 限流额度，每个子请求再按对应接口的额度计数，变更支持幂等键",
                 "consumes": [
                     "application/json"
                 ],
@@ -1371,33 +1867,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品属性值管理"
+                    "GraphQL"
                 ],
-                "summary": "删除商品属性值",
+                "summary": "GraphQL",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "商品属性值ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "GraphQL 请求",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graphql.Request"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "执行完成，字段级错误见 errors",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "语法错误、校验失败或超过深度、复杂度上限",
+                        "schema": {
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "无权限或内省查询已禁用",
+                        "schema": {
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/erp_backend_modules_graphql.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1405,9 +1927,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/health": {
             "get": {
-                "description": "获取所有商品的列表",
+                "description": "检查服务是否正常运行",
                 "consumes": [
                     "application/json"
                 ],
@@ -1415,12 +1937,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品管理"
+                    "系统"
                 ],
-                "summary": "获取商品列表",
+                "summary": "健康检查",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "服务运行正常",
                         "schema": {
                             "allOf": [
                                 {
@@ -1430,26 +1952,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/product.Product"
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
                     }
                 }
-            },
-            "post": {
-                "description": "创建新的商品",
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取后台任务，可按类型、状态（pending、running、succeeded、failed、cancelled）、定时计划筛选",
                 "consumes": [
                     "application/json"
                 ],
@@ -1457,23 +1980,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品管理"
+                    "后台任务管理"
                 ],
-                "summary": "创建商品",
+                "summary": "获取后台任务列表",
                 "parameters": [
                     {
-                        "description": "商品信息",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/product.Product"
-                        }
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[status]=failed",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1483,7 +2027,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/product.Product"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/jobs.Job"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -1505,9 +2064,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/jobs/kinds": {
             "get": {
-                "description": "根据ID获取商品信息",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取本实例注册的任务类型",
                 "consumes": [
                     "application/json"
                 ],
@@ -1515,18 +2079,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品管理"
-                ],
-                "summary": "获取单个商品",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "商品ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "后台任务管理"
                 ],
+                "summary": "获取后台任务类型",
                 "responses": {
                     "200": {
                         "description": "获取成功",
@@ -1539,29 +2094,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/product.Product"
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "商品不存在",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
                     }
                 }
-            },
-            "put": {
-                "description": "更新商品信息",
+            }
+        },
+        "/jobs/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取各模块声明的定时计划及下次执行时间",
                 "consumes": [
                     "application/json"
                 ],
@@ -1569,30 +2122,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品管理"
+                    "后台任务管理"
                 ],
-                "summary": "更新商品",
+                "summary": "获取定时计划列表",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "商品ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "商品信息",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/product.Product"
-                        }
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[is_enabled]=false",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1602,7 +2169,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/product.Product"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/jobs.Schedule"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -1615,12 +2197,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "商品不存在",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -1628,53 +2204,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "删除商品",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "商品管理"
-                ],
-                "summary": "删除商品",
-                "parameters": [
+            }
+        },
+        "/jobs/schedules/{name}/run": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "商品ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/products/{id}/toggle": {
-            "patch": {
-                "description": "启用/禁用商品",
+                "description": "立即按定时计划入队一次，不影响下次执行时间；上次产生的任务未结束时返回该任务",
                 "consumes": [
                     "application/json"
                 ],
@@ -1682,21 +2221,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "商品管理"
+                    "后台任务管理"
                 ],
-                "summary": "切换商品状态",
+                "summary": "立即执行定时计划",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "商品ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "计划名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "切换成功",
+                        "description": "已入队",
                         "schema": {
                             "allOf": [
                                 {
@@ -1706,21 +2251,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/product.Product"
+                                            "$ref": "#/definitions/jobs.Job"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
                     "404": {
-                        "description": "商品不存在",
+                        "description": "定时计划不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1734,9 +2273,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shops": {
-            "get": {
-                "description": "获取所有店铺的列表",
+        "/jobs/schedules/{name}/toggle": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "启用/停用定时计划，停用期间不再按计划入队，重启服务后保持停用",
                 "consumes": [
                     "application/json"
                 ],
@@ -1744,26 +2288,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "店铺管理"
+                    "后台任务管理"
                 ],
-                "summary": "获取店铺列表",
+                "summary": "切换定时计划状态",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "供应商ID",
-                        "name": "supplier_id",
-                        "in": "query"
+                        "description": "计划名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "是否启用",
-                        "name": "is_enabled",
-                        "in": "query"
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "切换成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1773,16 +2318,19 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/shop.Shop"
-                                            }
+                                            "$ref": "#/definitions/jobs.Schedule"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "404": {
+                        "description": "定时计划不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -1790,9 +2338,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建新的店铺",
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取后台任务，包括参数、执行次数与最近一次错误",
                 "consumes": [
                     "application/json"
                 ],
@@ -1800,23 +2355,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "店铺管理"
+                    "后台任务管理"
                 ],
-                "summary": "创建店铺",
+                "summary": "获取后台任务详情",
                 "parameters": [
                     {
-                        "description": "店铺信息",
-                        "name": "shop",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/shop.Shop"
-                        }
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -1826,7 +2379,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/shop.Shop"
+                                            "$ref": "#/definitions/jobs.Job"
                                         }
                                     }
                                 }
@@ -1839,8 +2392,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "404": {
+                        "description": "任务不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1848,9 +2401,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/shops/{id}": {
-            "get": {
-                "description": "根据ID获取店铺信息",
+        "/jobs/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取消等待中或执行中的任务；在其他实例执行中的任务会继续执行到结束，但结果不再写入",
                 "consumes": [
                     "application/json"
                 ],
@@ -1858,21 +2416,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "店铺管理"
+                    "后台任务管理"
                 ],
-                "summary": "获取单个店铺",
+                "summary": "取消后台任务",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "店铺ID",
+                        "description": "任务ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "已取消",
                         "schema": {
                             "allOf": [
                                 {
@@ -1882,7 +2446,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/shop.Shop"
+                                            "$ref": "#/definitions/jobs.Job"
                                         }
                                     }
                                 }
@@ -1896,15 +2460,34 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "店铺不存在",
+                        "description": "任务不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "任务已结束",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新店铺信息",
+            }
+        },
+        "/jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将失败或已取消的任务重置为等待执行并清零执行次数，由后台立即执行",
                 "consumes": [
                     "application/json"
                 ],
@@ -1912,30 +2495,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "店铺管理"
+                    "后台任务管理"
                 ],
-                "summary": "更新店铺",
+                "summary": "重试后台任务",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "店铺ID",
+                        "description": "任务ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "店铺信息",
-                        "name": "shop",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/shop.Shop"
-                        }
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "已重新排队",
                         "schema": {
                             "allOf": [
                                 {
@@ -1945,7 +2525,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/shop.Shop"
+                                            "$ref": "#/definitions/jobs.Job"
                                         }
                                     }
                                 }
@@ -1959,7 +2539,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "店铺不存在",
+                        "description": "任务不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "任务状态不允许重试，或已有相同唯一键的任务未结束",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -1971,53 +2557,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "删除店铺",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "店铺管理"
-                ],
-                "summary": "删除店铺",
-                "parameters": [
+            }
+        },
+        "/links": {
+            "get": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "店铺ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/shops/{id}/toggle": {
-            "patch": {
-                "description": "启用/禁用店铺",
+                "description": "分页获取链接列表，回收站中的链接不包含在内",
                 "consumes": [
                     "application/json"
                 ],
@@ -2025,21 +2574,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "店铺管理"
+                    "链接管理"
                 ],
-                "summary": "切换店铺状态",
+                "summary": "获取链接列表",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "type": "string",
                         "description": "店铺ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "类目ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2049,7 +2639,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/shop.Shop"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/link.Link"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -2062,8 +2667,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "店铺不存在",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2075,11 +2686,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/suppliers": {
-            "get": {
-                "description": "获取所有供应商的列表",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建新的链接",
                 "consumes": [
                     "application/json"
                 ],
@@ -2087,12 +2701,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "供应商管理"
+                    "链接管理"
+                ],
+                "summary": "创建链接",
+                "parameters": [
+                    {
+                        "description": "链接信息",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/link.Link"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "获取供应商列表",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2102,16 +2733,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/supplier.Supplier"
-                                            }
+                                            "$ref": "#/definitions/link.Link"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -2119,9 +2765,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建新的供应商",
+            }
+        },
+        "/links/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取已删除的链接，查询参数同列表接口",
                 "consumes": [
                     "application/json"
                 ],
@@ -2129,23 +2782,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "供应商管理"
+                    "链接管理"
                 ],
-                "summary": "创建供应商",
+                "summary": "获取回收站中的链接",
                 "parameters": [
                     {
-                        "description": "供应商信息",
-                        "name": "supplier",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/supplier.Supplier"
-                        }
+                        "type": "string",
+                        "description": "店铺ID",
+                        "name": "shop_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "类目ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "获取成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2155,7 +2847,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/supplier.Supplier"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/link.Link"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -2168,6 +2875,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -2177,9 +2896,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/suppliers/{id}": {
+        "/links/{id}": {
             "get": {
-                "description": "根据ID获取供应商信息",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取链接信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -2187,13 +2911,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "供应商管理"
+                    "链接管理"
                 ],
-                "summary": "获取单个供应商",
+                "summary": "获取单个链接",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "供应商ID",
+                        "description": "链接ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2211,7 +2935,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/supplier.Supplier"
+                                            "$ref": "#/definitions/link.Link"
                                         }
                                     }
                                 }
@@ -2224,8 +2948,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
-                        "description": "供应商不存在",
+                        "description": "链接不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2233,7 +2969,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "更新供应商信息",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "全量更新链接信息，主键与创建时间不可修改",
                 "consumes": [
                     "application/json"
                 ],
@@ -2241,24 +2982,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "供应商管理"
+                    "链接管理"
                 ],
-                "summary": "更新供应商",
+                "summary": "更新链接",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "供应商ID",
+                        "description": "链接ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "供应商信息",
-                        "name": "supplier",
+                        "description": "链接信息",
+                        "name": "link",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/supplier.Supplier"
+                            "$ref": "#/definitions/link.Link"
                         }
                     }
                 ],
@@ -2274,7 +3015,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/supplier.Supplier"
+                                            "$ref": "#/definitions/link.Link"
                                         }
                                     }
                                 }
@@ -2287,8 +3028,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
-                        "description": "供应商不存在",
+                        "description": "链接不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2302,7 +3055,12 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "删除供应商",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将链接移入回收站，可通过恢复接口恢复；链接不存在时同样返回成功",
                 "consumes": [
                     "application/json"
                 ],
@@ -2310,13 +3068,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "供应商管理"
+                    "链接管理"
                 ],
-                "summary": "删除供应商",
+                "summary": "删除链接",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "供应商ID",
+                        "description": "链接ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2335,6 +3093,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -2342,11 +3112,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/suppliers/{id}/toggle": {
+            },
             "patch": {
-                "description": "启用/禁用供应商",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改链接，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
                 "consumes": [
                     "application/json"
                 ],
@@ -2354,21 +3127,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "供应商管理"
+                    "链接管理"
                 ],
-                "summary": "切换供应商状态",
+                "summary": "部分更新链接",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "供应商ID",
+                        "description": "链接ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "切换成功",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2378,7 +3166,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/supplier.Supplier"
+                                            "$ref": "#/definitions/link.Link"
                                         }
                                     }
                                 }
@@ -2391,8 +3179,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
-                        "description": "供应商不存在",
+                        "description": "链接不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2406,14 +3218,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
+        "/links/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取所有用户的列表",
+                "description": "将回收站中的链接恢复",
                 "consumes": [
                     "application/json"
                 ],
@@ -2421,12 +3233,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "链接管理"
+                ],
+                "summary": "恢复链接",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "链接ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
                 ],
-                "summary": "获取用户列表",
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "恢复成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2436,16 +3263,37 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/user.UserResponse"
-                                            }
+                                            "$ref": "#/definitions/link.Link"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "链接不存在或不在回收站中",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -2453,14 +3301,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/links/{id}/toggle": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "创建新用户",
+                "description": "启用/禁用链接",
                 "consumes": [
                     "application/json"
                 ],
@@ -2468,23 +3318,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "链接管理"
                 ],
-                "summary": "创建用户",
+                "summary": "切换链接状态",
                 "parameters": [
                     {
-                        "description": "用户信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.CreateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "链接ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2494,7 +3348,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user.UserResponse"
+                                            "$ref": "#/definitions/link.Link"
                                         }
                                     }
                                 }
@@ -2507,65 +3361,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/users/password": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "修改当前登录用户的密码",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "个人中心"
-                ],
-                "summary": "修改密码",
-                "parameters": [
-                    {
-                        "description": "密码信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.UpdatePasswordRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "修改成功",
+                    "401": {
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "401": {
-                        "description": "未登录或旧密码错误",
+                    "404": {
+                        "description": "链接不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "用户不存在",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2573,14 +3388,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/profile": {
+        "/modules": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "Bearer": []
                     }
                 ],
-                "description": "获取当前登录用户的个人资料",
+                "description": "按启动顺序列出已启用的业务模块及其依赖与权限，模块通过 MODULES_ENABLED、MODULES_DISABLED 配置启用",
                 "consumes": [
                     "application/json"
                 ],
@@ -2588,9 +3403,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "个人中心"
+                    "系统"
                 ],
-                "summary": "获取个人资料",
+                "summary": "已启用模块列表",
                 "responses": {
                     "200": {
                         "description": "获取成功",
@@ -2603,7 +3418,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user.UserResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/system.ModuleInfo"
+                                            }
                                         }
                                     }
                                 }
@@ -2611,26 +3429,28 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未登录",
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "用户不存在",
+                    "403": {
+                        "description": "无权限",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/product-attributes": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新当前登录用户的个人资料",
+                "description": "获取所有商品属性值的列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -2638,38 +3458,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "个人中心"
+                    "商品属性值管理"
                 ],
-                "summary": "更新个人资料",
+                "summary": "获取商品属性值列表",
                 "parameters": [
                     {
-                        "description": "用户信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/user.UpdateUserRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "更新成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/user.UserResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                        "type": "string",
+                        "description": "商品ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "属性ID",
+                        "name": "attribute_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/attribute.ProductAttribute"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
                         "description": "请求参数错误",
@@ -2678,28 +3546,32 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "未登录",
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "用户不存在",
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据ID获取用户信息",
+                "description": "创建新的商品属性值",
                 "consumes": [
                     "application/json"
                 ],
@@ -2707,21 +3579,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "商品属性值管理"
                 ],
-                "summary": "获取单个用户",
+                "summary": "创建商品属性值",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "商品属性值信息",
+                        "name": "productAttribute",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attribute.ProductAttribute"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "获取成功",
+                        "description": "创建成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2731,7 +3611,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user.UserResponse"
+                                            "$ref": "#/definitions/attribute.ProductAttribute"
                                         }
                                     }
                                 }
@@ -2744,21 +3624,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
-                    "404": {
-                        "description": "用户不存在",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/product-attributes/{id}": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "更新用户信息",
+                "description": "更新商品属性值信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -2766,24 +3660,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "商品属性值管理"
                 ],
-                "summary": "更新用户",
+                "summary": "更新商品属性值",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "用户ID",
+                        "description": "商品属性值ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "用户信息",
-                        "name": "data",
+                        "description": "商品属性值信息",
+                        "name": "productAttribute",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.UpdateUserRequest"
+                            "$ref": "#/definitions/attribute.ProductAttribute"
                         }
                     }
                 ],
@@ -2799,7 +3693,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/user.UserResponse"
+                                            "$ref": "#/definitions/attribute.ProductAttribute"
                                         }
                                     }
                                 }
@@ -2812,8 +3706,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "404": {
-                        "description": "用户不存在",
+                        "description": "商品属性值不存在",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -2832,7 +3738,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "删除指定用户",
+                "description": "删除商品属性值",
                 "consumes": [
                     "application/json"
                 ],
@@ -2840,13 +3746,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "商品属性值管理"
                 ],
-                "summary": "删除用户",
+                "summary": "删除商品属性值",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "用户ID",
+                        "description": "商品属性值ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2865,6 +3771,122 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Response"
                         }
                     },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改商品属性值，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品属性值管理"
+                ],
+                "summary": "部分更新商品属性值",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品属性值ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/attribute.ProductAttribute"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品属性值不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -2873,26 +3895,4983 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "attribute.Attribute": {
-            "description": "属性信息",
-            "type": "object",
-            "properties": {
-                "category_id": {
-                    "description": "所属分类ID",
-                    "type": "integer"
-                },
-                "created_at": {
-                    "description": "创建时间",
-                    "type": "string"
-                },
-                "data_type": {
-                    "description": "数据类型",
-                    "type": "string"
-                },
-                "deleted_at": {
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取商品列表，回收站中的商品不包含在内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "获取商品列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "供应商ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分类ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "商品类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/product.Product"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建新的商品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "创建商品",
+                "parameters": [
+                    {
+                        "description": "商品信息",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.Product"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/product.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "商品SKU已存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "按名称、SKU、备注和动态属性值搜索商品，按相关度排序，支持中文子串匹配与拼写容错；回收站中的商品不包含在内。只支持页码分页，不支持 cursor 与 sort",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "搜索商品",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "搜索关键词",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "供应商ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分类ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[price][lte]=100",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "搜索成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/product.SearchResult"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取已删除的商品，查询参数同列表接口",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "获取回收站中的商品",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "供应商ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分类ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "商品类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/product.Product"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取商品信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "获取单个商品",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/product.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "全量更新商品信息，主键与创建时间不可修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "更新商品",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "商品信息",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/product.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "商品SKU已存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将商品移入回收站，可通过恢复接口恢复；商品不存在时同样返回成功",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "删除商品",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改商品，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "部分更新商品",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/product.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "设置商品价格，价格有变化时发布价格变更事件；与库存接口共用批量限流额度，适合同步脚本调用。供应商只能修改所属供应商的商品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "更新价格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "价格信息",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.PriceUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将回收站中的商品恢复",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "恢复商品",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/product.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在或不在回收站中",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "商品SKU已存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/stock": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "设置商品库存，库存有变化时发布库存调整事件；与价格接口共用批量限流额度，适合同步脚本调用。供应商只能修改所属供应商的商品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "更新库存",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "库存信息",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.StockUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "请求过于频繁",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/toggle": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "启用/禁用商品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "商品管理"
+                ],
+                "summary": "切换商品状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "商品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/product.Product"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "商品不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ready": {
+            "get": {
+                "description": "检查服务是否可以接收流量：服务正在关闭或数据库不可用时返回 503，供负载均衡与容器编排摘除实例",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "就绪检查",
+                "responses": {
+                    "200": {
+                        "description": "服务就绪",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "服务未就绪",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取各模块声明的设置项、JSON Schema、默认值与当前值，按设置键排序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统设置"
+                ],
+                "summary": "获取系统设置列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "命名空间，如 product",
+                        "name": "namespace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/settings.Item"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取设置的修改记录，包括修改前后的值与修改人，可按设置键、操作类型（update、reset）、修改人筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统设置"
+                ],
+                "summary": "获取系统设置修改记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[key]=user.password_policy",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/settings.Change"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/{key}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据设置键获取设置项及当前值",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统设置"
+                ],
+                "summary": "获取系统设置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设置键，如 product.low_stock_threshold",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/settings.Item"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "设置项不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改设置值并记录修改人，值需符合设置项的 JSON Schema；修改后所有实例立即生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统设置"
+                ],
+                "summary": "修改系统设置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设置键，如 product.low_stock_threshold",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新的值",
+                        "name": "setting",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/settings.UpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/settings.Item"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或值不符合 JSON Schema",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "设置项不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除修改过的值，恢复为模块声明的默认值并记录修改人",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统设置"
+                ],
+                "summary": "恢复系统设置的默认值",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "设置键，如 product.low_stock_threshold",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已恢复默认值",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/settings.Item"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "设置项不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shops": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取店铺列表，回收站中的店铺不包含在内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "获取店铺列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "供应商ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/shop.Shop"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建新的店铺",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "创建店铺",
+                "parameters": [
+                    {
+                        "description": "店铺信息",
+                        "name": "shop",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shop.Shop"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/shop.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shops/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取已删除的店铺，查询参数同列表接口",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "获取回收站中的店铺",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "供应商ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "是否启用",
+                        "name": "is_enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/shop.Shop"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shops/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取店铺信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "获取单个店铺",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "店铺ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/shop.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "店铺不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "全量更新店铺信息，主键与创建时间不可修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "更新店铺",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "店铺ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "店铺信息",
+                        "name": "shop",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shop.Shop"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/shop.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "店铺不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将店铺移入回收站，可通过恢复接口恢复；店铺不存在时同样返回成功",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "删除店铺",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "店铺ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改店铺，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "部分更新店铺",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "店铺ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/shop.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "店铺不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shops/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将回收站中的店铺恢复",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "恢复店铺",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "店铺ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/shop.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "店铺不存在或不在回收站中",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shops/{id}/toggle": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "启用/禁用店铺",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "店铺管理"
+                ],
+                "summary": "切换店铺状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "店铺ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/shop.Shop"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "店铺不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "以 text/event-stream 推送订阅主题内的变更事件，每条事件的 data 为 JSON 格式的 Event，id 为事件序号。\n主题格式：*（全部）、资源:*、资源:ID、资源:关联资源:ID，如 products:*、products:123、links:shop:5；不传 topics 时订阅全部。\n断线重连时携带 Last-Event-ID 请求头（EventSource 会自动携带）或 last_event_id 参数，补发缓冲中此后的事件；\n该事件已超出缓冲时先发送 reset 事件，客户端应重新加载数据。客户端接收过慢或服务关闭时发送 error 事件后断开。\n供应商类型的用户只能接收所属供应商的数据以及分类、属性等公共数据。",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "实时推送"
+                ],
+                "summary": "实时推送（SSE）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "products:*,links:shop:5",
+                        "description": "订阅主题，逗号分隔",
+                        "name": "topics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最后收到的事件序号",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最后收到的事件序号，无法设置请求头时使用",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "访问令牌，无法设置 Authorization 请求头时使用",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "事件流",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "订阅主题无效",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权订阅",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "实时推送未启用",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/ws": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "升级为 WebSocket 连接后，服务端以 JSON 文本消息发送 Frame：event 为变更事件，reset 表示断线期间的事件已超出回放范围，\nerror 为错误（连接被断开前也会发送），ping 为心跳，subscribed 为订阅变更后的全部主题。\n客户端可随时发送 ClientMessage 订阅或取消订阅主题，主题格式与 SSE 接口相同；topics 参数为连接时的初始主题。\n断线重连时通过 last_event_id 参数补发缓冲中此后的事件。",
+                "tags": [
+                    "实时推送"
+                ],
+                "summary": "实时推送（WebSocket）",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "products:123",
+                        "description": "初始订阅主题，逗号分隔",
+                        "name": "topics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最后收到的事件序号",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "访问令牌，浏览器 WebSocket 无法设置 Authorization 请求头时使用",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "切换为 WebSocket 协议",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Frame"
+                        }
+                    },
+                    "400": {
+                        "description": "订阅主题无效",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权订阅",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "实时推送未启用",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取供应商列表，回收站中的供应商不包含在内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "获取供应商列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/supplier.Supplier"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建新的供应商",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "创建供应商",
+                "parameters": [
+                    {
+                        "description": "供应商信息",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/supplier.Supplier"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/supplier.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "分页获取已删除的供应商，查询参数同列表接口",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "获取回收站中的供应商",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/supplier.Supplier"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取供应商信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "获取单个供应商",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "供应商ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/supplier.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "供应商不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "全量更新供应商信息，主键与创建时间不可修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "更新供应商",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "供应商ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "供应商信息",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/supplier.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/supplier.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "供应商不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将供应商移入回收站，可通过恢复接口恢复；供应商不存在时同样返回成功",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "删除供应商",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "供应商ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改供应商，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "部分更新供应商",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "供应商ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/supplier.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "供应商不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将回收站中的供应商恢复",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "恢复供应商",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "供应商ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/supplier.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "供应商不存在或不在回收站中",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/toggle": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "启用/禁用供应商",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "供应商管理"
+                ],
+                "summary": "切换供应商状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "供应商ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/supplier.Supplier"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "无权限",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "供应商不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取所有用户的列表，仅管理员与员工可调用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/user.UserResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "创建新用户，仅管理员可调用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "创建用户",
+                "parameters": [
+                    {
+                        "description": "用户信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateUserRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改当前登录用户的密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人中心"
+                ],
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "密码信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdatePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录或旧密码错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取当前登录用户的个人资料",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人中心"
+                ],
+                "summary": "获取个人资料",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新当前登录用户的个人资料",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人中心"
+                ],
+                "summary": "更新个人资料",
+                "parameters": [
+                    {
+                        "description": "用户信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "使用 JSON Merge Patch 或 JSON Patch 修改当前登录用户的个人资料，仅允许修改 name、email、phone、language",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人中心"
+                ],
+                "summary": "部分更新个人资料",
+                "parameters": [
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取用户信息，仅管理员与员工可调用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取单个用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新用户信息，仅管理员可调用，修改自己的资料使用 /users/profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "用户信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除指定用户，仅管理员可调用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "仅管理员可调用，使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改用户，只写入变更的字段。仅允许修改 name、email、phone、language",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "部分更新用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "补丁内容",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "JSON Patch test 操作未通过",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "请求体过大",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/access": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "修改用户类型与所属供应商，仅管理员可调用，用户重新登录后生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "修改用户访问权限",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "访问权限",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateAccessRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/user.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "用户不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取 Webhook 订阅列表，不含签名密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "获取 Webhook 订阅列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[is_enabled]=true",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/webhook.Subscription"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "注册接收地址与事件类型。投递请求带有 X-Webhook-Signature 签名头，值为 sha256=HMAC-SHA256(密钥, X-Webhook-Timestamp + \".\" + 请求体) 的十六进制；密钥只在此处返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "创建 Webhook 订阅",
+                "parameters": [
+                    {
+                        "description": "订阅信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.SubscriptionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.SecretResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未登录",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "权限不足",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取超过最大投递次数仍未成功的投递记录，可通过重新投递接口再次发送",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "获取 Webhook 死信",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[subscription_id]=1",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/webhook.Delivery"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取投递记录，可按订阅、事件、状态（pending、succeeded、dead）筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "获取 Webhook 投递记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标，传入后忽略页码",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段，多个用逗号分隔，前缀-表示降序",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "筛选条件，格式 filter[字段][操作符]=值，如 filter[status]=pending",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pagination.Page"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/webhook.Delivery"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取投递记录及每次投递的日志（响应状态码、错误、耗时、响应内容）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "获取 Webhook 投递详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "投递记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Delivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "投递记录不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "将投递记录（通常是死信）重置为待投递并清零投递次数，由后台立即重新发送",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "重新投递 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "投递记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "已重新排队",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Delivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "投递记录不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "获取全部事件类型，订阅时还可使用 资源.*（如 product.*）或 * 订阅多个事件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "获取可订阅的事件类型",
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "根据ID获取 Webhook 订阅，不含签名密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "获取单个 Webhook 订阅",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "订阅ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订阅不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "更新 Webhook 订阅；传入 secret 时更换签名密钥并在响应中返回",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "更新 Webhook 订阅",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "订阅ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "订阅信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhook.SubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.SecretResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订阅不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "删除 Webhook 订阅，未投递的记录将进入死信",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "删除 Webhook 订阅",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "订阅ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/toggle": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "启用/停用 Webhook 订阅，停用期间不产生新的投递",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook 管理"
+                ],
+                "summary": "切换 Webhook 订阅状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "订阅ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "幂等键，重试时携带相同的值，避免重复执行",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "切换成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/webhook.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "订阅不存在",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "attribute.Attribute": {
+            "description": "属性信息",
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "所属分类ID",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "data_type": {
+                    "description": "数据类型",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "删除时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "is_enabled": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "is_required": {
+                    "description": "是否必填",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "属性名称",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "属性备注",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "attribute.ProductAttribute": {
+            "description": "商品属性值信息",
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "description": "属性ID",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "deleted_at": {
                     "description": "删除时间",
                     "type": "string"
                 },
@@ -2900,21 +8879,172 @@ const docTemplate = `{
                     "description": "主键ID",
                     "type": "integer"
                 },
+                "product_id": {
+                    "description": "商品ID",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "属性值",
+                    "type": "string"
+                }
+            }
+        },
+        "batch.Operation": {
+            "description": "单个子请求，路径与请求体中可用 {{ref.字段}} 引用前序操作响应 data 中的值",
+            "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
+            "properties": {
+                "body": {
+                    "description": "请求体",
+                    "type": "object"
+                },
+                "headers": {
+                    "description": "额外请求头，如 JSON Patch 的 Content-Type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
+                    "description": "请求方法：GET、POST、PUT、PATCH、DELETE",
+                    "type": "string",
+                    "example": "POST"
+                },
+                "path": {
+                    "description": "相对 /api/v1 的路径，可含查询参数",
+                    "type": "string",
+                    "example": "/products"
+                },
+                "ref": {
+                    "description": "操作名称，后续操作通过 {{p1.id}} 引用其响应数据",
+                    "type": "string",
+                    "example": "p1"
+                }
+            }
+        },
+        "batch.Request": {
+            "description": "按顺序执行的一组子请求",
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "atomic": {
+                    "description": "是否在同一事务中执行，任一操作失败时全部回滚并停止执行",
+                    "type": "boolean",
+                    "example": true
+                },
+                "operations": {
+                    "description": "按顺序执行的操作",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/batch.Operation"
+                    }
+                }
+            }
+        },
+        "batch.Response": {
+            "description": "各操作按请求顺序的执行结果",
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "description": "是否为原子模式",
+                    "type": "boolean",
+                    "example": true
+                },
+                "results": {
+                    "description": "各操作结果",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/batch.Result"
+                    }
+                },
+                "rolled_back": {
+                    "description": "原子模式下有操作失败，全部变更已回滚",
+                    "type": "boolean",
+                    "example": false
+                },
+                "success": {
+                    "description": "是否全部操作均成功",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "batch.Result": {
+            "description": "子请求的响应状态码与响应体",
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "响应体",
+                    "type": "object"
+                },
+                "method": {
+                    "description": "请求方法",
+                    "type": "string",
+                    "example": "POST"
+                },
+                "path": {
+                    "description": "替换引用后的实际路径",
+                    "type": "string",
+                    "example": "/products"
+                },
+                "ref": {
+                    "description": "操作名称",
+                    "type": "string",
+                    "example": "p1"
+                },
+                "status": {
+                    "description": "HTTP 状态码",
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "category.Category": {
+            "description": "分类信息",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "删除时间，非空表示在回收站中",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "description": "分类描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
                 "is_enabled": {
                     "description": "是否启用",
                     "type": "boolean"
                 },
-                "is_required": {
-                    "description": "是否必填",
-                    "type": "boolean"
+                "level_remark": {
+                    "description": "层级备注",
+                    "type": "string"
                 },
                 "name": {
-                    "description": "属性名称",
+                    "description": "分类名称",
                     "type": "string"
                 },
-                "remark": {
-                    "description": "属性备注",
-                    "type": "string"
+                "parent_id": {
+                    "description": "父级分类ID",
+                    "type": "integer"
                 },
                 "updated_at": {
                     "description": "更新时间",
@@ -2922,56 +9052,275 @@ const docTemplate = `{
                 }
             }
         },
-        "attribute.ProductAttribute": {
-            "description": "商品属性值信息",
+        "erp_backend_modules_graphql.Response": {
+            "description": "执行结果，字段出错时该字段为 null 并在 errors 中给出原因",
             "type": "object",
             "properties": {
-                "attribute_id": {
-                    "description": "属性ID",
+                "data": {
+                    "description": "查询结果，请求未通过校验时不返回",
+                    "type": "object"
+                },
+                "errors": {
+                    "description": "错误列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graphql.Error"
+                    }
+                }
+            }
+        },
+        "errcode.FieldError": {
+            "description": "字段校验失败详情",
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "字段名（JSON 字段名）",
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "description": "错误信息",
+                    "type": "string",
+                    "example": "必须是有效的邮箱地址"
+                },
+                "rule": {
+                    "description": "校验规则",
+                    "type": "string",
+                    "example": "email"
+                }
+            }
+        },
+        "graphql.Error": {
+            "description": "错误信息、在查询中的位置与在结果中的路径",
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "description": "业务错误码等扩展信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/graphql.ErrorExtensions"
+                        }
+                    ]
+                },
+                "locations": {
+                    "description": "在查询语句中的位置",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graphql.Location"
+                    }
+                },
+                "message": {
+                    "description": "提示信息，按请求语言翻译",
+                    "type": "string",
+                    "example": "商品不存在"
+                },
+                "path": {
+                    "description": "出错字段在结果中的路径",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "graphql.ErrorExtensions": {
+            "description": "与 REST 接口一致的业务错误码与字段详情",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务错误码，见 GET /error-codes",
+                    "type": "string",
+                    "example": "PRODUCT_NOT_FOUND"
+                },
+                "details": {
+                    "description": "字段级错误详情",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errcode.FieldError"
+                    }
+                },
+                "status": {
+                    "description": "对应 REST 接口的 HTTP 状态码",
+                    "type": "integer",
+                    "example": 404
+                },
+                "trace_id": {
+                    "description": "追踪ID",
+                    "type": "string"
+                }
+            }
+        },
+        "graphql.Location": {
+            "description": "行号与列号",
+            "type": "object",
+            "properties": {
+                "column": {
+                    "description": "列号，从 1 开始",
+                    "type": "integer"
+                },
+                "line": {
+                    "description": "行号，从 1 开始",
+                    "type": "integer"
+                }
+            }
+        },
+        "graphql.Request": {
+            "description": "标准 GraphQL 请求，GET 请求时以同名查询参数传递（variables 为 JSON 字符串）",
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "description": "文档包含多个操作时要执行的操作名称",
+                    "type": "string"
+                },
+                "query": {
+                    "description": "查询语句",
+                    "type": "string",
+                    "example": "{ products(pageSize: 5) { items { id name supplier { name } } } }"
+                },
+                "variables": {
+                    "description": "变量",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "jobs.Job": {
+            "description": "后台任务",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "已执行次数",
                     "type": "integer"
                 },
                 "created_at": {
-                    "description": "创建时间",
+                    "description": "入队时间",
                     "type": "string"
                 },
-                "deleted_at": {
-                    "description": "删除时间",
+                "finished_at": {
+                    "description": "结束时间（成功、失败或取消）",
                     "type": "string"
                 },
                 "id": {
                     "description": "主键ID",
                     "type": "integer"
                 },
-                "product_id": {
-                    "description": "商品ID",
+                "kind": {
+                    "description": "任务类型，如 product.low_stock_scan",
+                    "type": "string"
+                },
+                "last_error": {
+                    "description": "最近一次错误",
+                    "type": "string"
+                },
+                "locked_by": {
+                    "description": "执行实例",
+                    "type": "string"
+                },
+                "locked_until": {
+                    "description": "执行租约到期时间，实例在租约内退出时任务到期后会被重新领取",
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "description": "最大执行次数",
                     "type": "integer"
                 },
-                "updated_at": {
-                    "description": "更新时间",
+                "payload": {
+                    "description": "任务参数",
+                    "type": "object"
+                },
+                "run_at": {
+                    "description": "最早执行时间，重试时为下次执行时间",
                     "type": "string"
                 },
-                "value": {
-                    "description": "属性值",
+                "schedule": {
+                    "description": "产生任务的定时计划，手动入队时为空",
+                    "type": "string"
+                },
+                "started_at": {
+                    "description": "最近一次开始执行时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：pending、running、succeeded、failed、cancelled",
+                    "type": "string"
+                },
+                "unique_key": {
+                    "description": "唯一键，同一唯一键同时只有一个未结束的任务",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "category.Category": {
-            "description": "分类信息",
+        "jobs.Schedule": {
+            "description": "后台任务的定时计划",
             "type": "object",
             "properties": {
                 "created_at": {
                     "description": "创建时间",
                     "type": "string"
                 },
-                "deleted_at": {
-                    "description": "删除时间",
+                "cron": {
+                    "description": "执行规则，如 0 * * * *、@daily、@every 10m",
                     "type": "string"
                 },
-                "description": {
-                    "description": "分类描述",
+                "is_enabled": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "任务类型",
+                    "type": "string"
+                },
+                "last_job_id": {
+                    "description": "上次产生的任务ID",
+                    "type": "integer"
+                },
+                "last_run_at": {
+                    "description": "上次入队时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "计划名称",
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "description": "下次执行时间",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "任务参数",
+                    "type": "object"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "link.Link": {
+            "description": "链接信息",
+            "type": "object",
+            "properties": {
+                "base_remark": {
+                    "description": "基础备注",
+                    "type": "string"
+                },
+                "category_id": {
+                    "description": "类目ID",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
                     "type": "string"
                 },
+                "deleted_at": {
+                    "description": "删除时间，非空表示在回收站中",
+                    "type": "string",
+                    "format": "date-time"
+                },
                 "id": {
                     "description": "主键ID",
                     "type": "integer"
@@ -2980,43 +9329,126 @@ const docTemplate = `{
                     "description": "是否启用",
                     "type": "boolean"
                 },
-                "level_remark": {
-                    "description": "层级备注",
+                "name": {
+                    "description": "链接名称",
                     "type": "string"
                 },
-                "name": {
-                    "description": "分类名称",
+                "remark": {
+                    "description": "链接备注",
                     "type": "string"
                 },
-                "parent_id": {
-                    "description": "父级分类ID",
+                "shop_id": {
+                    "description": "店铺ID",
                     "type": "integer"
                 },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
+                },
+                "url": {
+                    "description": "链接地址",
+                    "type": "string"
+                }
+            }
+        },
+        "module.Permission": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "权限编码，格式为 模块.操作，如 product.write",
+                    "type": "string",
+                    "example": "product.write"
+                },
+                "description": {
+                    "description": "权限说明",
+                    "type": "string",
+                    "example": "创建、修改与删除商品"
+                },
+                "user_types": {
+                    "description": "拥有该权限的用户类型，为空表示所有登录用户",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "admin",
+                        "管理员",
+                        "员工"
+                    ]
+                }
+            }
+        },
+        "pagination.Page": {
+            "description": "分页数据",
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "数据列表"
+                },
+                "next_cursor": {
+                    "description": "下一页游标",
+                    "type": "string"
+                },
+                "page": {
+                    "description": "当前页码（游标模式下为0）",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "总条数",
+                    "type": "integer"
+                }
+            }
+        },
+        "product.DynamicAttributes": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "product.PriceUpdate": {
+            "type": "object",
+            "required": [
+                "price"
+            ],
+            "properties": {
+                "price": {
+                    "description": "价格",
+                    "type": "number",
+                    "example": 99.9
                 }
             }
         },
-        "link.Link": {
-            "description": "链接信息",
+        "product.Product": {
+            "description": "商品信息",
             "type": "object",
             "properties": {
-                "base_remark": {
-                    "description": "基础备注",
-                    "type": "string"
-                },
                 "category_id": {
-                    "description": "类目ID",
+                    "description": "分类ID",
                     "type": "integer"
                 },
                 "created_at": {
                     "description": "创建时间",
                     "type": "string"
                 },
+                "currency": {
+                    "description": "价格币种（ISO 4217），创建时为空使用 product.default_currency",
+                    "type": "string",
+                    "example": "CNY"
+                },
                 "deleted_at": {
-                    "description": "删除时间",
-                    "type": "string"
+                    "description": "删除时间，非空表示在回收站中",
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "dynamic_attrs": {
+                    "description": "动态属性",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/product.DynamicAttributes"
+                        }
+                    ]
                 },
                 "id": {
                     "description": "主键ID",
@@ -3027,33 +9459,41 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "name": {
-                    "description": "链接名称",
+                    "description": "商品名称",
                     "type": "string"
                 },
+                "price": {
+                    "description": "商品价格",
+                    "type": "number"
+                },
                 "remark": {
-                    "description": "链接备注",
+                    "description": "商品备注",
                     "type": "string"
                 },
-                "shop_id": {
-                    "description": "店铺ID",
+                "sku": {
+                    "description": "商品SKU，回收站中的商品不占用",
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "商品库存",
+                    "type": "integer"
+                },
+                "supplier_id": {
+                    "description": "供应商ID",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "商品类型",
                     "type": "integer"
                 },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
-                },
-                "url": {
-                    "description": "链接地址",
-                    "type": "string"
                 }
             }
         },
-        "product.DynamicAttributes": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "product.Product": {
-            "description": "商品信息",
+        "product.SearchResult": {
+            "description": "商品搜索结果，包含相关度得分与高亮片段",
             "type": "object",
             "properties": {
                 "category_id": {
@@ -3064,9 +9504,15 @@ const docTemplate = `{
                     "description": "创建时间",
                     "type": "string"
                 },
+                "currency": {
+                    "description": "价格币种（ISO 4217），创建时为空使用 product.default_currency",
+                    "type": "string",
+                    "example": "CNY"
+                },
                 "deleted_at": {
-                    "description": "删除时间",
-                    "type": "string"
+                    "description": "删除时间，非空表示在回收站中",
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "dynamic_attrs": {
                     "description": "动态属性",
@@ -3076,6 +9522,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "highlights": {
+                    "description": "高亮片段，命中词以 \u003cem\u003e 包裹",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "description": "主键ID",
                     "type": "integer"
@@ -3096,8 +9549,12 @@ const docTemplate = `{
                     "description": "商品备注",
                     "type": "string"
                 },
+                "score": {
+                    "description": "相关度得分",
+                    "type": "number"
+                },
                 "sku": {
-                    "description": "商品SKU",
+                    "description": "商品SKU，回收站中的商品不占用",
                     "type": "string"
                 },
                 "stock": {
@@ -3118,18 +9575,222 @@ const docTemplate = `{
                 }
             }
         },
+        "product.StockUpdate": {
+            "type": "object",
+            "required": [
+                "stock"
+            ],
+            "properties": {
+                "stock": {
+                    "description": "库存数量",
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
         "response.Response": {
             "type": "object",
             "properties": {
                 "code": {
+                    "description": "0 表示成功，否则为 HTTP 状态码",
                     "type": "integer"
                 },
-                "data": {},
+                "data": {
+                    "description": "响应数据"
+                },
+                "details": {
+                    "description": "字段级错误详情",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/errcode.FieldError"
+                    }
+                },
+                "error_code": {
+                    "description": "业务错误码，见 GET /error-codes",
+                    "type": "string"
+                },
                 "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "追踪ID，错误响应时返回，便于排查",
+                    "type": "string"
+                }
+            }
+        },
+        "settings.Change": {
+            "description": "系统设置的修改记录",
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "操作类型：update 修改、reset 恢复默认值",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "修改时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "设置键",
+                    "type": "string"
+                },
+                "new_value": {
+                    "description": "修改后的值",
+                    "type": "object"
+                },
+                "old_value": {
+                    "description": "修改前的值",
+                    "type": "object"
+                },
+                "user_id": {
+                    "description": "修改人ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "settings.Item": {
+            "description": "系统设置项",
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "默认值",
+                    "type": "object"
+                },
+                "description": {
+                    "description": "说明",
+                    "type": "string",
+                    "example": "低库存阈值"
+                },
+                "is_default": {
+                    "description": "是否使用默认值（未修改过或已恢复默认值）",
+                    "type": "boolean"
+                },
+                "key": {
+                    "description": "设置键",
+                    "type": "string",
+                    "example": "product.low_stock_threshold"
+                },
+                "namespace": {
+                    "description": "命名空间",
+                    "type": "string",
+                    "example": "product"
+                },
+                "schema": {
+                    "description": "值的 JSON Schema",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/settings.Schema"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "description": "最近修改时间",
+                    "type": "string"
+                },
+                "updated_by": {
+                    "description": "最近修改人ID",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "当前值",
+                    "type": "object"
+                }
+            }
+        },
+        "settings.Schema": {
+            "type": "object",
+            "properties": {
+                "additionalProperties": {
+                    "description": "是否允许 properties 之外的字段，为空表示允许",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "说明",
+                    "type": "string"
+                },
+                "enum": {
+                    "description": "允许的取值",
+                    "type": "array",
+                    "items": {}
+                },
+                "format": {
+                    "description": "字符串格式",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "数组元素的 Schema",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/settings.Schema"
+                        }
+                    ]
+                },
+                "maxItems": {
+                    "description": "数组最多元素数",
+                    "type": "integer"
+                },
+                "maxLength": {
+                    "description": "字符串最大长度",
+                    "type": "integer"
+                },
+                "maximum": {
+                    "description": "数值上限（含）",
+                    "type": "number"
+                },
+                "minItems": {
+                    "description": "数组最少元素数",
+                    "type": "integer"
+                },
+                "minLength": {
+                    "description": "字符串最小长度",
+                    "type": "integer"
+                },
+                "minimum": {
+                    "description": "数值下限（含）",
+                    "type": "number"
+                },
+                "pattern": {
+                    "description": "字符串需匹配的正则表达式",
+                    "type": "string"
+                },
+                "properties": {
+                    "description": "对象字段的 Schema",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/settings.Schema"
+                    }
+                },
+                "required": {
+                    "description": "对象必填字段",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "类型：string、integer、number、boolean、object、array，为空表示不限",
                     "type": "string"
                 }
             }
         },
+        "settings.UpdateRequest": {
+            "description": "修改系统设置的请求参数",
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "value": {
+                    "description": "新的值，需符合设置项的 JSON Schema",
+                    "type": "object"
+                }
+            }
+        },
         "shop.Shop": {
             "description": "店铺信息",
             "type": "object",
@@ -3139,8 +9800,9 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "deleted_at": {
-                    "description": "删除时间",
-                    "type": "string"
+                    "description": "删除时间，非空表示在回收站中",
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "id": {
                     "description": "主键ID",
@@ -3168,6 +9830,77 @@ const docTemplate = `{
                 }
             }
         },
+        "stream.Event": {
+            "description": "实时推送的变更事件",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "事件发生时间",
+                    "type": "string"
+                },
+                "data": {
+                    "description": "事件内容，与 Webhook 的 data 相同",
+                    "type": "object"
+                },
+                "id": {
+                    "description": "事件序号，断线重连时作为 Last-Event-ID",
+                    "type": "integer",
+                    "example": 1024
+                },
+                "resource": {
+                    "description": "资源名称",
+                    "type": "string",
+                    "example": "products"
+                },
+                "resource_id": {
+                    "description": "资源ID",
+                    "type": "integer",
+                    "example": 123
+                },
+                "type": {
+                    "description": "事件类型",
+                    "type": "string",
+                    "example": "product.stock_changed"
+                }
+            }
+        },
+        "stream.Frame": {
+            "description": "WebSocket 连接中服务端发送的消息",
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "错误信息，type 为 error、reset 时返回",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    ]
+                },
+                "event": {
+                    "description": "变更事件，type 为 event 时返回",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/stream.Event"
+                        }
+                    ]
+                },
+                "topics": {
+                    "description": "当前订阅的主题，type 为 subscribed 时返回",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products:*"
+                    ]
+                },
+                "type": {
+                    "description": "消息类型：event、reset、error、ping、subscribed",
+                    "type": "string",
+                    "example": "event"
+                }
+            }
+        },
         "supplier.Supplier": {
             "description": "供应商信息",
             "type": "object",
@@ -3177,8 +9910,9 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "deleted_at": {
-                    "description": "删除时间",
-                    "type": "string"
+                    "description": "删除时间，非空表示在回收站中",
+                    "type": "string",
+                    "format": "date-time"
                 },
                 "id": {
                     "description": "主键ID",
@@ -3189,21 +9923,71 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "name": {
-                    "description": "供应商名称",
-                    "type": "string"
-                },
-                "remark": {
-                    "description": "供应商备注",
-                    "type": "string"
+                    "description": "供应商名称",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "供应商备注",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "system.ErrorCodeInfo": {
+            "description": "业务错误码说明",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "业务错误码",
+                    "type": "string",
+                    "example": "PRODUCT_SKU_DUPLICATE"
+                },
+                "message": {
+                    "description": "提示信息（按请求语言）",
+                    "type": "string",
+                    "example": "商品SKU已存在"
+                },
+                "status": {
+                    "description": "HTTP 状态码",
+                    "type": "integer",
+                    "example": 409
+                }
+            }
+        },
+        "system.ModuleInfo": {
+            "description": "已启用的业务模块",
+            "type": "object",
+            "properties": {
+                "depends_on": {
+                    "description": "依赖的模块",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "supplier",
+                        "category"
+                    ]
+                },
+                "name": {
+                    "description": "模块名",
+                    "type": "string",
+                    "example": "product"
                 },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
+                "permissions": {
+                    "description": "模块定义的权限及拥有权限的用户类型",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/module.Permission"
+                    }
                 }
             }
         },
         "user.CreateUserRequest": {
-            "description": "创建用户的请求参数",
+            "description": "创建用户的请求参数，仅管理员可调用",
             "type": "object",
             "required": [
                 "email",
@@ -3218,6 +10002,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "zhangsan@example.com"
                 },
+                "language": {
+                    "description": "语言偏好",
+                    "type": "string",
+                    "enum": [
+                        "zh-CN",
+                        "en-US"
+                    ],
+                    "example": "zh-CN"
+                },
                 "name": {
                     "description": "用户名",
                     "type": "string",
@@ -3234,6 +10027,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "13800138000"
                 },
+                "supplier_id": {
+                    "description": "所属供应商ID，供应商类型的用户需设置",
+                    "type": "integer",
+                    "example": 1
+                },
                 "user_type": {
                     "description": "用户类型",
                     "type": "string",
@@ -3285,6 +10083,73 @@ const docTemplate = `{
                 }
             }
         },
+        "user.RegisterRequest": {
+            "description": "用户注册的请求参数，注册的用户为默认类型，用户类型由管理员修改",
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "邮箱",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "zhangsan@example.com"
+                },
+                "language": {
+                    "description": "语言偏好",
+                    "type": "string",
+                    "enum": [
+                        "zh-CN",
+                        "en-US"
+                    ],
+                    "example": "zh-CN"
+                },
+                "name": {
+                    "description": "用户名",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "张三"
+                },
+                "password": {
+                    "description": "密码，需符合密码策略",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "phone": {
+                    "description": "电话号码",
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "13800138000"
+                }
+            }
+        },
+        "user.UpdateAccessRequest": {
+            "description": "修改用户类型与所属供应商的请求参数，仅管理员可调用",
+            "type": "object",
+            "required": [
+                "user_type"
+            ],
+            "properties": {
+                "supplier_id": {
+                    "description": "所属供应商ID，供应商类型的用户需设置",
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_type": {
+                    "description": "用户类型",
+                    "type": "string",
+                    "enum": [
+                        "管理员",
+                        "供应商",
+                        "员工"
+                    ],
+                    "example": "供应商"
+                }
+            }
+        },
         "user.UpdatePasswordRequest": {
             "description": "修改密码的请求参数",
             "type": "object",
@@ -3307,13 +10172,12 @@ const docTemplate = `{
             }
         },
         "user.UpdateUserRequest": {
-            "description": "更新用户的请求参数",
+            "description": "更新用户的请求参数，用户类型与所属供应商只能由管理员通过修改访问权限接口变更，密码通过修改密码接口变更",
             "type": "object",
             "required": [
                 "email",
                 "name",
-                "phone",
-                "user_type"
+                "phone"
             ],
             "properties": {
                 "email": {
@@ -3321,31 +10185,24 @@ const docTemplate = `{
                     "type": "string",
                     "example": "zhangsan@example.com"
                 },
+                "language": {
+                    "description": "语言偏好",
+                    "type": "string",
+                    "enum": [
+                        "zh-CN",
+                        "en-US"
+                    ],
+                    "example": "en-US"
+                },
                 "name": {
                     "description": "用户名",
                     "type": "string",
                     "example": "张三"
                 },
-                "password": {
-                    "description": "密码（可选）",
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "123456"
-                },
                 "phone": {
                     "description": "电话号码",
                     "type": "string",
                     "example": "13800138000"
-                },
-                "user_type": {
-                    "description": "用户类型",
-                    "type": "string",
-                    "enum": [
-                        "管理员",
-                        "供应商",
-                        "员工"
-                    ],
-                    "example": "员工"
                 }
             }
         },
@@ -3373,6 +10230,15 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": false
                 },
+                "language": {
+                    "description": "语言偏好",
+                    "type": "string",
+                    "example": "zh-CN"
+                },
+                "locked_until": {
+                    "description": "登录失败锁定截止时间，为空表示未锁定",
+                    "type": "string"
+                },
                 "name": {
                     "description": "用户名",
                     "type": "string",
@@ -3383,6 +10249,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "13800138000"
                 },
+                "supplier_id": {
+                    "description": "所属供应商ID",
+                    "type": "integer",
+                    "example": 1
+                },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string",
@@ -3394,6 +10265,255 @@ const docTemplate = `{
                     "example": "员工"
                 }
             }
+        },
+        "webhook.Attempt": {
+            "description": "Webhook 单次投递的请求结果",
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "第几次投递",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "投递时间",
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "description": "投递记录ID",
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "description": "耗时（毫秒）",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "错误信息",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "response_body": {
+                    "description": "响应内容，最多保留 2KB",
+                    "type": "string"
+                },
+                "status_code": {
+                    "description": "响应状态码，请求失败时为 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "webhook.Delivery": {
+            "description": "Webhook 投递记录",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "已投递次数",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "投递成功时间",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "事件类型",
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "事件ID，同一事件的各订阅投递相同",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "最近一次错误",
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "description": "最近一次响应状态码",
+                    "type": "integer"
+                },
+                "logs": {
+                    "description": "投递日志",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhook.Attempt"
+                    }
+                },
+                "next_attempt_at": {
+                    "description": "下次投递时间",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "投递内容",
+                    "type": "object"
+                },
+                "status": {
+                    "description": "状态：pending、succeeded、dead",
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "description": "订阅ID",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.SecretResponse": {
+            "description": "订阅信息与签名密钥",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "删除时间",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "订阅的事件类型",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "is_enabled": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "订阅名称",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "签名密钥，请妥善保存",
+                    "type": "string",
+                    "example": "whsec_3f1c..."
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "接收地址",
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.Subscription": {
+            "description": "Webhook 订阅信息",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "删除时间",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "订阅的事件类型",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "is_enabled": {
+                    "description": "是否启用",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "订阅名称",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "接收地址",
+                    "type": "string"
+                }
+            }
+        },
+        "webhook.SubscriptionRequest": {
+            "description": "Webhook 订阅的请求参数",
+            "type": "object",
+            "required": [
+                "events",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "description": "订阅的事件类型，支持 资源.* 与 *",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "product.*",
+                        "link.toggled"
+                    ]
+                },
+                "is_enabled": {
+                    "description": "是否启用，默认启用",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "description": "订阅名称",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "商城同步"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "example": "商品与链接变更推送到商城"
+                },
+                "secret": {
+                    "description": "签名密钥，创建时为空则自动生成，更新时为空表示不更换",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 16
+                },
+                "url": {
+                    "description": "接收地址，需为 http 或 https",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "https://shop.example.com/hooks/erp"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -3413,7 +10533,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "ERP 系统 API",
-	Description:      "ERP 系统后端 API 文档",
+	Description:      "ERP 系统后端 API 文档。错误响应中的 error_code 为稳定的业务错误码，完整列表见 GET /error-codes；提示信息语言由 lang 参数、用户偏好或 Accept-Language 决定（zh-CN、en-US）",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
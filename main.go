@@ -1,34 +1,45 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
-	"erp_backend/modules/attribute"
-	"erp_backend/modules/category"
-	"erp_backend/modules/link"
-	"erp_backend/modules/product"
-	"erp_backend/modules/shop"
-	"erp_backend/modules/supplier"
-	"erp_backend/modules/system"
-	"erp_backend/modules/user"
+	"erp_backend/pkg/config"
 	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/idempotency"
+	"erp_backend/pkg/jobs"
+	"erp_backend/pkg/logger"
+	"erp_backend/pkg/metrics"
 	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/ratelimit"
 	"erp_backend/pkg/response"
+	"erp_backend/pkg/rpc"
+	"erp_backend/pkg/server"
+	"erp_backend/pkg/settings"
+	"erp_backend/pkg/tracing"
 
-	_ "erp_backend/docs" // 导入 swagger docs
+	_ "erp_backend/docs"    // 导入 swagger docs
+	_ "erp_backend/modules" // 导入并注册全部业务模块
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 // @title ERP 系统 API
 // @version 1.0
-// @description ERP 系统后端 API 文档
+// @description ERP 系统后端 API 文档。错误响应中的 error_code 为稳定的业务错误码，完整列表见 GET /error-codes；提示信息语言由 lang 参数、用户偏好或 Accept-Language 决定（zh-CN、en-US）
 // @host localhost:8080
 // @BasePath /api/v1
 
@@ -49,20 +60,40 @@ func main() {
 		log.Println("未找到.env文件，使用默认配置")
 	}
 
+	os.Exit(run(os.Args[1:]))
+}
+
+// serve 启动服务，收到 SIGINT/SIGTERM 后优雅关闭
+func serve(modules []module.Module) {
+	// 初始化日志
+	logCloser, err := logger.Init(config.GetLogConfig())
+	if err != nil {
+		log.Fatalf("日志初始化失败: %v", err)
+	}
+	defer logCloser.Close()
+
+	// 初始化链路追踪
+	shutdownTracing, err := tracing.Init(context.Background(), config.GetTracingConfig())
+	if err != nil {
+		log.Fatalf("链路追踪初始化失败: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// 设置Gin模式
 	if os.Getenv("GIN_MODE") == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	// 初始化数据库
-	db, err := database.Connect()
+	db, err := connectDB()
 	if err != nil {
-		log.Fatalf("数据库连接失败: %v", err)
+		log.Fatalf("数据库初始化失败: %v", err)
 	}
+	log.Printf("已启用模块: %s", moduleNames(modules))
 
 	// 自动迁移数据库结构
 	if os.Getenv("SKIP_MIGRATION") != "true" {
-		if err := autoMigrate(db); err != nil {
+		if err := autoMigrate(db, modules); err != nil {
 			log.Fatalf("数据库迁移失败: %v", err)
 		}
 	} else {
@@ -71,7 +102,7 @@ func main() {
 
 	// 初始化种子数据
 	if os.Getenv("SKIP_SEED") != "true" {
-		if err := seedData(db); err != nil {
+		if err := seedData(db, modules); err != nil {
 			log.Fatal("种子数据初始化失败:", err)
 		}
 	} else {
@@ -79,127 +110,297 @@ func main() {
 	}
 
 	// 创建Gin引擎
-	r := gin.Default()
+	r := gin.New()
+	// gin.Context 作为 context 使用时回退到请求 context，使 SQL 日志与追踪能取到请求的 span
+	r.ContextWithFallback = true
 
 	// 添加中间件
-	r.Use(middleware.CORSMiddleware())
+	r.Use(middleware.RequestID(), middleware.Tracing(), middleware.AccessLog(), middleware.Metrics(), gin.Recovery())
+	r.Use(middleware.CORS(config.GetCORSConfig(), config.GetCORSGroups()...))
+	r.Use(middleware.APIKey())
+	r.Use(middleware.RateLimit(ratelimit.PolicyDefault, "/api/v1/health", "/api/v1/ready", config.GetMetricsConfig().Path))
+	r.Use(middleware.Idempotency())
+	r.Use(middleware.Maintenance("/api/v1/auth/login", "/api/v1/settings/:key", "/api/v1/batch", "/api/v1/graphql"))
+
+	// 创建HTTP服务
+	srv := server.New(config.GetServerConfig(), r)
+
+	// 限流，API 密钥与 gRPC 服务共用
+	middleware.SetAPIKeys(config.GetGRPCConfig().APIKeys)
+	setupRateLimit(srv, db)
+
+	// 幂等键
+	setupIdempotency(srv, db)
+
+	// 模块装配时可用的公共依赖
+	app := &module.App{
+		DB:       db,
+		Router:   r,
+		Relay:    events.NewRelay(db, config.GetOutboxConfig()),
+		Queue:    jobs.NewQueue(db, config.GetJobsConfig()),
+		Settings: newSettings(db, modules),
+	}
+
+	// 其他实例修改设置后使本实例的设置缓存失效
+	srv.Go("settings-listener", app.Settings.Listen)
+
+	// 模块后台任务（Webhook 投递、实时推送等），需在启动事件转发前订阅事件
+	setupJobs(srv, app, modules)
+
+	// 持久化任务队列，任务类型与定时计划已在 setupJobs 中注册
+	setupQueue(srv, app.Queue)
+
+	// 领域事件转发
+	setupEvents(srv, app.Relay)
 
 	// 设置路由
-	setupRoutes(r, db)
+	setupRoutes(r, app, modules)
+
+	// 监控指标
+	setupMetrics(srv, r, app, modules)
+
+	// gRPC 服务
+	setupGRPC(srv, app, modules)
 
 	// 添加 Swagger 文档路由
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// 获取端口
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// 启动服务器，收到 SIGINT/SIGTERM 后优雅关闭
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := srv.Run(ctx); err != nil {
+		log.Printf("服务器异常退出: %v", err)
 	}
 
-	// 启动服务器
-	log.Printf("服务器启动在端口 %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("启动服务器失败:", err)
+	// 关闭数据库连接
+	if err := database.CloseDatabase(); err != nil {
+		log.Printf("关闭数据库连接失败: %v", err)
 	}
+	log.Println("服务器已停止")
 }
 
-// autoMigrate 自动迁移数据库结构
-func autoMigrate(db *gorm.DB) error {
+// autoMigrate 自动迁移数据库结构：先迁移公共模型与各模块的模型，再按模块顺序执行额外迁移
+func autoMigrate(db *gorm.DB, modules []module.Module) error {
 	log.Println("开始数据库结构迁移...")
 
-	err := db.AutoMigrate(
-		&user.User{}, // 添加用户模型
-		&supplier.Supplier{},
-		&shop.Shop{},
-		&product.Product{},
-		&category.Category{},
-		&link.Link{},
-		&attribute.Attribute{},
-		&attribute.ProductAttribute{},
-	)
-
-	if err != nil {
+	models := []interface{}{
+		&idempotency.Record{},
+		&events.Message{},
+		&jobs.Job{},
+		&jobs.Schedule{},
+		&settings.Setting{},
+		&settings.Change{},
+	}
+	for _, m := range modules {
+		models = append(models, m.Models()...)
+	}
+	if err := db.AutoMigrate(models...); err != nil {
 		log.Printf("数据库迁移失败: %v", err)
 		return err
 	}
 
+	for _, m := range modules {
+		if err := m.Migrate(db); err != nil {
+			log.Printf("模块 %s 迁移失败: %v", m.Name(), err)
+			return err
+		}
+	}
+
 	log.Println("数据库结构迁移完成")
 	return nil
 }
 
-// seedData 初始化种子数据
-func seedData(db *gorm.DB) error {
+// seedData 按模块顺序初始化种子数据
+func seedData(db *gorm.DB, modules []module.Module) error {
 	log.Println("检查种子数据...")
 
-	// 检查是否已有数据
-	var userCount int64
-	db.Model(&user.User{}).Count(&userCount)
-	if userCount > 0 {
-		log.Println("用户表已有数据，跳过种子数据初始化")
-		return nil
+	for _, m := range modules {
+		if err := m.Seed(context.Background(), db); err != nil {
+			log.Printf("模块 %s 种子数据初始化失败: %v", m.Name(), err)
+			return err
+		}
 	}
 
-	log.Println("开始初始化种子数据...")
+	log.Println("种子数据检查完成")
+	return nil
+}
 
-	// 创建管理员用户
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("test1234"), bcrypt.DefaultCost)
+// connectDB 连接数据库并注册追踪与事务插件，服务与运维命令共用
+func connectDB() (*gorm.DB, error) {
+	db, err := database.Connect()
 	if err != nil {
-		log.Printf("密码加密失败: %v", err)
-		return err
+		return nil, err
+	}
+	if err := tracing.RegisterDB(db); err != nil {
+		return nil, fmt.Errorf("数据库追踪注册失败: %w", err)
+	}
+	if err := database.RegisterTx(db); err != nil {
+		return nil, fmt.Errorf("数据库事务插件注册失败: %w", err)
 	}
+	return db, nil
+}
 
-	adminUser := user.User{
-		Name:     "evansun",
-		Email:    "admin@example.com",
-		Password: string(hashedPassword),
-		UserType: "admin",
-		IsDelete: false,
+// moduleNames 模块名列表，用于日志
+func moduleNames(modules []module.Module) string {
+	names := make([]string, len(modules))
+	for i, m := range modules {
+		names[i] = m.Name()
 	}
+	return strings.Join(names, ", ")
+}
 
-	if err := db.Create(&adminUser).Error; err != nil {
-		log.Printf("创建管理员用户失败: %v", err)
-		return err
+// setupRateLimit 初始化限流器并启动闲置令牌桶清理
+func setupRateLimit(srv *server.Server, db *gorm.DB) {
+	cfg := config.GetRateLimitConfig()
+	if !cfg.Enabled {
+		return
 	}
 
-	log.Println("管理员用户创建成功")
-	return nil
+	limiter, err := ratelimit.New(cfg, db)
+	if err != nil {
+		log.Fatalf("限流初始化失败: %v", err)
+	}
+	middleware.SetRateLimiter(limiter)
+	srv.Go("rate-limit-cleanup", limiter.Cleanup)
+}
+
+// setupIdempotency 初始化幂等键存储并启动过期记录清理
+func setupIdempotency(srv *server.Server, db *gorm.DB) {
+	cfg := config.GetIdempotencyConfig()
+	if !cfg.Enabled {
+		return
+	}
+
+	store := idempotency.NewStore(db, cfg)
+	middleware.SetIdempotencyStore(store)
+	srv.Go("idempotency-cleanup", store.Cleanup)
+}
+
+// newSettings 创建运行时设置存储并声明各模块的设置项，服务与运维命令共用
+func newSettings(db *gorm.DB, modules []module.Module) *settings.Store {
+	store := settings.NewStore(db, config.GetSettingsConfig())
+	for _, m := range modules {
+		if sm, ok := m.(module.SettingsModule); ok {
+			store.Declare(sm.Settings()...)
+		}
+	}
+	settings.SetStore(store)
+	return store
+}
+
+// setupJobs 启动各模块的后台任务
+func setupJobs(srv *server.Server, app *module.App, modules []module.Module) {
+	for _, m := range modules {
+		for _, job := range m.Jobs(app) {
+			srv.Go(job.Name, job.Run)
+			if job.Stop != nil {
+				srv.OnShutdown(job.Stop)
+			}
+		}
+	}
+}
+
+// setupQueue 启动任务队列的工作池、定时调度与过期任务清理
+// 未开启 JOBS_ENABLED 的实例只入队，由其他实例执行
+func setupQueue(srv *server.Server, queue *jobs.Queue) {
+	jobs.SetQueue(queue)
+	if !config.GetJobsConfig().Enabled {
+		return
+	}
+	srv.Go("job-worker", queue.Run)
+	srv.Go("job-scheduler", queue.RunScheduler)
+	srv.Go("job-cleanup", queue.Cleanup)
 }
 
-// setupRoutes 设置路由
-func setupRoutes(r *gin.Engine, db *gorm.DB) {
-	// API v1 路由组
-	v1 := r.Group("/api/v1")
-	{
-		// 系统模块路由
-		system.RegisterRoutes(v1, db)
+// setupEvents 在后台转发发件箱中的事件，订阅方已在 setupJobs 中注册
+// 未开启转发的实例只写入发件箱，由其他实例转发
+func setupEvents(srv *server.Server, relay *events.Relay) {
+	if !config.GetOutboxConfig().Enabled {
+		return
+	}
+	events.SetRelay(relay)
+	srv.Go("outbox-relay", relay.Run)
+	srv.Go("outbox-cleanup", relay.Cleanup)
+}
 
-		// 用户模块路由
-		user.RegisterRoutes(v1, db)
+// setupMetrics 注册 Prometheus 指标
+// 配置 METRICS_ADDR 时在独立端口提供指标，否则挂载在业务端口上
+func setupMetrics(srv *server.Server, r *gin.Engine, app *module.App, modules []module.Module) {
+	cfg := config.GetMetricsConfig()
+	if !cfg.Enabled {
+		return
+	}
+	// 业务端口对外暴露，未设置令牌时不挂载，避免任何人都能读取指标
+	if cfg.Addr == "" && cfg.Token == "" {
+		log.Printf("错误: 未设置 METRICS_TOKEN 或 METRICS_ADDR，指标路径 %s 未挂载；设置其一以开启指标，或设置 METRICS_ENABLED=false", cfg.Path)
+		return
+	}
 
-		// 供应商模块路由
-		supplier.RegisterRoutes(v1, db)
+	if err := metrics.RegisterDB(app.DB); err != nil {
+		log.Fatalf("数据库指标注册失败: %v", err)
+	}
+	for _, m := range modules {
+		if mm, ok := m.(module.MetricsModule); ok {
+			metrics.MustRegister(mm.Collectors(app)...)
+		}
+	}
 
-		// 店铺模块路由
-		shop.RegisterRoutes(v1, db)
+	handler := metrics.Handler(cfg.Token)
+	if cfg.Addr == "" {
+		r.GET(cfg.Path, gin.WrapH(handler))
+		return
+	}
 
-		// 商品模块路由
-		product.RegisterRoutes(v1, db)
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, handler)
+	metricsServer := &http.Server{Addr: cfg.Addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	srv.Go("metrics", func(ctx context.Context) {
+		go func() {
+			log.Printf("监控指标服务启动在 %s%s", cfg.Addr, cfg.Path)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("监控指标服务异常退出: %v", err)
+			}
+		}()
+		<-ctx.Done()
+		metricsServer.Close()
+	})
+}
+
+// setupGRPC 注册各模块的 gRPC 服务，与 HTTP 接口共用服务层，在独立端口提供服务（可同时挂载 JSON 网关）
+func setupGRPC(srv *server.Server, app *module.App, modules []module.Module) {
+	cfg := config.GetGRPCConfig()
+	if !cfg.Enabled {
+		return
+	}
 
-		// 分类模块路由
-		category.RegisterRoutes(v1, db)
+	s := rpc.New(cfg, rpc.NewAuthenticator(cfg.APIKeys, module.GRPCAccess(modules)))
+	for _, m := range modules {
+		if gm, ok := m.(module.GRPCModule); ok {
+			gm.RegisterGRPC(s, app)
+		}
+	}
 
-		// 链接模块路由
-		link.RegisterRoutes(v1, db)
+	srv.Go("grpc-server", func(ctx context.Context) {
+		if err := s.Run(ctx); err != nil {
+			log.Printf("gRPC 服务异常退出: %v", err)
+		}
+	})
+}
 
-		// 属性模块路由
-		attribute.RegisterRoutes(v1, db)
+// setupRoutes 设置路由，各模块按启动顺序在 /api/v1 下注册，返回登记了访问要求的路由表
+func setupRoutes(r *gin.Engine, app *module.App, modules []module.Module) *middleware.Router {
+	root := middleware.NewRouter(&r.RouterGroup)
+	v1 := root.Group("/api/v1")
+	for _, m := range modules {
+		m.RegisterRoutes(v1, app)
 	}
 
 	// 根路径
-	r.GET("/", func(c *gin.Context) {
+	root.GET("/", middleware.Public, func(c *gin.Context) {
 		response.Success(c, gin.H{
 			"message": "ERP后端服务运行正常",
 			"version": "1.0.0",
 		})
 	})
+	return root
 }
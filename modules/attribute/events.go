@@ -0,0 +1,50 @@
+package attribute
+
+import "erp_backend/pkg/events"
+
+// AggregateType 实现 events.Aggregate 接口
+func (Attribute) AggregateType() string { return "attribute" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (a Attribute) AggregateID() uint { return a.ID }
+
+// AggregateType 实现 events.Aggregate 接口
+func (ProductAttribute) AggregateType() string { return "product_attribute" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (pa ProductAttribute) AggregateID() uint { return pa.ID }
+
+// 属性领域事件
+type (
+	AttributeCreated = events.Created[Attribute] // 属性创建
+	AttributeUpdated = events.Updated[Attribute] // 属性更新
+	AttributeToggled = events.Toggled[Attribute] // 属性启用状态切换
+	AttributeDeleted = events.Deleted[Attribute] // 属性删除
+
+	ProductAttributeCreated = events.Created[ProductAttribute] // 商品属性值创建
+	ProductAttributeUpdated = events.Updated[ProductAttribute] // 商品属性值更新
+	ProductAttributeDeleted = events.Deleted[ProductAttribute] // 商品属性值删除
+)
+
+// attributeUpdated 属性补丁写入后的事件
+func attributeUpdated(a *Attribute) events.Event {
+	return AttributeUpdated{Entity: *a}
+}
+
+// attributeToggled 属性启用状态切换后的事件
+func attributeToggled(a *Attribute) events.Event {
+	return AttributeToggled{Entity: *a}
+}
+
+// productAttributeUpdated 商品属性值补丁写入后的事件
+func productAttributeUpdated(pa *ProductAttribute) events.Event {
+	return ProductAttributeUpdated{Entity: *pa}
+}
+
+// Events 实现 module.EventsModule 接口
+func (Module) Events() []events.Event {
+	return []events.Event{
+		AttributeCreated{}, AttributeUpdated{}, AttributeToggled{}, AttributeDeleted{},
+		ProductAttributeCreated{}, ProductAttributeUpdated{}, ProductAttributeDeleted{},
+	}
+}
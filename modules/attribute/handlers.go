@@ -1,21 +1,76 @@
 package attribute
 
 import (
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
 	"erp_backend/pkg/response"
 )
 
+// attributeListConfig 属性列表排序配置
+var attributeListConfig = pagination.Config{
+	SortFields:  []string{"name", "data_type", "category_id", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// attributeListFilters 属性列表筛选字段
+var attributeListFilters = filter.Fields{
+	"id":          filter.Number,
+	"category_id": filter.Number,
+	"name":        filter.String,
+	"data_type":   filter.String,
+	"is_required": filter.Bool,
+	"is_enabled":  filter.Bool,
+	"created_at":  filter.Time,
+	"updated_at":  filter.Time,
+}
+
+// productAttributeListConfig 商品属性值列表排序配置
+var productAttributeListConfig = pagination.Config{
+	SortFields:  []string{"product_id", "attribute_id", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// productAttributeListFilters 商品属性值列表筛选字段
+var productAttributeListFilters = filter.Fields{
+	"id":           filter.Number,
+	"product_id":   filter.Number,
+	"attribute_id": filter.Number,
+	"value":        filter.String,
+	"created_at":   filter.Time,
+	"updated_at":   filter.Time,
+}
+
+// attributePatchFields 属性允许通过 PATCH 修改的字段及校验规则
+var attributePatchFields = patch.Fields{
+	"name":        "required,max=100",
+	"data_type":   "required,max=50",
+	"category_id": "required",
+	"is_required": "",
+	"remark":      "",
+	"is_enabled":  "",
+}
+
+// productAttributePatchFields 商品属性值允许通过 PATCH 修改的字段及校验规则
+var productAttributePatchFields = patch.Fields{
+	"product_id":   "required",
+	"attribute_id": "required",
+	"value":        "",
+}
+
 type Handler struct {
-	db *gorm.DB
+	attributes        *Service
+	productAttributes *ProductAttributeService
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(attributes *Service, productAttributes *ProductAttributeService) *Handler {
+	return &Handler{attributes: attributes, productAttributes: productAttributes}
 }
 
 // CreateAttribute 创建属性
@@ -24,20 +79,24 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Tags 属性管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param attribute body Attribute true "属性信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Attribute} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /attributes [post]
 func (h *Handler) CreateAttribute(c *gin.Context) {
 	var attribute Attribute
 	if err := c.ShouldBindJSON(&attribute); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+		response.BindError(c, err)
 		return
 	}
 
-	if err := h.db.Create(&attribute).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建属性失败")
+	if err := h.attributes.Create(c, &attribute); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -50,31 +109,28 @@ func (h *Handler) CreateAttribute(c *gin.Context) {
 // @Tags 属性管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param category_id query string false "分类ID"
 // @Param is_enabled query string false "是否启用"
-// @Success 200 {object} response.Response{data=[]Attribute} "获取成功"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Attribute}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /attributes [get]
 func (h *Handler) ListAttributes(c *gin.Context) {
-	var attributes []Attribute
-	query := h.db.Model(&Attribute{})
-
-	// 支持按分类ID筛选
-	if categoryID := c.Query("category_id"); categoryID != "" {
-		query = query.Where("category_id = ?", categoryID)
-	}
-
-	// 支持按启用状态筛选
-	if isEnabled := c.Query("is_enabled"); isEnabled != "" {
-		query = query.Where("is_enabled = ?", isEnabled)
-	}
-
-	if err := query.Find(&attributes).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取属性列表失败")
+	page, err := h.attributes.List(c, c.Request.URL.Query())
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, attributes)
+	response.Success(c, page)
 }
 
 // GetAttribute 获取单个属性
@@ -83,21 +139,24 @@ func (h *Handler) ListAttributes(c *gin.Context) {
 // @Tags 属性管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "属性ID"
 // @Success 200 {object} response.Response{data=Attribute} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "属性不存在"
 // @Router /attributes/{id} [get]
 func (h *Handler) GetAttribute(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	var attribute Attribute
-	if err := h.db.First(&attribute, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "属性不存在")
+	attribute, err := h.attributes.Get(c, uint(id))
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -110,33 +169,68 @@ func (h *Handler) GetAttribute(c *gin.Context) {
 // @Tags 属性管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "属性ID"
 // @Param attribute body Attribute true "属性信息"
 // @Success 200 {object} response.Response{data=Attribute} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "属性不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /attributes/{id} [put]
 func (h *Handler) UpdateAttribute(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	var attribute Attribute
-	if err := h.db.First(&attribute, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "属性不存在")
+	attribute, err := h.attributes.Update(c, uint(id), func(attribute *Attribute) error {
+		if err := c.ShouldBindJSON(attribute); err != nil {
+			return errcode.FromBinding(err)
+		}
+		return nil
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&attribute); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+	response.Success(c, attribute)
+}
+
+// PatchAttribute 部分更新属性
+// @Summary 部分更新属性
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改属性，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 属性管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "属性ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Attribute} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "属性不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /attributes/{id} [patch]
+func (h *Handler) PatchAttribute(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	if err := h.db.Save(&attribute).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新属性失败")
+	attribute, err := h.attributes.Patch(c, uint(id), func(attribute *Attribute) (map[string]interface{}, error) {
+		return patch.Apply(c, attribute, attributePatchFields)
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -149,24 +243,27 @@ func (h *Handler) UpdateAttribute(c *gin.Context) {
 // @Tags 属性管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "属性ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /attributes/{id} [delete]
 func (h *Handler) DeleteAttribute(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	if err := h.db.Delete(&Attribute{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除属性失败")
+	if err := h.attributes.Delete(c, uint(id)); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, gin.H{"message": "删除成功"})
+	response.Success(c, gin.H{"message": i18n.T(c, "message.deleted")})
 }
 
 // ToggleAttributeStatus 切换属性状态
@@ -175,28 +272,26 @@ func (h *Handler) DeleteAttribute(c *gin.Context) {
 // @Tags 属性管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "属性ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Attribute} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "属性不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /attributes/{id}/toggle [patch]
 func (h *Handler) ToggleAttributeStatus(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var attribute Attribute
-	if err := h.db.First(&attribute, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "属性不存在")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	attribute.IsEnabled = !attribute.IsEnabled
-	if err := h.db.Save(&attribute).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新状态失败")
+	attribute, err := h.attributes.Toggle(c, uint(id))
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -209,20 +304,24 @@ func (h *Handler) ToggleAttributeStatus(c *gin.Context) {
 // @Tags 商品属性值管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param productAttribute body ProductAttribute true "商品属性值信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=ProductAttribute} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /product-attributes [post]
 func (h *Handler) CreateProductAttribute(c *gin.Context) {
 	var productAttribute ProductAttribute
 	if err := c.ShouldBindJSON(&productAttribute); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+		response.BindError(c, err)
 		return
 	}
 
-	if err := h.db.Create(&productAttribute).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建商品属性值失败")
+	if err := h.productAttributes.Create(c, &productAttribute); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -235,31 +334,28 @@ func (h *Handler) CreateProductAttribute(c *gin.Context) {
 // @Tags 商品属性值管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param product_id query string false "商品ID"
 // @Param attribute_id query string false "属性ID"
-// @Success 200 {object} response.Response{data=[]ProductAttribute} "获取成功"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]ProductAttribute}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /product-attributes [get]
 func (h *Handler) ListProductAttributes(c *gin.Context) {
-	var productAttributes []ProductAttribute
-	query := h.db.Model(&ProductAttribute{})
-
-	// 支持按商品ID筛选
-	if productID := c.Query("product_id"); productID != "" {
-		query = query.Where("product_id = ?", productID)
-	}
-
-	// 支持按属性ID筛选
-	if attributeID := c.Query("attribute_id"); attributeID != "" {
-		query = query.Where("attribute_id = ?", attributeID)
-	}
-
-	if err := query.Find(&productAttributes).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取商品属性值列表失败")
+	page, err := h.productAttributes.List(c, c.Request.URL.Query())
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, productAttributes)
+	response.Success(c, page)
 }
 
 // UpdateProductAttribute 更新商品属性值
@@ -268,33 +364,68 @@ func (h *Handler) ListProductAttributes(c *gin.Context) {
 // @Tags 商品属性值管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "商品属性值ID"
 // @Param productAttribute body ProductAttribute true "商品属性值信息"
 // @Success 200 {object} response.Response{data=ProductAttribute} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "商品属性值不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /product-attributes/{id} [put]
 func (h *Handler) UpdateProductAttribute(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	var productAttribute ProductAttribute
-	if err := h.db.First(&productAttribute, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "商品属性值不存在")
+	productAttribute, err := h.productAttributes.Update(c, uint(id), func(productAttribute *ProductAttribute) error {
+		if err := c.ShouldBindJSON(productAttribute); err != nil {
+			return errcode.FromBinding(err)
+		}
+		return nil
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&productAttribute); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+	response.Success(c, productAttribute)
+}
+
+// PatchProductAttribute 部分更新商品属性值
+// @Summary 部分更新商品属性值
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改商品属性值，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 商品属性值管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "商品属性值ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=ProductAttribute} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "商品属性值不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /product-attributes/{id} [patch]
+func (h *Handler) PatchProductAttribute(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	if err := h.db.Save(&productAttribute).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新商品属性值失败")
+	productAttribute, err := h.productAttributes.Patch(c, uint(id), func(productAttribute *ProductAttribute) (map[string]interface{}, error) {
+		return patch.Apply(c, productAttribute, productAttributePatchFields)
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -307,22 +438,25 @@ func (h *Handler) UpdateProductAttribute(c *gin.Context) {
 // @Tags 商品属性值管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "商品属性值ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /product-attributes/{id} [delete]
 func (h *Handler) DeleteProductAttribute(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	if err := h.db.Delete(&ProductAttribute{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除商品属性值失败")
+	if err := h.productAttributes.Delete(c, uint(id)); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, gin.H{"message": "删除成功"})
+	response.Success(c, gin.H{"message": i18n.T(c, "message.deleted")})
 }
@@ -0,0 +1,35 @@
+package attribute
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 属性管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "attribute" }
+
+// DependsOn 实现 module.Module 接口
+func (Module) DependsOn() []string { return []string{"category", "product"} }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&Attribute{}, &ProductAttribute{}} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "attribute.read", Description: "查看属性与商品属性值"},
+		{Code: "attribute.write", Description: "创建、修改与删除属性与商品属性值", UserTypes: module.Staff},
+	}
+}
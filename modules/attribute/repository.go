@@ -0,0 +1,27 @@
+package attribute
+
+import (
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/repository"
+)
+
+// Repository 属性数据访问接口
+type Repository interface {
+	repository.CRUD[Attribute]
+}
+
+// NewRepository 创建基于 GORM 的属性仓储
+func NewRepository(db *gorm.DB) Repository {
+	return repository.NewGorm[Attribute](db)
+}
+
+// ProductAttributeRepository 商品属性值数据访问接口
+type ProductAttributeRepository interface {
+	repository.CRUD[ProductAttribute]
+}
+
+// NewProductAttributeRepository 创建基于 GORM 的商品属性值仓储
+func NewProductAttributeRepository(db *gorm.DB) ProductAttributeRepository {
+	return repository.NewGorm[ProductAttribute](db)
+}
@@ -1,31 +1,43 @@
 package attribute
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
 )
 
 // RegisterRoutes 注册属性相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	tx, publisher := database.NewTransactor(db), events.NewOutbox(db)
+	handler := NewHandler(
+		NewService(NewRepository(db), tx, publisher),
+		NewProductAttributeService(NewProductAttributeRepository(db), tx, publisher),
+	)
+
+	read, write := module.RequirePermission("attribute.read"), module.RequirePermission("attribute.write")
 
 	// 属性管理路由
 	attributes := r.Group("/attributes")
 	{
-		attributes.POST("", handler.CreateAttribute)
-		attributes.GET("", handler.ListAttributes)
-		attributes.GET("/:id", handler.GetAttribute)
-		attributes.PUT("/:id", handler.UpdateAttribute)
-		attributes.DELETE("/:id", handler.DeleteAttribute)
-		attributes.PATCH("/:id/toggle", handler.ToggleAttributeStatus)
+		attributes.POST("", write, handler.CreateAttribute)
+		attributes.GET("", read, handler.ListAttributes)
+		attributes.GET("/:id", read, handler.GetAttribute)
+		attributes.PUT("/:id", write, handler.UpdateAttribute)
+		attributes.PATCH("/:id", write, handler.PatchAttribute)
+		attributes.DELETE("/:id", write, handler.DeleteAttribute)
+		attributes.PATCH("/:id/toggle", write, handler.ToggleAttributeStatus)
 	}
 
 	// 商品属性值路由
 	productAttributes := r.Group("/product-attributes")
 	{
-		productAttributes.POST("", handler.CreateProductAttribute)
-		productAttributes.GET("", handler.ListProductAttributes)
-		productAttributes.PUT("/:id", handler.UpdateProductAttribute)
-		productAttributes.DELETE("/:id", handler.DeleteProductAttribute)
+		productAttributes.POST("", write, handler.CreateProductAttribute)
+		productAttributes.GET("", read, handler.ListProductAttributes)
+		productAttributes.PUT("/:id", write, handler.UpdateProductAttribute)
+		productAttributes.PATCH("/:id", write, handler.PatchProductAttribute)
+		productAttributes.DELETE("/:id", write, handler.DeleteProductAttribute)
 	}
 }
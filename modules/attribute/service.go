@@ -0,0 +1,282 @@
+package attribute
+
+import (
+	"context"
+	"net/url"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+)
+
+// Service 属性业务逻辑，供 HTTP 接口、命令行与后台任务共用，返回的错误均为 *errcode.Error（回调返回的错误除外）
+type Service struct {
+	repo      Repository
+	tx        database.Transactor
+	publisher events.Publisher
+}
+
+// NewService 创建属性服务
+func NewService(repo Repository, tx database.Transactor, publisher events.Publisher) *Service {
+	return &Service{repo: repo, tx: tx, publisher: publisher}
+}
+
+// List 分页查询属性，query 与 REST 列表接口的查询参数一致
+func (s *Service) List(ctx context.Context, query url.Values) (*pagination.Page, error) {
+	params, err := pagination.ParseValues(query, attributeListConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := filter.ParseValues(query, attributeListFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	// 支持按分类ID与启用状态筛选的旧版参数
+	conditions = append(filter.Legacy(query, "category_id", "is_enabled"), conditions...)
+
+	page, err := s.repo.List(ctx, params, conditions)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.Attribute.ListFailed)
+	}
+	return page, nil
+}
+
+// Get 查询单个属性
+func (s *Service) Get(ctx context.Context, id uint) (*Attribute, error) {
+	attribute, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.Attribute.NotFound)
+	}
+	return attribute, nil
+}
+
+// Create 创建属性
+func (s *Service) Create(ctx context.Context, attribute *Attribute) error {
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, attribute); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, AttributeCreated{Entity: *attribute})
+	})
+	if err != nil {
+		return errcode.FromDB(err, errcode.Attribute.CreateFailed)
+	}
+	return nil
+}
+
+// Update 全量更新属性，bind 将新的值写入加载的属性
+func (s *Service) Update(ctx context.Context, id uint, bind func(*Attribute) error) (*Attribute, error) {
+	attribute, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := bind(attribute); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, attribute); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, AttributeUpdated{Entity: *attribute})
+	})
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.Attribute.UpdateFailed)
+	}
+	return attribute, nil
+}
+
+// Patch 部分更新属性，apply 将补丁应用到加载的属性并返回变更的列
+func (s *Service) Patch(ctx context.Context, id uint, apply func(*Attribute) (map[string]interface{}, error)) (*Attribute, error) {
+	return s.patch(ctx, id, errcode.Attribute.UpdateFailed, attributeUpdated, apply)
+}
+
+// Merge 部分更新属性，values 的键为 JSON 字段名，受 PATCH 接口相同的白名单与校验规则约束
+func (s *Service) Merge(ctx context.Context, id uint, values map[string]interface{}) (*Attribute, error) {
+	return s.Patch(ctx, id, func(attribute *Attribute) (map[string]interface{}, error) {
+		return patch.Merge(ctx, attribute, attributePatchFields, values)
+	})
+}
+
+// Toggle 切换属性启用状态
+func (s *Service) Toggle(ctx context.Context, id uint) (*Attribute, error) {
+	return s.patch(ctx, id, errcode.Attribute.ToggleFailed, attributeToggled, func(attribute *Attribute) (map[string]interface{}, error) {
+		return patch.Merge(ctx, attribute, attributePatchFields, map[string]interface{}{"is_enabled": !attribute.IsEnabled})
+	})
+}
+
+// patch 加载属性并应用补丁，只写入变更的列，并在同一事务中发布 event 返回的事件
+func (s *Service) patch(ctx context.Context, id uint, failed errcode.Code, event func(*Attribute) events.Event, apply func(*Attribute) (map[string]interface{}, error)) (*Attribute, error) {
+	attribute, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := apply(attribute)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) > 0 {
+		err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.Update(ctx, attribute, columns); err != nil {
+				return err
+			}
+			return s.publisher.Publish(ctx, event(attribute))
+		})
+		if err != nil {
+			return nil, errcode.FromDB(err, failed)
+		}
+	}
+	return attribute, nil
+}
+
+// Delete 删除属性，属性不存在时视为成功
+func (s *Service) Delete(ctx context.Context, id uint) error {
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		deleted, err := s.repo.Delete(ctx, id)
+		if err != nil || !deleted {
+			return err
+		}
+		return s.publisher.Publish(ctx, AttributeDeleted{ID: id})
+	})
+	if err != nil {
+		return errcode.FromDB(err, errcode.Attribute.DeleteFailed)
+	}
+	return nil
+}
+
+// ProductAttributeService 商品属性值业务逻辑，供 HTTP 接口、命令行与后台任务共用，返回的错误均为 *errcode.Error（回调返回的错误除外）
+type ProductAttributeService struct {
+	repo      ProductAttributeRepository
+	tx        database.Transactor
+	publisher events.Publisher
+}
+
+// NewProductAttributeService 创建商品属性值服务
+func NewProductAttributeService(repo ProductAttributeRepository, tx database.Transactor, publisher events.Publisher) *ProductAttributeService {
+	return &ProductAttributeService{repo: repo, tx: tx, publisher: publisher}
+}
+
+// List 分页查询商品属性值，query 与 REST 列表接口的查询参数一致
+func (s *ProductAttributeService) List(ctx context.Context, query url.Values) (*pagination.Page, error) {
+	params, err := pagination.ParseValues(query, productAttributeListConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := filter.ParseValues(query, productAttributeListFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	// 支持按商品ID与属性ID筛选的旧版参数
+	conditions = append(filter.Legacy(query, "product_id", "attribute_id"), conditions...)
+
+	page, err := s.repo.List(ctx, params, conditions)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.ProductAttribute.ListFailed)
+	}
+	return page, nil
+}
+
+// Get 查询单个商品属性值
+func (s *ProductAttributeService) Get(ctx context.Context, id uint) (*ProductAttribute, error) {
+	productAttribute, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.ProductAttribute.NotFound)
+	}
+	return productAttribute, nil
+}
+
+// Create 创建商品属性值
+func (s *ProductAttributeService) Create(ctx context.Context, productAttribute *ProductAttribute) error {
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, productAttribute); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, ProductAttributeCreated{Entity: *productAttribute})
+	})
+	if err != nil {
+		return errcode.FromDB(err, errcode.ProductAttribute.CreateFailed)
+	}
+	return nil
+}
+
+// Update 全量更新商品属性值，bind 将新的值写入加载的商品属性值
+func (s *ProductAttributeService) Update(ctx context.Context, id uint, bind func(*ProductAttribute) error) (*ProductAttribute, error) {
+	productAttribute, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := bind(productAttribute); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, productAttribute); err != nil {
+			return err
+		}
+		return s.publisher.Publish(ctx, ProductAttributeUpdated{Entity: *productAttribute})
+	})
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.ProductAttribute.UpdateFailed)
+	}
+	return productAttribute, nil
+}
+
+// Patch 部分更新商品属性值，apply 将补丁应用到加载的商品属性值并返回变更的列
+func (s *ProductAttributeService) Patch(ctx context.Context, id uint, apply func(*ProductAttribute) (map[string]interface{}, error)) (*ProductAttribute, error) {
+	return s.patch(ctx, id, errcode.ProductAttribute.UpdateFailed, productAttributeUpdated, apply)
+}
+
+// Merge 部分更新商品属性值，values 的键为 JSON 字段名，受 PATCH 接口相同的白名单与校验规则约束
+func (s *ProductAttributeService) Merge(ctx context.Context, id uint, values map[string]interface{}) (*ProductAttribute, error) {
+	return s.Patch(ctx, id, func(productAttribute *ProductAttribute) (map[string]interface{}, error) {
+		return patch.Merge(ctx, productAttribute, productAttributePatchFields, values)
+	})
+}
+
+// patch 加载商品属性值并应用补丁，只写入变更的列，并在同一事务中发布 event 返回的事件
+func (s *ProductAttributeService) patch(ctx context.Context, id uint, failed errcode.Code, event func(*ProductAttribute) events.Event, apply func(*ProductAttribute) (map[string]interface{}, error)) (*ProductAttribute, error) {
+	productAttribute, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := apply(productAttribute)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) > 0 {
+		err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+			if err := s.repo.Update(ctx, productAttribute, columns); err != nil {
+				return err
+			}
+			return s.publisher.Publish(ctx, event(productAttribute))
+		})
+		if err != nil {
+			return nil, errcode.FromDB(err, failed)
+		}
+	}
+	return productAttribute, nil
+}
+
+// Delete 删除商品属性值，商品属性值不存在时视为成功
+func (s *ProductAttributeService) Delete(ctx context.Context, id uint) error {
+	err := s.tx.Transaction(ctx, func(ctx context.Context) error {
+		deleted, err := s.repo.Delete(ctx, id)
+		if err != nil || !deleted {
+			return err
+		}
+		return s.publisher.Publish(ctx, ProductAttributeDeleted{ID: id})
+	})
+	if err != nil {
+		return errcode.FromDB(err, errcode.ProductAttribute.DeleteFailed)
+	}
+	return nil
+}
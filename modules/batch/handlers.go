@@ -0,0 +1,388 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/logger"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/response"
+	"erp_backend/pkg/subrequest"
+)
+
+// methods 子请求允许的方法
+var methods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// refPattern 操作名称格式
+var refPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// placeholder 引用占位符，如 {{p1.id}}、{{p1.items.0.id}}
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)((?:\.[A-Za-z0-9_]+)+)\s*\}\}`)
+
+type Handler struct {
+	db            *gorm.DB
+	router        http.Handler
+	basePath      string
+	maxOperations int
+	maxBodySize   int64
+}
+
+func NewHandler(db *gorm.DB, router http.Handler, basePath string, cfg *config.BatchConfig) *Handler {
+	return &Handler{db: db, router: router, basePath: basePath, maxOperations: cfg.MaxOperations, maxBodySize: int64(cfg.MaxBodySize)}
+}
+
+// Execute 执行批量请求
+// @Summary 批量操作
+// @Description 按顺序执行一组子请求，每个子请求与单独调用对应接口完全一致（认证、校验、错误码相同）。
+// @Description 操作可设置 ref 名称，后续操作的路径和请求体中用 {{ref.字段}} 引用其响应 data 中的值，如 {{p1.id}}；请求体中整个字符串为一个引用时保留原类型。
+// @Description atomic 为 true 时所有操作在同一事务中执行，首个失败（状态码 >= 400）后回滚并跳过剩余操作；否则各操作独立执行，引用了失败操作的操作返回 424。
+// @Description 批量请求本身计入 bulk 限流额度并支持幂等键，每个子请求再按对应接口的额度计数，超出额度的子请求返回 429
+// @Tags 批量操作
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body Request true "批量请求"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Response} "执行完成，各操作结果见 results"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 429 {object} response.Response "请求过于频繁"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /batch [post]
+func (h *Handler) Execute(c *gin.Context) {
+	// 批量请求经 GraphQL 或编码后的路径作为子请求执行时同样拒绝，避免嵌套放大操作数并开启独立的事务
+	if middleware.IsSubRequest(c.Request.Context()) {
+		response.FailWithError(c, errcode.BatchNested.New())
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBodySize)
+	var req Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.FailWithError(c, errcode.RequestTooLarge.New())
+			return
+		}
+		response.BindError(c, err)
+		return
+	}
+	if err := h.validate(&req); err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	ctx := middleware.WithSubRequest(c.Request.Context())
+	var tx *gorm.DB
+	finished := false
+	if req.Atomic {
+		tx = h.db.WithContext(c).Begin()
+		if tx.Error != nil {
+			response.DBError(c, tx.Error, errcode.DatabaseError)
+			return
+		}
+		// 子请求 panic 等未走到提交或回滚的情况下回滚事务，避免连接与锁一直被占用
+		defer func() {
+			if !finished {
+				tx.Rollback()
+			}
+		}()
+		ctx = database.WithTx(ctx, tx)
+	}
+
+	resp := Response{Atomic: req.Atomic, Success: true, Results: make([]Result, 0, len(req.Operations))}
+	refs := newScope()
+	for i, op := range req.Operations {
+		var result Result
+		if req.Atomic && !resp.Success {
+			result = failed(c, op, op.Path, errcode.BatchAborted.New())
+		} else {
+			result = h.execute(c, ctx, i, op, refs)
+		}
+		if result.Status >= http.StatusBadRequest {
+			resp.Success = false
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	if req.Atomic {
+		finished = true
+		if !resp.Success {
+			tx.Rollback()
+			resp.RolledBack = true
+		} else {
+			if err := tx.Commit().Error; err != nil {
+				response.DBError(c, err, errcode.DatabaseError)
+				return
+			}
+			database.Committed(ctx)
+		}
+	}
+
+	response.Success(c, resp)
+}
+
+// validate 校验操作数量、方法、路径与操作名称，并将方法统一为大写
+func (h *Handler) validate(req *Request) error {
+	var details []errcode.FieldError
+	if len(req.Operations) > h.maxOperations {
+		details = append(details, errcode.FieldError{Field: "operations", Rule: "max", Param: strconv.Itoa(h.maxOperations)})
+	}
+
+	names := make(map[string]bool)
+	for i := range req.Operations {
+		op := &req.Operations[i]
+		field := fmt.Sprintf("operations[%d]", i)
+
+		op.Method = strings.ToUpper(op.Method)
+		if !methods[op.Method] {
+			details = append(details, errcode.FieldError{Field: field + ".method", Rule: "oneof", Param: "GET POST PUT PATCH DELETE"})
+		}
+		if !strings.HasPrefix(op.Path, "/") {
+			details = append(details, errcode.FieldError{Field: field + ".path", Rule: "startswith", Param: "/"})
+		} else if isBatchPath(op.Path) {
+			return errcode.BatchNested.New()
+		}
+		if op.Ref != "" {
+			switch {
+			case !refPattern.MatchString(op.Ref):
+				details = append(details, errcode.FieldError{Field: field + ".ref", Rule: "identifier"})
+			case names[op.Ref]:
+				details = append(details, errcode.FieldError{Field: field + ".ref", Rule: "unique"})
+			}
+			names[op.Ref] = true
+		}
+	}
+
+	if len(details) > 0 {
+		return errcode.ValidationFailed.WithDetails(details...)
+	}
+	return nil
+}
+
+// execute 替换引用后经路由执行单个操作，成功时记录其响应数据供后续操作引用
+func (h *Handler) execute(c *gin.Context, ctx context.Context, index int, op Operation, refs *scope) Result {
+	target, err := refs.resolvePath(op.Path)
+	if err != nil {
+		refs.fail(op.Ref)
+		return failed(c, op, op.Path, err)
+	}
+	if isBatchPath(target) {
+		refs.fail(op.Ref)
+		return failed(c, op, target, errcode.BatchNested.New())
+	}
+	body, err := refs.resolveBody(op.Body)
+	if err != nil {
+		refs.fail(op.Ref)
+		return failed(c, op, target, err)
+	}
+
+	req, err := subrequest.New(ctx, c.Request, op.Method, h.basePath+target, body)
+	if err != nil {
+		refs.fail(op.Ref)
+		return failed(c, op, target, errcode.InvalidParams.New())
+	}
+	for name, value := range op.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set(middleware.RequestIDHeader, fmt.Sprintf("%s-%d", c.GetString(logger.RequestIDKey), index+1))
+
+	rec := subrequest.Do(h.router, req)
+
+	result := Result{Ref: op.Ref, Method: op.Method, Path: target, Status: rec.Status(), Body: rec.JSON()}
+	if result.Status >= http.StatusBadRequest {
+		refs.fail(op.Ref)
+	} else {
+		refs.record(op.Ref, rec.Body())
+	}
+	return result
+}
+
+// failed 未经路由执行的操作结果，响应体与普通错误响应一致
+func failed(c *gin.Context, op Operation, target string, err error) Result {
+	resp := response.ErrorResponse(c, err)
+	body, _ := json.Marshal(resp)
+	return Result{Ref: op.Ref, Method: op.Method, Path: target, Status: resp.Code, Body: body}
+}
+
+// isBatchPath 判断路径是否指向批量接口本身，按路由实际匹配的解码后路径判断，如 /%62atch
+func isBatchPath(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		// 无法解析的路径也无法构造子请求，由 subrequest.New 报错
+		return false
+	}
+	p := path.Clean(u.Path)
+	return p == "/batch" || strings.HasPrefix(p, "/batch/")
+}
+
+// scope 已执行操作的响应数据，按操作名称引用
+type scope struct {
+	data   map[string]interface{}
+	failed map[string]bool
+}
+
+func newScope() *scope {
+	return &scope{data: make(map[string]interface{}), failed: make(map[string]bool)}
+}
+
+// record 保存成功操作响应中的 data
+func (s *scope) record(ref string, body []byte) {
+	if ref == "" {
+		return
+	}
+	var resp struct {
+		Data interface{} `json:"data"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&resp); err != nil {
+		s.failed[ref] = true
+		return
+	}
+	s.data[ref] = resp.Data
+}
+
+// fail 标记操作失败，引用它的后续操作返回 424
+func (s *scope) fail(ref string) {
+	if ref != "" {
+		s.failed[ref] = true
+	}
+}
+
+// lookup 按 ref 与 .字段 路径取值，数组用下标，如 .items.0.id
+func (s *scope) lookup(expr, ref, fields string) (interface{}, error) {
+	if s.failed[ref] {
+		return nil, errcode.BatchDependencyFailed.New(ref)
+	}
+	value, ok := s.data[ref]
+	if !ok {
+		return nil, errcode.BatchReferenceInvalid.New(expr)
+	}
+	for _, field := range strings.Split(strings.TrimPrefix(fields, "."), ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value, ok = v[field]
+		case []interface{}:
+			i, err := strconv.Atoi(field)
+			ok = err == nil && i >= 0 && i < len(v)
+			if ok {
+				value = v[i]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, errcode.BatchReferenceInvalid.New(expr)
+		}
+	}
+	return value, nil
+}
+
+// replace 将字符串中的引用替换为文本，escape 用于路径转义
+func (s *scope) replace(text string, escape func(string) string) (string, error) {
+	var lookupErr error
+	result := placeholder.ReplaceAllStringFunc(text, func(expr string) string {
+		if lookupErr != nil {
+			return expr
+		}
+		m := placeholder.FindStringSubmatch(expr)
+		value, err := s.lookup(expr, m[1], m[2])
+		if err != nil {
+			lookupErr = err
+			return expr
+		}
+		return escape(format(value))
+	})
+	return result, lookupErr
+}
+
+// resolvePath 替换路径中的引用，引用值按路径段转义
+func (s *scope) resolvePath(target string) (string, error) {
+	return s.replace(target, url.PathEscape)
+}
+
+// resolveBody 替换请求体字符串中的引用，整个字符串为一个引用时保留引用值的类型
+func (s *scope) resolveBody(body json.RawMessage) ([]byte, error) {
+	if len(body) == 0 || !bytes.Contains(body, []byte("{{")) {
+		return body, nil
+	}
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	value, err := s.walk(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// walk 递归替换 JSON 值中的引用
+func (s *scope) walk(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if m := placeholder.FindStringSubmatch(v); m != nil && m[0] == strings.TrimSpace(v) {
+			return s.lookup(m[0], m[1], m[2])
+		}
+		return s.replace(v, func(text string) string { return text })
+	case map[string]interface{}:
+		for key, item := range v {
+			resolved, err := s.walk(item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = resolved
+		}
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := s.walk(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+	}
+	return value, nil
+}
+
+// format 将引用值转为文本，对象与数组为 JSON
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case nil:
+		return ""
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
@@ -0,0 +1,153 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+)
+
+// txDriver 只支持事务的数据库驱动，记录事务的提交与回滚次数
+type txDriver struct {
+	mu                           sync.Mutex
+	begun, committed, rolledBack int
+}
+
+// Connect 实现 driver.Connector
+func (d *txDriver) Connect(context.Context) (driver.Conn, error) { return txConn{d}, nil }
+
+// Driver 实现 driver.Connector
+func (d *txDriver) Driver() driver.Driver { return nil }
+
+// count 在锁内修改计数
+func (d *txDriver) count(n *int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	*n++
+}
+
+// txConn 数据库连接，不支持执行语句
+type txConn struct{ d *txDriver }
+
+func (c txConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c txConn) Close() error                        { return nil }
+func (c txConn) Begin() (driver.Tx, error) {
+	c.d.count(&c.d.begun)
+	return c, nil
+}
+func (c txConn) Commit() error {
+	c.d.count(&c.d.committed)
+	return nil
+}
+func (c txConn) Rollback() error {
+	c.d.count(&c.d.rolledBack)
+	return nil
+}
+
+func TestExecuteRollsBackOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	drv := &txDriver{}
+	sqlDB := sql.OpenDB(drv)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+
+	// 子请求的路由不带 Recovery，panic 穿过批量处理器，由外层的 Recovery 转为 500
+	router := gin.New()
+	router.POST("/api/v1/boom", func(*gin.Context) { panic("boom") })
+	engine := gin.New()
+	engine.Use(gin.RecoveryWithWriter(io.Discard))
+	engine.POST("/api/v1/batch", NewHandler(db, router, "/api/v1", &config.BatchConfig{MaxOperations: 10, MaxBodySize: 1 << 20}).Execute)
+
+	body := `{"atomic": true, "operations": [{"method": "POST", "path": "/boom"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("POST /batch = %d, 期望 500", w.Code)
+	}
+	if drv.begun != 1 || drv.rolledBack != 1 || drv.committed != 0 {
+		t.Errorf("事务 开启 %d 次、回滚 %d 次、提交 %d 次, 期望开启并回滚 1 次", drv.begun, drv.rolledBack, drv.committed)
+	}
+	if inUse := sqlDB.Stats().InUse; inUse != 0 {
+		t.Errorf("占用的连接数 = %d, 期望 0", inUse)
+	}
+}
+
+// newNestingEngine 注册批量接口的路由，子请求经同一路由执行，calls 记录批量接口被执行的次数
+func newNestingEngine(cfg *config.BatchConfig, calls *int) *gin.Engine {
+	engine := gin.New()
+	handler := NewHandler(nil, engine, "/api/v1", cfg)
+	engine.POST("/api/v1/batch", func(c *gin.Context) {
+		*calls++
+		handler.Execute(c)
+	})
+	return engine
+}
+
+// post 发起批量请求
+func post(engine *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestExecuteRejectsNestedBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &config.BatchConfig{MaxOperations: 10, MaxBodySize: 1 << 20}
+
+	// 路由按解码后的路径匹配，编码后的路径同样指向批量接口
+	for _, target := range []string{"/batch", "/./batch", "/%62atch", "/%62atch?x=1", "/products/../batch"} {
+		t.Run(target, func(t *testing.T) {
+			calls := 0
+			engine := newNestingEngine(cfg, &calls)
+			body := `{"operations": [{"method": "POST", "path": "` + target + `", "body": {"operations": []}}]}`
+			w := post(engine, httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body)))
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "BATCH_NESTED") {
+				t.Errorf("POST /batch = %d %s, 期望 400 BATCH_NESTED", w.Code, w.Body.String())
+			}
+			if calls != 1 {
+				t.Errorf("批量接口执行 %d 次, 期望 1", calls)
+			}
+		})
+	}
+
+	// 作为子请求执行时直接拒绝
+	calls := 0
+	engine := newNestingEngine(cfg, &calls)
+	body := `{"operations": [{"method": "GET", "path": "/products"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body))
+	req = req.WithContext(middleware.WithSubRequest(req.Context()))
+	if w := post(engine, req); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "BATCH_NESTED") {
+		t.Errorf("子请求 POST /batch = %d %s, 期望 400 BATCH_NESTED", w.Code, w.Body.String())
+	}
+}
+
+func TestExecuteBodyTooLarge(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	engine := newNestingEngine(&config.BatchConfig{MaxOperations: 10, MaxBodySize: 64}, &calls)
+
+	body := `{"operations": [{"method": "GET", "path": "/products?q=` + strings.Repeat("a", 100) + `"}]}`
+	w := post(engine, httptest.NewRequest(http.MethodPost, "/api/v1/batch", strings.NewReader(body)))
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), "REQUEST_TOO_LARGE") {
+		t.Errorf("POST /batch = %d %s, 期望 413 REQUEST_TOO_LARGE", w.Code, w.Body.String())
+	}
+}
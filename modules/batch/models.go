@@ -0,0 +1,39 @@
+package batch
+
+import "encoding/json"
+
+// Request 批量请求
+// @Description 按顺序执行的一组子请求
+type Request struct {
+	Atomic     bool        `json:"atomic" example:"true"`                    // 是否在同一事务中执行，任一操作失败时全部回滚并停止执行
+	Operations []Operation `json:"operations" binding:"required,min=1,dive"` // 按顺序执行的操作
+}
+
+// Operation 批量请求中的单个操作
+// @Description 单个子请求，路径与请求体中可用 {{ref.字段}} 引用前序操作响应 data 中的值
+type Operation struct {
+	Ref     string            `json:"ref,omitempty" example:"p1"`                  // 操作名称，后续操作通过 {{p1.id}} 引用其响应数据
+	Method  string            `json:"method" binding:"required" example:"POST"`    // 请求方法：GET、POST、PUT、PATCH、DELETE
+	Path    string            `json:"path" binding:"required" example:"/products"` // 相对 /api/v1 的路径，可含查询参数
+	Headers map[string]string `json:"headers,omitempty"`                           // 额外请求头，如 JSON Patch 的 Content-Type
+	Body    json.RawMessage   `json:"body,omitempty" swaggertype:"object"`         // 请求体
+}
+
+// Result 单个操作的执行结果
+// @Description 子请求的响应状态码与响应体
+type Result struct {
+	Ref    string          `json:"ref,omitempty" example:"p1"`          // 操作名称
+	Method string          `json:"method" example:"POST"`               // 请求方法
+	Path   string          `json:"path" example:"/products"`            // 替换引用后的实际路径
+	Status int             `json:"status" example:"200"`                // HTTP 状态码
+	Body   json.RawMessage `json:"body,omitempty" swaggertype:"object"` // 响应体
+}
+
+// Response 批量请求结果
+// @Description 各操作按请求顺序的执行结果
+type Response struct {
+	Atomic     bool     `json:"atomic" example:"true"`       // 是否为原子模式
+	Success    bool     `json:"success" example:"true"`      // 是否全部操作均成功
+	RolledBack bool     `json:"rolled_back" example:"false"` // 原子模式下有操作失败，全部变更已回滚
+	Results    []Result `json:"results"`                     // 各操作结果
+}
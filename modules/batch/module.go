@@ -0,0 +1,30 @@
+package batch
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 批量操作模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "batch" }
+
+// RegisterRoutes 实现 module.Module 接口，子请求经由完整路由执行
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) {
+	RegisterRoutes(r, app.DB, app.Router)
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "batch.execute", Description: "执行批量请求，子请求仍按各自接口鉴权"},
+	}
+}
@@ -0,0 +1,19 @@
+package batch
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/ratelimit"
+)
+
+// RegisterRoutes 注册批量接口路由，子请求交由 router 按普通请求处理
+func RegisterRoutes(r *middleware.Router, db *gorm.DB, router http.Handler) {
+	handler := NewHandler(db, router, r.BasePath(), config.GetBatchConfig())
+
+	r.POST("/batch", module.RequirePermission("batch.execute"), middleware.RateLimit(ratelimit.PolicyBulk), handler.Execute)
+}
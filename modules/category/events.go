@@ -0,0 +1,23 @@
+package category
+
+import "erp_backend/pkg/events"
+
+// AggregateType 实现 events.Aggregate 接口
+func (Category) AggregateType() string { return "category" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (c Category) AggregateID() uint { return c.ID }
+
+// 分类领域事件
+type (
+	CategoryCreated  = events.Created[Category]  // 分类创建
+	CategoryUpdated  = events.Updated[Category]  // 分类更新
+	CategoryToggled  = events.Toggled[Category]  // 分类启用状态切换
+	CategoryDeleted  = events.Deleted[Category]  // 分类删除（移入回收站）
+	CategoryRestored = events.Restored[Category] // 分类从回收站恢复
+)
+
+// Events 实现 module.EventsModule 接口
+func (Module) Events() []events.Event {
+	return []events.Event{CategoryCreated{}, CategoryUpdated{}, CategoryToggled{}, CategoryDeleted{}, CategoryRestored{}}
+}
@@ -0,0 +1,176 @@
+package category
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/rpc"
+	"erp_backend/pkg/rpc/erpv1"
+)
+
+// RegisterGRPC 注册分类 gRPC 服务
+func RegisterGRPC(s grpc.ServiceRegistrar, db *gorm.DB) {
+	erpv1.RegisterCategoryServiceServer(s, &grpcServer{service: NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db))})
+}
+
+// grpcPermissions 分类 gRPC 方法所需的权限，与对应 REST 接口一致
+var grpcPermissions = map[string]string{
+	erpv1.CategoryService_GetCategory_FullMethodName:          "category.read",
+	erpv1.CategoryService_ListCategories_FullMethodName:       "category.read",
+	erpv1.CategoryService_ListCategoryChildren_FullMethodName: "category.read",
+	erpv1.CategoryService_CreateCategory_FullMethodName:       "category.write",
+	erpv1.CategoryService_UpdateCategory_FullMethodName:       "category.write",
+	erpv1.CategoryService_DeleteCategory_FullMethodName:       "category.write",
+	erpv1.CategoryService_ToggleCategory_FullMethodName:       "category.write",
+}
+
+// grpcServer 分类 gRPC 服务
+type grpcServer struct {
+	erpv1.UnimplementedCategoryServiceServer
+	service *Service
+}
+
+// GetCategory 获取单个分类
+func (s *grpcServer) GetCategory(ctx context.Context, req *erpv1.GetCategoryRequest) (*erpv1.Category, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.service.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(category), nil
+}
+
+// ListCategories 分页查询分类
+func (s *grpcServer) ListCategories(ctx context.Context, req *erpv1.ListCategoriesRequest) (*erpv1.ListCategoriesResponse, error) {
+	page, err := s.service.List(ctx, rpc.Query(req.Options))
+	if err != nil {
+		return nil, err
+	}
+	categories := *page.Items.(*[]Category)
+	resp := &erpv1.ListCategoriesResponse{Items: make([]*erpv1.Category, len(categories)), Page: rpc.PageInfo(page)}
+	for i := range categories {
+		resp.Items[i] = toProto(&categories[i])
+	}
+	return resp, nil
+}
+
+// ListCategoryChildren 查询直接子分类
+func (s *grpcServer) ListCategoryChildren(ctx context.Context, req *erpv1.ListCategoryChildrenRequest) (*erpv1.ListCategoryChildrenResponse, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.service.Children(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := &erpv1.ListCategoryChildrenResponse{Items: make([]*erpv1.Category, len(categories))}
+	for i := range categories {
+		resp.Items[i] = toProto(&categories[i])
+	}
+	return resp, nil
+}
+
+// CreateCategory 创建分类
+func (s *grpcServer) CreateCategory(ctx context.Context, req *erpv1.CreateCategoryRequest) (*erpv1.Category, error) {
+	if req.Category == nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	category := &Category{
+		Name:        req.Category.Name,
+		Description: req.Category.Description,
+		LevelRemark: req.Category.LevelRemark,
+		IsEnabled:   req.Category.IsEnabled,
+	}
+	if req.Category.ParentId != nil {
+		parentID := uint(req.Category.ParentId.Value)
+		category.ParentID = &parentID
+	}
+	if err := s.service.Create(ctx, category); err != nil {
+		return nil, err
+	}
+	return toProto(category), nil
+}
+
+// UpdateCategory 按字段掩码更新分类，与 PATCH 接口使用相同的白名单、校验规则与领域事件
+func (s *grpcServer) UpdateCategory(ctx context.Context, req *erpv1.UpdateCategoryRequest) (*erpv1.Category, error) {
+	if req.Category == nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	id, err := rpc.ID(req.Category.Id)
+	if err != nil {
+		return nil, err
+	}
+	var parentID interface{}
+	if req.Category.ParentId != nil {
+		parentID = req.Category.ParentId.Value
+	}
+	values, err := rpc.Fields(map[string]interface{}{
+		"name":         req.Category.Name,
+		"description":  req.Category.Description,
+		"parent_id":    parentID,
+		"level_remark": req.Category.LevelRemark,
+		"is_enabled":   req.Category.IsEnabled,
+	}, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.service.Merge(ctx, id, values)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(category), nil
+}
+
+// DeleteCategory 删除分类
+func (s *grpcServer) DeleteCategory(ctx context.Context, req *erpv1.DeleteCategoryRequest) (*emptypb.Empty, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ToggleCategory 切换分类启用状态
+func (s *grpcServer) ToggleCategory(ctx context.Context, req *erpv1.ToggleCategoryRequest) (*erpv1.Category, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	category, err := s.service.Toggle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(category), nil
+}
+
+// toProto 转换为 protobuf 消息
+func toProto(c *Category) *erpv1.Category {
+	msg := &erpv1.Category{
+		Id:          uint64(c.ID),
+		Name:        c.Name,
+		Description: c.Description,
+		LevelRemark: c.LevelRemark,
+		IsEnabled:   c.IsEnabled,
+		CreatedAt:   timestamppb.New(c.CreatedAt),
+		UpdatedAt:   timestamppb.New(c.UpdatedAt),
+	}
+	if c.ParentID != nil {
+		msg.ParentId = wrapperspb.UInt64(uint64(*c.ParentID))
+	}
+	return msg
+}
@@ -1,21 +1,48 @@
 package category
 
 import (
-	"net/http"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/resource"
 	"erp_backend/pkg/response"
 )
 
+// listConfig 分类列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 分类列表筛选字段
+var listFilters = filter.Fields{
+	"id":          filter.Number,
+	"parent_id":   filter.Number,
+	"name":        filter.String,
+	"description": filter.String,
+	"is_enabled":  filter.Bool,
+	"created_at":  filter.Time,
+	"updated_at":  filter.Time,
+}
+
+// patchFields 分类允许通过 PATCH 修改的字段及校验规则
+var patchFields = patch.Fields{
+	"name":         "required,max=100",
+	"description":  "",
+	"parent_id":    "",
+	"level_remark": "",
+	"is_enabled":   "",
+}
+
 type Handler struct {
-	db *gorm.DB
+	resource *resource.Handler[Category]
+	service  *Service
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(service *Service) *Handler {
+	return &Handler{resource: resource.NewHandler(service.Resource), service: service}
 }
 
 // Create 创建分类
@@ -24,43 +51,61 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Tags 分类管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param category body Category true "分类信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Category} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /categories [post]
 func (h *Handler) Create(c *gin.Context) {
-	var category Category
-	if err := c.ShouldBindJSON(&category); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Create(&category).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建分类失败")
-		return
-	}
-
-	response.Success(c, category)
+	h.resource.Create(c)
 }
 
 // List 获取分类列表
 // @Summary 获取分类列表
-// @Description 获取所有分类的列表
+// @Description 分页获取分类列表，回收站中的分类不包含在内
 // @Tags 分类管理
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Response{data=[]Category} "获取成功"
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Category}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /categories [get]
 func (h *Handler) List(c *gin.Context) {
-	var categories []Category
-	if err := h.db.Find(&categories).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取分类列表失败")
-		return
-	}
+	h.resource.List(c)
+}
 
-	response.Success(c, categories)
+// Trash 获取回收站中的分类
+// @Summary 获取回收站中的分类
+// @Description 分页获取已删除的分类，查询参数同列表接口
+// @Tags 分类管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Category}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /categories/trash [get]
+func (h *Handler) Trash(c *gin.Context) {
+	h.resource.Trash(c)
 }
 
 // Get 获取单个分类
@@ -69,102 +114,98 @@ func (h *Handler) List(c *gin.Context) {
 // @Tags 分类管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "分类ID"
 // @Success 200 {object} response.Response{data=Category} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "分类不存在"
 // @Router /categories/{id} [get]
 func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var category Category
-	if err := h.db.First(&category, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "分类不存在")
-		return
-	}
-
-	response.Success(c, category)
+	h.resource.Get(c)
 }
 
 // Update 更新分类
 // @Summary 更新分类
-// @Description 更新分类信息
+// @Description 全量更新分类信息，主键与创建时间不可修改
 // @Tags 分类管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "分类ID"
 // @Param category body Category true "分类信息"
 // @Success 200 {object} response.Response{data=Category} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "分类不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /categories/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var category Category
-	if err := h.db.First(&category, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "分类不存在")
-		return
-	}
-
-	if err := c.ShouldBindJSON(&category); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Save(&category).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新分类失败")
-		return
-	}
+	h.resource.Update(c)
+}
 
-	response.Success(c, category)
+// Patch 部分更新分类
+// @Summary 部分更新分类
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改分类，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 分类管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分类ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Category} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "分类不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /categories/{id} [patch]
+func (h *Handler) Patch(c *gin.Context) {
+	h.resource.Patch(c)
 }
 
 // Delete 删除分类
 // @Summary 删除分类
-// @Description 删除分类
+// @Description 将分类移入回收站，可通过恢复接口恢复；分类不存在时同样返回成功
 // @Tags 分类管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "分类ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 409 {object} response.Response "该分类下存在子分类"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /categories/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	// 检查是否有子分类
-	var count int64
-	if err := h.db.Model(&Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "检查子分类失败")
-		return
-	}
-
-	if count > 0 {
-		response.Error(c, http.StatusBadRequest, "该分类下存在子分类，无法删除")
-		return
-	}
-
-	if err := h.db.Delete(&Category{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除分类失败")
-		return
-	}
+	h.resource.Delete(c)
+}
 
-	response.Success(c, gin.H{"message": "删除成功"})
+// Restore 恢复分类
+// @Summary 恢复分类
+// @Description 将回收站中的分类恢复
+// @Tags 分类管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分类ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Category} "恢复成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "分类不存在或不在回收站中"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /categories/{id}/restore [post]
+func (h *Handler) Restore(c *gin.Context) {
+	h.resource.Restore(c)
 }
 
 // ToggleStatus 切换分类状态
@@ -173,45 +214,43 @@ func (h *Handler) Delete(c *gin.Context) {
 // @Tags 分类管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "分类ID"
-// @Success 200 {object} response.Response{data=Category} "切换成功"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Category} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "分类不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /categories/{id}/toggle [patch]
 func (h *Handler) ToggleStatus(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var category Category
-	if err := h.db.First(&category, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "分类不存在")
-		return
-	}
-
-	category.IsEnabled = !category.IsEnabled
-	if err := h.db.Save(&category).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新状态失败")
-		return
-	}
-
-	response.Success(c, category)
+	h.resource.Toggle(c)
 }
 
 // GetChildren 获取子分类
+// @Summary 获取子分类
+// @Description 获取分类的直接子分类，回收站中的分类不包含在内
+// @Tags 分类管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "分类ID"
+// @Success 200 {object} response.Response{data=[]Category} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /categories/{id}/children [get]
 func (h *Handler) GetChildren(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+	id, ok := resource.ID(c)
+	if !ok {
 		return
 	}
 
-	var categories []Category
-	if err := h.db.Where("parent_id = ?", id).Find(&categories).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取子分类失败")
+	categories, err := h.service.Children(c, id)
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -0,0 +1,46 @@
+package category
+
+import (
+	"google.golang.org/grpc"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 分类管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "category" }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&Category{}} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// RegisterGRPC 实现 module.GRPCModule 接口
+func (Module) RegisterGRPC(s grpc.ServiceRegistrar, app *module.App) { RegisterGRPC(s, app.DB) }
+
+// GRPCPermissions 实现 module.GRPCModule 接口
+func (Module) GRPCPermissions() map[string]string { return grpcPermissions }
+
+// Jobs 实现 module.Module 接口，定期永久删除回收站中过期的分类
+func (Module) Jobs(app *module.App) []module.Job {
+	app.Queue.PurgeTrash("category", &Category{})
+	return nil
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "category.read", Description: "查看分类"},
+		{Code: "category.write", Description: "创建、修改与删除分类", UserTypes: module.Staff},
+	}
+}
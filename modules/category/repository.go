@@ -0,0 +1,44 @@
+package category
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/repository"
+)
+
+// Repository 分类数据访问接口
+type Repository interface {
+	repository.Trashable[Category]
+	// Children 查询直接子分类
+	Children(ctx context.Context, id uint) ([]Category, error)
+	// CountChildren 统计直接子分类数量
+	CountChildren(ctx context.Context, id uint) (int64, error)
+}
+
+// gormRepository 基于 GORM 的分类仓储
+type gormRepository struct {
+	repository.Gorm[Category]
+}
+
+// NewRepository 创建基于 GORM 的分类仓储
+func NewRepository(db *gorm.DB) Repository {
+	return gormRepository{Gorm: repository.NewGorm[Category](db)}
+}
+
+// Children 实现 Repository 接口
+func (r gormRepository) Children(ctx context.Context, id uint) ([]Category, error) {
+	var categories []Category
+	if err := r.DB(ctx).Where("parent_id = ?", id).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CountChildren 实现 Repository 接口
+func (r gormRepository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.DB(ctx).Model(&Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
@@ -1,22 +1,30 @@
 package category
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
 )
 
 // RegisterRoutes 注册分类相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db)))
 
+	read, write := module.RequirePermission("category.read"), module.RequirePermission("category.write")
 	categories := r.Group("/categories")
 	{
-		categories.POST("", handler.Create)
-		categories.GET("", handler.List)
-		categories.GET("/:id", handler.Get)
-		categories.PUT("/:id", handler.Update)
-		categories.DELETE("/:id", handler.Delete)
-		categories.PATCH("/:id/toggle", handler.ToggleStatus)
-		categories.GET("/:id/children", handler.GetChildren)
+		categories.POST("", write, handler.Create)
+		categories.GET("", read, handler.List)
+		categories.GET("/trash", read, handler.Trash)
+		categories.GET("/:id", read, handler.Get)
+		categories.PUT("/:id", write, handler.Update)
+		categories.PATCH("/:id", write, handler.Patch)
+		categories.DELETE("/:id", write, handler.Delete)
+		categories.POST("/:id/restore", write, handler.Restore)
+		categories.PATCH("/:id/toggle", write, handler.ToggleStatus)
+		categories.GET("/:id/children", read, handler.GetChildren)
 	}
 }
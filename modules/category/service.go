@@ -0,0 +1,52 @@
+package category
+
+import (
+	"context"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/resource"
+)
+
+// Service 分类业务逻辑，供 HTTP、gRPC 与后台任务共用，增删改查、切换状态与回收站等通用操作由 resource.Resource 提供
+type Service struct {
+	*resource.Resource[Category]
+	repo Repository
+}
+
+// NewService 创建分类服务
+func NewService(repo Repository, tx database.Transactor, publisher events.Publisher) *Service {
+	s := &Service{repo: repo}
+	s.Resource = resource.New[Category](repo, tx, publisher, resource.Config[Category]{
+		Errors:      errcode.Category,
+		List:        listConfig,
+		Filters:     listFilters,
+		PatchFields: patchFields,
+		Hooks: resource.Hooks[Category]{
+			BeforeDelete: s.checkChildren,
+		},
+	})
+	return s
+}
+
+// Children 查询直接子分类
+func (s *Service) Children(ctx context.Context, id uint) ([]Category, error) {
+	categories, err := s.repo.Children(ctx, id)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.CategoryChildrenListFailed)
+	}
+	return categories, nil
+}
+
+// checkChildren 存在子分类时不允许删除
+func (s *Service) checkChildren(ctx context.Context, category *Category) error {
+	count, err := s.repo.CountChildren(ctx, category.ID)
+	if err != nil {
+		return errcode.FromDB(err, errcode.CategoryChildrenListFailed)
+	}
+	if count > 0 {
+		return errcode.CategoryHasChildren.New()
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/pagination"
+)
+
+// analyze 执行前检查请求：语法与校验错误、GET 请求中的变更、已禁用的内省查询，
+// 以及查询深度与复杂度上限。复杂度按字段计数，带 pageSize 或 limit 参数的列表字段按条数成倍计算
+func (s *Schema) analyze(req Request, cfg *config.GraphQLConfig, queryOnly bool) error {
+	if strings.TrimSpace(req.Query) == "" {
+		return errcode.GraphQLQueryMissing.New()
+	}
+	doc, errs := gqlparser.LoadQuery(s.ast, req.Query)
+	if len(errs) > 0 {
+		return errcode.GraphQLInvalid.New(errs[0].Message)
+	}
+
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		if req.OperationName == "" {
+			return errcode.GraphQLOperationNameRequired.New()
+		}
+		return errcode.GraphQLOperationNotFound.New(req.OperationName)
+	}
+	if queryOnly && op.Operation != ast.Query {
+		return errcode.GraphQLMutationNotAllowed.New()
+	}
+
+	a := &analyzer{cfg: cfg, vars: req.Variables}
+	depth, complexity := a.selectionSet(op.SelectionSet, 1)
+	if a.introspection && !cfg.Introspection {
+		return errcode.GraphQLIntrospectionDisabled.New()
+	}
+	if cfg.MaxDepth > 0 && depth > cfg.MaxDepth {
+		return errcode.GraphQLTooDeep.New(depth, cfg.MaxDepth)
+	}
+	if cfg.MaxComplexity > 0 && complexity > cfg.MaxComplexity {
+		return errcode.GraphQLTooComplex.New(complexity, cfg.MaxComplexity)
+	}
+	return nil
+}
+
+// analyzer 遍历已通过校验的操作，片段不存在循环引用
+type analyzer struct {
+	cfg           *config.GraphQLConfig
+	vars          map[string]interface{}
+	introspection bool // 是否包含内省字段
+}
+
+// selectionSet 返回选择集的最大深度与复杂度，片段展开时深度不增加
+func (a *analyzer) selectionSet(set ast.SelectionSet, depth int) (int, int) {
+	maxDepth, complexity := 0, 0
+	for _, selection := range set {
+		var d, c int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if a.skip(sel.Directives) {
+				continue
+			}
+			d, c = a.field(sel, depth)
+		case *ast.FragmentSpread:
+			if a.skip(sel.Directives) {
+				continue
+			}
+			d, c = a.selectionSet(sel.Definition.SelectionSet, depth)
+		case *ast.InlineFragment:
+			if a.skip(sel.Directives) {
+				continue
+			}
+			d, c = a.selectionSet(sel.SelectionSet, depth)
+		}
+		maxDepth = max(maxDepth, d)
+		complexity += c
+	}
+	return maxDepth, complexity
+}
+
+// field 字段的深度与复杂度，内省字段与 __typename 不计入
+func (a *analyzer) field(sel *ast.Field, depth int) (int, int) {
+	if strings.HasPrefix(sel.Name, "__") {
+		if sel.Name != "__typename" {
+			a.introspection = true
+		}
+		return 0, 0
+	}
+	fieldDepth, child := depth, 0
+	if len(sel.SelectionSet) > 0 {
+		fieldDepth, child = a.selectionSet(sel.SelectionSet, depth+1)
+	}
+	return fieldDepth, 1 + a.multiplier(sel)*child
+}
+
+// multiplier 列表字段的条数：取 pageSize 或 limit 参数，未提供时取参数默认值或默认每页条数
+func (a *analyzer) multiplier(sel *ast.Field) int {
+	for _, name := range []string{"pageSize", "limit"} {
+		def := sel.Definition.Arguments.ForName(name)
+		if def == nil {
+			continue
+		}
+		size := 0
+		if arg := sel.Arguments.ForName(name); arg != nil {
+			size = a.intValue(arg.Value)
+		} else if def.DefaultValue != nil {
+			size = a.intValue(def.DefaultValue)
+		}
+		if size <= 0 {
+			size = pagination.DefaultPageSize
+		}
+		return min(size, pagination.MaxPageSize)
+	}
+	return 1
+}
+
+// intValue 取整数参数值，变量按请求中的值计算
+func (a *analyzer) intValue(v *ast.Value) int {
+	value, err := v.Value(a.vars)
+	if err != nil {
+		return 0
+	}
+	switch n := value.(type) {
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// skip 按 @skip 与 @include 指令判断是否跳过
+func (a *analyzer) skip(directives ast.DirectiveList) bool {
+	if d := directives.ForName("skip"); d != nil && a.condition(d) {
+		return true
+	}
+	if d := directives.ForName("include"); d != nil && !a.condition(d) {
+		return true
+	}
+	return false
+}
+
+// condition 取指令的 if 参数
+func (a *analyzer) condition(d *ast.Directive) bool {
+	arg := d.Arguments.ForName("if")
+	if arg == nil {
+		return false
+	}
+	value, _ := arg.Value.Value(a.vars)
+	b, _ := value.(bool)
+	return b
+}
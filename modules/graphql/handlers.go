@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/response"
+)
+
+type Handler struct {
+	schema   *Schema
+	db       *gorm.DB
+	router   http.Handler
+	basePath string
+	cfg      *config.GraphQLConfig
+}
+
+func NewHandler(db *gorm.DB, router http.Handler, basePath string, cfg *config.GraphQLConfig) *Handler {
+	return &Handler{schema: newSchema(), db: db, router: router, basePath: basePath, cfg: cfg}
+}
+
+// Execute 执行 GraphQL 请求
+// @Summary GraphQL
+// @Description 以 GraphQL 查询供应商、店铺、商品、分类、链接、属性与商品属性值及其关联关系，变更与对应 REST 接口一致。
+// @Description 顶层查询与变更经由对应的 REST 接口执行，认证、权限、校验与错误码完全相同，错误码见 errors[].extensions.code；
+// @Description 关联字段（如 product.supplier、shop.links）按层批量加载，不会产生 N+1 查询。
+// @Description 查询深度或复杂度（列表字段按每页条数成倍计算）超过上限时拒绝执行。请求本身计入 bulk 限流额度，每个子请求再按对应接口的额度计数，变更支持幂等键
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body Request true "GraphQL 请求"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} Response "执行完成，字段级错误见 errors"
+// @Failure 400 {object} Response "语法错误、校验失败或超过深度、复杂度上限"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} Response "无权限或内省查询已禁用"
+// @Failure 413 {object} Response "请求体过大"
+// @Failure 429 {object} response.Response "请求过于频繁"
+// @Router /graphql [post]
+func (h *Handler) Execute(c *gin.Context) {
+	var req Request
+	decoder := json.NewDecoder(http.MaxBytesReader(c.Writer, c.Request.Body, int64(h.cfg.MaxBodySize)))
+	if err := decoder.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.fail(c, errcode.RequestTooLarge.New())
+		} else {
+			h.fail(c, errcode.InvalidParams.New())
+		}
+		return
+	}
+	h.execute(c, req, false)
+}
+
+// Query 以 GET 请求执行 GraphQL 查询
+// @Summary GraphQL（GET）
+// @Description 与 POST /graphql 相同，但只能执行查询，便于缓存；variables 为 JSON 字符串
+// @Tags GraphQL
+// @Produce json
+// @Security ApiKeyAuth
+// @Param query query string true "查询语句"
+// @Param operationName query string false "操作名称"
+// @Param variables query string false "变量，JSON 格式"
+// @Success 200 {object} Response "执行完成，字段级错误见 errors"
+// @Failure 400 {object} Response "语法错误、校验失败或超过深度、复杂度上限"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} Response "无权限或内省查询已禁用"
+// @Failure 405 {object} Response "GET 请求不能执行变更"
+// @Failure 429 {object} response.Response "请求过于频繁"
+// @Router /graphql [get]
+func (h *Handler) Query(c *gin.Context) {
+	req := Request{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if raw := c.Query("variables"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+			h.fail(c, errcode.InvalidParams.New())
+			return
+		}
+	}
+	h.execute(c, req, true)
+}
+
+// execute 执行请求，请求级错误按错误码返回对应状态码，字段级错误返回 200
+func (h *Handler) execute(c *gin.Context, req Request, queryOnly bool) {
+	if err := h.schema.analyze(req, h.cfg, queryOnly); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	ctx := middleware.WithSubRequest(c.Request.Context())
+	ctx = context.WithValue(ctx, callerKey{}, &caller{c: c, router: h.router, basePath: h.basePath})
+	ctx = withLoaders(ctx, h.db)
+
+	result := h.schema.exec.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	resp := Response{Errors: make([]Error, 0, len(result.Errors))}
+	for _, err := range result.Errors {
+		resp.Errors = append(resp.Errors, toError(c, err))
+	}
+	switch {
+	case result.Data != nil:
+		resp.Data = result.Data
+	case len(result.Errors) > 0 && result.Errors[0].Path == nil:
+		// 请求未通过校验，不返回 data
+		c.JSON(resp.Errors[0].Extensions.Status, resp)
+		return
+	default:
+		// 非空的顶层字段出错，空值传递到根对象
+		resp.Data = json.RawMessage("null")
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// fail 请求无法执行时的错误响应
+func (h *Handler) fail(c *gin.Context, err error) {
+	e := toError(c, &gqlerrors.QueryError{ResolverError: err})
+	c.JSON(e.Extensions.Status, Response{Errors: []Error{e}})
+}
+
+// toError 转换为 GraphQL 错误，REST 接口的错误保留其错误码与字段详情，其余错误按请求语言翻译；
+// 没有路径的执行器错误（如变量类型不符）视为查询无效
+func toError(c *gin.Context, err *gqlerrors.QueryError) Error {
+	var resp response.Response
+	var re *restError
+	switch {
+	case errors.As(err.ResolverError, &re):
+		resp = re.resp
+	case err.ResolverError != nil:
+		resp = response.ErrorResponse(c, err.ResolverError)
+	case err.Path == nil:
+		resp = response.ErrorResponse(c, errcode.GraphQLInvalid.New(err.Message))
+	default:
+		resp = response.ErrorResponse(c, err)
+	}
+
+	locations := make([]Location, 0, len(err.Locations))
+	for _, loc := range err.Locations {
+		locations = append(locations, Location{Line: loc.Line, Column: loc.Column})
+	}
+	return Error{
+		Message:   resp.Message,
+		Locations: locations,
+		Path:      err.Path,
+		Extensions: ErrorExtensions{
+			Code:    resp.ErrorCode,
+			Status:  resp.Code,
+			Details: resp.Details,
+			TraceID: resp.TraceID,
+		},
+	}
+}
@@ -0,0 +1,213 @@
+package graphql
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+)
+
+// restCall 假 REST 接口收到的子请求
+type restCall struct {
+	method string
+	target string
+	body   string
+}
+
+// testServer GraphQL 接口与返回固定响应的假 REST 接口
+type testServer struct {
+	router *gin.Engine
+	calls  []restCall
+}
+
+// newTestServer 创建测试服务，REST 子请求按 "方法 路径" 取 responses 中的状态码与响应体，未配置的返回 404
+func newTestServer(cfg *config.GraphQLConfig, responses map[string]string) *testServer {
+	gin.SetMode(gin.TestMode)
+	s := &testServer{}
+
+	rest := gin.New()
+	rest.NoRoute(func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		s.calls = append(s.calls, restCall{method: c.Request.Method, target: c.Request.URL.RequestURI(), body: string(body)})
+		if resp, ok := responses[c.Request.Method+" "+c.Request.URL.Path]; ok {
+			c.Data(http.StatusOK, "application/json", []byte(resp))
+			return
+		}
+		c.Data(http.StatusNotFound, "application/json", []byte(`{"code":404,"message":"商品不存在","error_code":"PRODUCT_NOT_FOUND"}`))
+	})
+
+	handler := NewHandler(nil, rest, "/api/v1", cfg)
+	s.router = gin.New()
+	s.router.POST("/api/v1/graphql", handler.Execute)
+	s.router.GET("/api/v1/graphql", handler.Query)
+	return s
+}
+
+// post 以 POST 执行查询，返回状态码与响应
+func (s *testServer) post(t *testing.T, query string, variables map[string]interface{}) (int, Response) {
+	t.Helper()
+	payload, _ := json.Marshal(Request{Query: query, Variables: variables})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", strings.NewReader(string(payload)))
+	req.Header.Set("Content-Type", "application/json")
+	return s.serve(t, req)
+}
+
+// get 以 GET 执行查询，返回状态码与响应
+func (s *testServer) get(t *testing.T, query string) (int, Response) {
+	t.Helper()
+	return s.serve(t, httptest.NewRequest(http.MethodGet, "/api/v1/graphql?query="+url.QueryEscape(query), nil))
+}
+
+func (s *testServer) serve(t *testing.T, req *http.Request) (int, Response) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("响应不是 JSON: %s", w.Body.String())
+	}
+	return w.Code, resp
+}
+
+// data 将响应中的 data 重新编码为 JSON 字符串，便于比较
+func data(t *testing.T, resp Response) string {
+	t.Helper()
+	raw, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatalf("data 无法编码: %v", err)
+	}
+	return string(raw)
+}
+
+func TestAnalyze(t *testing.T) {
+	cfg := &config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 100, Introspection: false, MaxBodySize: 1 << 20}
+
+	tests := []struct {
+		name   string
+		get    bool
+		query  string
+		status int
+		code   string
+	}{
+		{"缺少查询语句", false, " ", http.StatusBadRequest, errcode.GraphQLQueryMissing.Key},
+		{"语法错误", false, "{ product(id: 1) { id ", http.StatusBadRequest, errcode.GraphQLInvalid.Key},
+		{"字段不存在", false, `{ product(id: "1") { price2 } }`, http.StatusBadRequest, errcode.GraphQLInvalid.Key},
+		{"多个操作未指定名称", false, `query A { product(id: "1") { id } } query B { product(id: "2") { id } }`, http.StatusBadRequest, errcode.GraphQLOperationNameRequired.Key},
+		{"GET 请求不能执行变更", true, `mutation { deleteProduct(id: "1") }`, http.StatusMethodNotAllowed, errcode.GraphQLMutationNotAllowed.Key},
+		{"内省查询已禁用", false, "{ __schema { types { name } } }", http.StatusForbidden, errcode.GraphQLIntrospectionDisabled.Key},
+		{"超过深度上限", false, `{ product(id: "1") { supplier { shops { links { id } } } } }`, http.StatusBadRequest, errcode.GraphQLTooDeep.Key},
+		{"片段展开后超过深度上限", false, `{ product(id: "1") { ...P } } fragment P on Product { supplier { shops { links { id } } } }`, http.StatusBadRequest, errcode.GraphQLTooDeep.Key},
+		{"列表按每页条数计算复杂度", false, "{ products(pageSize: 50) { items { id name } } }", http.StatusBadRequest, errcode.GraphQLTooComplex.Key},
+		{"关联列表按 limit 默认值计算复杂度", false, `{ supplier(id: "1") { shops { id name remark isEnabled createdAt updatedAt } } }`, http.StatusBadRequest, errcode.GraphQLTooComplex.Key},
+		{"跳过的字段不计入复杂度", false, "{ products(pageSize: 50) @skip(if: true) { items { id name } } }", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(cfg, nil)
+			var status int
+			var resp Response
+			if tt.get {
+				status, resp = s.get(t, tt.query)
+			} else {
+				status, resp = s.post(t, tt.query, nil)
+			}
+			code := ""
+			if len(resp.Errors) > 0 {
+				code = resp.Errors[0].Extensions.Code
+			}
+			if status != tt.status || code != tt.code {
+				t.Errorf("状态码与错误码 = (%d, %s), 期望 (%d, %s)", status, code, tt.status, tt.code)
+			}
+			if tt.code != "" && len(s.calls) > 0 {
+				t.Errorf("请求被拒绝时不应发起子请求，实际 %v", s.calls)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	cfg := &config.GraphQLConfig{MaxDepth: 10, MaxComplexity: 1000, Introspection: true, MaxBodySize: 1 << 20}
+	product := `{"id":7,"name":"键盘","sku":"KB-1","price":99.5,"stock":3,"dynamic_attrs":{"color":"黑"},"created_at":"2024-01-02T03:04:05Z"}`
+	responses := map[string]string{
+		"GET /api/v1/products/7":   `{"code":200,"data":` + product + `}`,
+		"GET /api/v1/products":     `{"code":200,"data":{"items":[` + product + `],"total":1,"page":1,"page_size":5}}`,
+		"PATCH /api/v1/products/7": `{"code":200,"data":` + product + `}`,
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		data      string
+		code      string
+		call      restCall
+	}{
+		{
+			name:  "按 ID 查询",
+			query: `{ product(id: "7") { id name price stock dynamicAttrs createdAt } }`,
+			data:  `{"product":{"createdAt":"2024-01-02T03:04:05Z","dynamicAttrs":{"color":"黑"},"id":"7","name":"键盘","price":99.5,"stock":3}}`,
+			call:  restCall{method: http.MethodGet, target: "/api/v1/products/7"},
+		},
+		{
+			name:  "不存在时返回 null",
+			query: `{ product(id: "8") { id } }`,
+			data:  `{"product":null}`,
+			call:  restCall{method: http.MethodGet, target: "/api/v1/products/8"},
+		},
+		{
+			name:  "列表参数转换为 REST 查询参数",
+			query: `{ products(pageSize: 5, sort: "-createdAt,name", filter: [{field: "supplierId", value: "3"}, {field: "price", op: GTE, value: "10"}]) { total pageSize items { sku } } }`,
+			data:  `{"products":{"items":[{"sku":"KB-1"}],"pageSize":5,"total":1}}`,
+			call: restCall{method: http.MethodGet, target: "/api/v1/products?" + url.Values{
+				"page_size":               {"5"},
+				"sort":                    {"-created_at,name"},
+				"filter[supplier_id][eq]": {"3"},
+				"filter[price][gte]":      {"10"},
+			}.Encode()},
+		},
+		{
+			name:      "部分更新只提交提供的字段",
+			query:     `mutation($input: ProductInput!) { patchProduct(id: "7", input: $input) { id } }`,
+			variables: map[string]interface{}{"input": map[string]interface{}{"name": "键盘", "categoryId": "2", "remark": nil}},
+			data:      `{"patchProduct":{"id":"7"}}`,
+			call:      restCall{method: http.MethodPatch, target: "/api/v1/products/7", body: `{"category_id":2,"name":"键盘","remark":null}`},
+		},
+		{
+			name:  "REST 错误保留错误码与路径",
+			query: `mutation { deleteProduct(id: "8") }`,
+			data:  `null`,
+			code:  "PRODUCT_NOT_FOUND",
+			call:  restCall{method: http.MethodDelete, target: "/api/v1/products/8"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(cfg, responses)
+			status, resp := s.post(t, tt.query, tt.variables)
+			if status != http.StatusOK {
+				t.Fatalf("状态码 = %d, 期望 200, 错误 %+v", status, resp.Errors)
+			}
+			if got := data(t, resp); got != tt.data {
+				t.Errorf("data = %s, 期望 %s", got, tt.data)
+			}
+			switch {
+			case tt.code == "" && len(resp.Errors) > 0:
+				t.Errorf("不应返回错误，实际 %+v", resp.Errors)
+			case tt.code != "" && (len(resp.Errors) != 1 || resp.Errors[0].Extensions.Code != tt.code || len(resp.Errors[0].Path) == 0):
+				t.Errorf("错误 = %+v, 期望带路径的 %s", resp.Errors, tt.code)
+			}
+			if len(s.calls) != 1 || s.calls[0] != tt.call {
+				t.Errorf("子请求 = %+v, 期望 %+v", s.calls, tt.call)
+			}
+		})
+	}
+}
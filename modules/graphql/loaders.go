@@ -0,0 +1,165 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/pagination"
+)
+
+// 关联字段直接查询数据库，不经过 REST 接口：各资源的 read 权限授予所有登录用户，与执行 GraphQL 所需的登录要求一致。
+// 同一次加载得到的对象组成一个批次，批次中任一对象解析关联字段时为整个批次一次执行 IN 查询，
+// 其余对象直接取缓存，因此每层每种关联只查询一次，与执行器的并发解析顺序无关
+
+// loaders 请求级的加载器注册表
+type loaders struct {
+	db    *gorm.DB
+	mu    sync.Mutex
+	items map[string]interface{}
+}
+
+// loadersKey loaders 在 context 中的键
+type loadersKey struct{}
+
+// withLoaders 为请求创建加载器注册表
+func withLoaders(ctx context.Context, db *gorm.DB) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{db: db, items: make(map[string]interface{})})
+}
+
+// loader 按键批量加载并缓存结果
+type loader[V any] struct {
+	mu      sync.Mutex
+	fetch   func(ctx context.Context, keys []uint) (map[uint]V, error)
+	results map[uint]V
+	errs    map[uint]error
+}
+
+// getLoader 获取请求级的加载器，同一请求中相同名称返回同一个加载器
+// name 需包含影响查询结果的参数，如 "shop.links:20"
+func getLoader[V any](ctx context.Context, name string, fetch func(ctx context.Context, db *gorm.DB, keys []uint) (map[uint]V, error)) *loader[V] {
+	registry := ctx.Value(loadersKey{}).(*loaders)
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if l, ok := registry.items[name].(*loader[V]); ok {
+		return l
+	}
+	l := &loader[V]{
+		fetch: func(ctx context.Context, keys []uint) (map[uint]V, error) {
+			return fetch(ctx, registry.db, keys)
+		},
+		results: make(map[uint]V),
+		errs:    make(map[uint]error),
+	}
+	registry.items[name] = l
+	return l
+}
+
+// load 加载 key 对应的值，未缓存时与批次中其他对象的键 siblings 一起加载
+func (l *loader[V]) load(ctx context.Context, key uint, siblings []uint) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, done := l.results[key]; !done && l.errs[key] == nil {
+		seen := make(map[uint]bool, len(siblings)+1)
+		var keys []uint
+		for _, k := range append([]uint{key}, siblings...) {
+			if _, done := l.results[k]; done || l.errs[k] != nil || seen[k] || k == 0 {
+				continue
+			}
+			seen[k] = true
+			keys = append(keys, k)
+		}
+
+		values, err := l.fetch(ctx, keys)
+		for _, k := range keys {
+			if err != nil {
+				l.errs[k] = err
+				continue
+			}
+			l.results[k] = values[k]
+		}
+	}
+	return l.results[key], l.errs[key]
+}
+
+// keys 取批次中每个对象的键
+func keys[T any](batch []*T, key func(*T) uint) []uint {
+	result := make([]uint, 0, len(batch))
+	for _, item := range batch {
+		result = append(result, key(item))
+	}
+	return result
+}
+
+// belongsTo 多对一关联：按批次中各对象的外键查询关联对象，查到的对象组成新的批次
+func belongsTo[T events.Aggregate](ctx context.Context, id uint, siblings []uint) (*T, []*T, error) {
+	type result struct {
+		entity *T
+		batch  []*T
+	}
+	l := getLoader(ctx, fmt.Sprintf("%T", new(T)), func(ctx context.Context, db *gorm.DB, ids []uint) (map[uint]result, error) {
+		var rows []*T
+		if err := db.WithContext(ctx).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		results := make(map[uint]result, len(rows))
+		for _, row := range rows {
+			results[(*row).AggregateID()] = result{entity: row, batch: rows}
+		}
+		return results, nil
+	})
+	if id == 0 {
+		return nil, nil, nil
+	}
+	r, err := l.load(ctx, id, siblings)
+	return r.entity, r.batch, err
+}
+
+// hasMany 一对多关联：按子对象的外键 fk 为批次中的全部父对象查询，每个父对象最多 limit 条，
+// 查到的全部子对象组成新的批次
+func hasMany[T any](ctx context.Context, fk string, key func(*T) uint, limit int32, id uint, siblings []uint) ([]*T, []*T, error) {
+	type result struct {
+		items []*T
+		batch []*T
+	}
+	size := relationLimit(limit)
+	name := fmt.Sprintf("%T.%s:%d", new(T), fk, size)
+	l := getLoader(ctx, name, func(ctx context.Context, db *gorm.DB, ids []uint) (map[uint]result, error) {
+		// 按外键分组编号，每组只取前 limit 条
+		inner := db.WithContext(ctx).Model(new(T)).
+			Select("*, ROW_NUMBER() OVER (PARTITION BY "+fk+" ORDER BY id) AS row_num").
+			Where(fk+" IN ?", ids)
+		var rows []*T
+		err := db.WithContext(ctx).Table("(?) AS t", inner).
+			Where("row_num <= ?", size).
+			Order(fk + ", id").
+			Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		groups := make(map[uint][]*T, len(ids))
+		for _, row := range rows {
+			groups[key(row)] = append(groups[key(row)], row)
+		}
+		results := make(map[uint]result, len(ids))
+		for _, id := range ids {
+			results[id] = result{items: groups[id], batch: rows}
+		}
+		return results, nil
+	})
+	r, err := l.load(ctx, id, siblings)
+	return r.items, r.batch, err
+}
+
+// relationLimit 关联列表条数，限制在 1 到 pagination.MaxPageSize 之间
+func relationLimit(limit int32) int {
+	if limit <= 0 {
+		return pagination.DefaultPageSize
+	}
+	return min(int(limit), pagination.MaxPageSize)
+}
@@ -0,0 +1,45 @@
+package graphql
+
+import (
+	"erp_backend/pkg/errcode"
+)
+
+// Request GraphQL 请求
+// @Description 标准 GraphQL 请求，GET 请求时以同名查询参数传递（variables 为 JSON 字符串）
+type Request struct {
+	Query         string                 `json:"query" example:"{ products(pageSize: 5) { items { id name supplier { name } } } }"` // 查询语句
+	OperationName string                 `json:"operationName,omitempty"`                                                           // 文档包含多个操作时要执行的操作名称
+	Variables     map[string]interface{} `json:"variables,omitempty"`                                                               // 变量
+}
+
+// Response GraphQL 响应
+// @Description 执行结果，字段出错时该字段为 null 并在 errors 中给出原因
+type Response struct {
+	Data   interface{} `json:"data,omitempty" swaggertype:"object"` // 查询结果，请求未通过校验时不返回
+	Errors []Error     `json:"errors,omitempty"`                    // 错误列表
+}
+
+// Error GraphQL 错误
+// @Description 错误信息、在查询中的位置与在结果中的路径
+type Error struct {
+	Message    string          `json:"message" example:"商品不存在"`                   // 提示信息，按请求语言翻译
+	Locations  []Location      `json:"locations,omitempty"`                       // 在查询语句中的位置
+	Path       []interface{}   `json:"path,omitempty" swaggertype:"array,string"` // 出错字段在结果中的路径
+	Extensions ErrorExtensions `json:"extensions"`                                // 业务错误码等扩展信息
+}
+
+// ErrorExtensions 错误扩展信息
+// @Description 与 REST 接口一致的业务错误码与字段详情
+type ErrorExtensions struct {
+	Code    string               `json:"code" example:"PRODUCT_NOT_FOUND"` // 业务错误码，见 GET /error-codes
+	Status  int                  `json:"status" example:"404"`             // 对应 REST 接口的 HTTP 状态码
+	Details []errcode.FieldError `json:"details,omitempty"`                // 字段级错误详情
+	TraceID string               `json:"trace_id,omitempty"`               // 追踪ID
+}
+
+// Location 在查询语句中的位置
+// @Description 行号与列号
+type Location struct {
+	Line   int `json:"line"`   // 行号，从 1 开始
+	Column int `json:"column"` // 列号，从 1 开始
+}
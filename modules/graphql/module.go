@@ -0,0 +1,35 @@
+package graphql
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module GraphQL 模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "graphql" }
+
+// DependsOn 实现 module.Module 接口，查询与变更转发到这些模块的接口
+func (Module) DependsOn() []string {
+	return []string{"supplier", "shop", "link", "product", "category", "attribute"}
+}
+
+// RegisterRoutes 实现 module.Module 接口，顶层查询与变更经由完整路由执行
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) {
+	RegisterRoutes(r, app.DB, app.Router)
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "graphql.query", Description: "执行 GraphQL 查询与变更，顶层字段仍按对应接口鉴权"},
+	}
+}
@@ -0,0 +1,533 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/logger"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/response"
+	"erp_backend/pkg/subrequest"
+)
+
+// 顶层查询与变更经路由以子请求调用 REST 接口，认证、权限、校验、错误码与领域事件都与直接调用一致
+
+// caller 发起子请求所需的原请求信息
+type caller struct {
+	c        *gin.Context
+	router   http.Handler
+	basePath string
+	seq      atomic.Int64 // 子请求序号，用于生成子请求ID
+}
+
+// callerKey caller 在 context 中的键
+type callerKey struct{}
+
+// restError REST 接口返回的错误响应
+type restError struct {
+	resp response.Response
+}
+
+// Error 实现 error 接口
+func (e *restError) Error() string {
+	return e.resp.Message
+}
+
+// call 执行子请求，成功时将响应中的 data 解析到 out
+func call(ctx context.Context, method, target string, body, out interface{}) error {
+	cl := ctx.Value(callerKey{}).(*caller)
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := subrequest.New(ctx, cl.c.Request, method, cl.basePath+target, payload)
+	if err != nil {
+		return errcode.InvalidParams.New()
+	}
+	req.Header.Set(middleware.RequestIDHeader, fmt.Sprintf("%s-g%d", cl.c.GetString(logger.RequestIDKey), cl.seq.Add(1)))
+
+	rec := subrequest.Do(cl.router, req)
+
+	var resp struct {
+		response.Response
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body(), &resp); err != nil {
+		return fmt.Errorf("%s %s 响应无法解析: %w", method, target, err)
+	}
+	if rec.Status() >= http.StatusBadRequest {
+		return &restError{resp: resp.Response}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, out); err != nil {
+		return fmt.Errorf("%s %s 响应无法解析: %w", method, target, err)
+	}
+	return nil
+}
+
+// resourcePath 资源路径，如 /products/12
+func resourcePath(path string, id graphql.ID) string {
+	return path + "/" + url.PathEscape(string(id))
+}
+
+// idArgs 按 ID 操作的参数
+type idArgs struct {
+	ID graphql.ID
+}
+
+// filterInput 列表筛选条件
+type filterInput struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// listArgs 列表查询参数，与 REST 列表接口的分页、排序、筛选参数一致
+type listArgs struct {
+	Page     *int32
+	PageSize *int32
+	Cursor   *string
+	Sort     *string
+	Filter   *[]filterInput
+}
+
+// query 转换为 REST 列表接口的查询参数，字段名转为下划线形式
+func (a listArgs) query() url.Values {
+	query := url.Values{}
+	if a.Page != nil {
+		query.Set("page", strconv.Itoa(int(*a.Page)))
+	}
+	if a.PageSize != nil {
+		query.Set("page_size", strconv.Itoa(int(*a.PageSize)))
+	}
+	if a.Cursor != nil {
+		query.Set("cursor", *a.Cursor)
+	}
+	if a.Sort != nil {
+		fields := strings.Split(*a.Sort, ",")
+		for i, field := range fields {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			fields[i] = snakeCase(strings.TrimPrefix(field, "-"))
+			if desc {
+				fields[i] = "-" + fields[i]
+			}
+		}
+		query.Set("sort", strings.Join(fields, ","))
+	}
+	if a.Filter != nil {
+		for _, f := range *a.Filter {
+			query.Add(fmt.Sprintf("filter[%s][%s]", snakeCase(f.Field), strings.ToLower(f.Op)), f.Value)
+		}
+	}
+	return query
+}
+
+// page 分页结果
+type page[R any] struct {
+	Items      []*R
+	Total      int32
+	Page       int32
+	PageSize   int32
+	NextCursor *string
+}
+
+// get 按 ID 查询，不存在时返回 null
+func get[T, R any](ctx context.Context, path string, id graphql.ID, fn func(T, []*T) *R) (*R, error) {
+	result, err := fetch(ctx, http.MethodGet, resourcePath(path, id), nil, fn)
+	if re, ok := err.(*restError); ok && re.resp.Code == http.StatusNotFound {
+		return nil, nil
+	}
+	return result, err
+}
+
+// list 分页列表，当前页的对象组成一个批次
+func list[T, R any](ctx context.Context, path string, args listArgs, fn func(T, []*T) *R) (*page[R], error) {
+	target := path
+	if query := args.query(); len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var data struct {
+		Items      []*T    `json:"items"`
+		Total      int32   `json:"total"`
+		Page       int32   `json:"page"`
+		PageSize   int32   `json:"page_size"`
+		NextCursor *string `json:"next_cursor"`
+	}
+	if err := call(ctx, http.MethodGet, target, nil, &data); err != nil {
+		return nil, err
+	}
+	return &page[R]{
+		Items:      wrap(data.Items, data.Items, fn),
+		Total:      data.Total,
+		Page:       data.Page,
+		PageSize:   data.PageSize,
+		NextCursor: data.NextCursor,
+	}, nil
+}
+
+// fetch 执行返回单个对象的子请求
+func fetch[T, R any](ctx context.Context, method, target string, body interface{}, fn func(T, []*T) *R) (*R, error) {
+	var entity T
+	if err := call(ctx, method, target, body, &entity); err != nil {
+		return nil, err
+	}
+	return fn(entity, []*T{&entity}), nil
+}
+
+// remove 删除，成功时返回 true
+func remove(ctx context.Context, path string, id graphql.ID) (bool, error) {
+	if err := call(ctx, http.MethodDelete, resourcePath(path, id), nil, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// updateAndGet 调用 PATCH {path}/{id}/{field} 更新单个字段后重新查询，接口本身只返回提示信息
+func updateAndGet[T, R any](ctx context.Context, path string, id graphql.ID, field string, value interface{}, fn func(T, []*T) *R) (*R, error) {
+	target := resourcePath(path, id)
+	if err := call(ctx, http.MethodPatch, target+"/"+field, map[string]interface{}{field: value}, nil); err != nil {
+		return nil, err
+	}
+	return fetch(ctx, http.MethodGet, target, nil, fn)
+}
+
+// body 将输入对象转换为 REST 请求体：只包含请求中提供的字段（显式传 null 的字段值为 null），
+// 字段名取 json 标签，ID 类型的值转为数字；部分更新时未提供的字段因此不会出现在补丁中
+func body(input interface{}) map[string]interface{} {
+	v := reflect.ValueOf(input)
+	t := v.Type()
+	result := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if !field.FieldByName("Set").Bool() {
+			continue
+		}
+		var value interface{}
+		if inner := field.FieldByName("Value"); !inner.IsNil() {
+			value = reflect.Indirect(inner).Interface()
+		}
+		if id, ok := value.(graphql.ID); ok {
+			if n, err := strconv.ParseUint(string(id), 10, 64); err == nil {
+				value = n
+			}
+		}
+		result[t.Field(i).Tag.Get("json")] = value
+	}
+	return result
+}
+
+// snakeCase 将 camelCase 字段名转换为下划线形式
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// 输入对象，字段均可省略，Set 区分未提供与显式传 null
+type (
+	supplierInput struct {
+		Name      graphql.NullString `json:"name"`
+		Remark    graphql.NullString `json:"remark"`
+		IsEnabled graphql.NullBool   `json:"is_enabled"`
+	}
+	shopInput struct {
+		SupplierID graphql.NullID     `json:"supplier_id"`
+		Name       graphql.NullString `json:"name"`
+		Remark     graphql.NullString `json:"remark"`
+		IsEnabled  graphql.NullBool   `json:"is_enabled"`
+	}
+	productInput struct {
+		SupplierID   graphql.NullID     `json:"supplier_id"`
+		CategoryID   graphql.NullID     `json:"category_id"`
+		Name         graphql.NullString `json:"name"`
+		SKU          graphql.NullString `json:"sku"`
+		Type         graphql.NullInt    `json:"type"`
+		Price        graphql.NullFloat  `json:"price"`
+		Stock        graphql.NullInt    `json:"stock"`
+		DynamicAttrs JSON               `json:"dynamic_attrs"`
+		Remark       graphql.NullString `json:"remark"`
+		IsEnabled    graphql.NullBool   `json:"is_enabled"`
+	}
+	categoryInput struct {
+		Name        graphql.NullString `json:"name"`
+		Description graphql.NullString `json:"description"`
+		ParentID    graphql.NullID     `json:"parent_id"`
+		LevelRemark graphql.NullString `json:"level_remark"`
+		IsEnabled   graphql.NullBool   `json:"is_enabled"`
+	}
+	linkInput struct {
+		Name       graphql.NullString `json:"name"`
+		URL        graphql.NullString `json:"url"`
+		BaseRemark graphql.NullString `json:"base_remark"`
+		ShopID     graphql.NullID     `json:"shop_id"`
+		CategoryID graphql.NullID     `json:"category_id"`
+		Remark     graphql.NullString `json:"remark"`
+		IsEnabled  graphql.NullBool   `json:"is_enabled"`
+	}
+	attributeInput struct {
+		Name       graphql.NullString `json:"name"`
+		DataType   graphql.NullString `json:"data_type"`
+		CategoryID graphql.NullID     `json:"category_id"`
+		IsRequired graphql.NullBool   `json:"is_required"`
+		Remark     graphql.NullString `json:"remark"`
+		IsEnabled  graphql.NullBool   `json:"is_enabled"`
+	}
+	productAttributeInput struct {
+		ProductID   graphql.NullID     `json:"product_id"`
+		AttributeID graphql.NullID     `json:"attribute_id"`
+		Value       graphql.NullString `json:"value"`
+	}
+)
+
+// inputArgs 带输入对象的变更参数，更新时 ID 为要更新的对象
+type inputArgs[I any] struct {
+	ID    graphql.ID
+	Input I
+}
+
+// resolver 顶层查询与变更的解析器
+type resolver struct{}
+
+// 供应商
+
+func (resolver) Supplier(ctx context.Context, args idArgs) (*supplierResolver, error) {
+	return get(ctx, "/suppliers", args.ID, newSupplier)
+}
+
+func (resolver) Suppliers(ctx context.Context, args listArgs) (*page[supplierResolver], error) {
+	return list(ctx, "/suppliers", args, newSupplier)
+}
+
+func (resolver) CreateSupplier(ctx context.Context, args inputArgs[supplierInput]) (*supplierResolver, error) {
+	return fetch(ctx, http.MethodPost, "/suppliers", body(args.Input), newSupplier)
+}
+
+func (resolver) UpdateSupplier(ctx context.Context, args inputArgs[supplierInput]) (*supplierResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/suppliers", args.ID), body(args.Input), newSupplier)
+}
+
+func (resolver) PatchSupplier(ctx context.Context, args inputArgs[supplierInput]) (*supplierResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/suppliers", args.ID), body(args.Input), newSupplier)
+}
+
+func (resolver) DeleteSupplier(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/suppliers", args.ID)
+}
+
+func (resolver) ToggleSupplier(ctx context.Context, args idArgs) (*supplierResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/suppliers", args.ID)+"/toggle", nil, newSupplier)
+}
+
+// 店铺
+
+func (resolver) Shop(ctx context.Context, args idArgs) (*shopResolver, error) {
+	return get(ctx, "/shops", args.ID, newShop)
+}
+
+func (resolver) Shops(ctx context.Context, args listArgs) (*page[shopResolver], error) {
+	return list(ctx, "/shops", args, newShop)
+}
+
+func (resolver) CreateShop(ctx context.Context, args inputArgs[shopInput]) (*shopResolver, error) {
+	return fetch(ctx, http.MethodPost, "/shops", body(args.Input), newShop)
+}
+
+func (resolver) UpdateShop(ctx context.Context, args inputArgs[shopInput]) (*shopResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/shops", args.ID), body(args.Input), newShop)
+}
+
+func (resolver) PatchShop(ctx context.Context, args inputArgs[shopInput]) (*shopResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/shops", args.ID), body(args.Input), newShop)
+}
+
+func (resolver) DeleteShop(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/shops", args.ID)
+}
+
+func (resolver) ToggleShop(ctx context.Context, args idArgs) (*shopResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/shops", args.ID)+"/toggle", nil, newShop)
+}
+
+// 商品
+
+func (resolver) Product(ctx context.Context, args idArgs) (*productResolver, error) {
+	return get(ctx, "/products", args.ID, newProduct)
+}
+
+func (resolver) Products(ctx context.Context, args listArgs) (*page[productResolver], error) {
+	return list(ctx, "/products", args, newProduct)
+}
+
+func (resolver) CreateProduct(ctx context.Context, args inputArgs[productInput]) (*productResolver, error) {
+	return fetch(ctx, http.MethodPost, "/products", body(args.Input), newProduct)
+}
+
+func (resolver) UpdateProduct(ctx context.Context, args inputArgs[productInput]) (*productResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/products", args.ID), body(args.Input), newProduct)
+}
+
+func (resolver) PatchProduct(ctx context.Context, args inputArgs[productInput]) (*productResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/products", args.ID), body(args.Input), newProduct)
+}
+
+func (resolver) DeleteProduct(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/products", args.ID)
+}
+
+func (resolver) ToggleProduct(ctx context.Context, args idArgs) (*productResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/products", args.ID)+"/toggle", nil, newProduct)
+}
+
+func (resolver) UpdateProductStock(ctx context.Context, args struct {
+	ID    graphql.ID
+	Stock int32
+}) (*productResolver, error) {
+	return updateAndGet(ctx, "/products", args.ID, "stock", args.Stock, newProduct)
+}
+
+func (resolver) UpdateProductPrice(ctx context.Context, args struct {
+	ID    graphql.ID
+	Price float64
+}) (*productResolver, error) {
+	return updateAndGet(ctx, "/products", args.ID, "price", args.Price, newProduct)
+}
+
+// 分类
+
+func (resolver) Category(ctx context.Context, args idArgs) (*categoryResolver, error) {
+	return get(ctx, "/categories", args.ID, newCategory)
+}
+
+func (resolver) Categories(ctx context.Context, args listArgs) (*page[categoryResolver], error) {
+	return list(ctx, "/categories", args, newCategory)
+}
+
+func (resolver) CreateCategory(ctx context.Context, args inputArgs[categoryInput]) (*categoryResolver, error) {
+	return fetch(ctx, http.MethodPost, "/categories", body(args.Input), newCategory)
+}
+
+func (resolver) UpdateCategory(ctx context.Context, args inputArgs[categoryInput]) (*categoryResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/categories", args.ID), body(args.Input), newCategory)
+}
+
+func (resolver) PatchCategory(ctx context.Context, args inputArgs[categoryInput]) (*categoryResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/categories", args.ID), body(args.Input), newCategory)
+}
+
+func (resolver) DeleteCategory(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/categories", args.ID)
+}
+
+func (resolver) ToggleCategory(ctx context.Context, args idArgs) (*categoryResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/categories", args.ID)+"/toggle", nil, newCategory)
+}
+
+// 链接
+
+func (resolver) Link(ctx context.Context, args idArgs) (*linkResolver, error) {
+	return get(ctx, "/links", args.ID, newLink)
+}
+
+func (resolver) Links(ctx context.Context, args listArgs) (*page[linkResolver], error) {
+	return list(ctx, "/links", args, newLink)
+}
+
+func (resolver) CreateLink(ctx context.Context, args inputArgs[linkInput]) (*linkResolver, error) {
+	return fetch(ctx, http.MethodPost, "/links", body(args.Input), newLink)
+}
+
+func (resolver) UpdateLink(ctx context.Context, args inputArgs[linkInput]) (*linkResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/links", args.ID), body(args.Input), newLink)
+}
+
+func (resolver) PatchLink(ctx context.Context, args inputArgs[linkInput]) (*linkResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/links", args.ID), body(args.Input), newLink)
+}
+
+func (resolver) DeleteLink(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/links", args.ID)
+}
+
+func (resolver) ToggleLink(ctx context.Context, args idArgs) (*linkResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/links", args.ID)+"/toggle", nil, newLink)
+}
+
+// 属性
+
+func (resolver) Attribute(ctx context.Context, args idArgs) (*attributeResolver, error) {
+	return get(ctx, "/attributes", args.ID, newAttribute)
+}
+
+func (resolver) Attributes(ctx context.Context, args listArgs) (*page[attributeResolver], error) {
+	return list(ctx, "/attributes", args, newAttribute)
+}
+
+func (resolver) CreateAttribute(ctx context.Context, args inputArgs[attributeInput]) (*attributeResolver, error) {
+	return fetch(ctx, http.MethodPost, "/attributes", body(args.Input), newAttribute)
+}
+
+func (resolver) UpdateAttribute(ctx context.Context, args inputArgs[attributeInput]) (*attributeResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/attributes", args.ID), body(args.Input), newAttribute)
+}
+
+func (resolver) PatchAttribute(ctx context.Context, args inputArgs[attributeInput]) (*attributeResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/attributes", args.ID), body(args.Input), newAttribute)
+}
+
+func (resolver) DeleteAttribute(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/attributes", args.ID)
+}
+
+func (resolver) ToggleAttribute(ctx context.Context, args idArgs) (*attributeResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/attributes", args.ID)+"/toggle", nil, newAttribute)
+}
+
+// 商品属性值
+
+func (resolver) ProductAttributes(ctx context.Context, args listArgs) (*page[productAttributeResolver], error) {
+	return list(ctx, "/product-attributes", args, newProductAttribute)
+}
+
+func (resolver) CreateProductAttribute(ctx context.Context, args inputArgs[productAttributeInput]) (*productAttributeResolver, error) {
+	return fetch(ctx, http.MethodPost, "/product-attributes", body(args.Input), newProductAttribute)
+}
+
+func (resolver) UpdateProductAttribute(ctx context.Context, args inputArgs[productAttributeInput]) (*productAttributeResolver, error) {
+	return fetch(ctx, http.MethodPut, resourcePath("/product-attributes", args.ID), body(args.Input), newProductAttribute)
+}
+
+func (resolver) PatchProductAttribute(ctx context.Context, args inputArgs[productAttributeInput]) (*productAttributeResolver, error) {
+	return fetch(ctx, http.MethodPatch, resourcePath("/product-attributes", args.ID), body(args.Input), newProductAttribute)
+}
+
+func (resolver) DeleteProductAttribute(ctx context.Context, args idArgs) (bool, error) {
+	return remove(ctx, "/product-attributes", args.ID)
+}
@@ -0,0 +1,24 @@
+package graphql
+
+import (
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/ratelimit"
+)
+
+// RegisterRoutes 注册 GraphQL 路由，顶层查询与变更交由 router 按普通请求处理
+func RegisterRoutes(r *middleware.Router, db *gorm.DB, router http.Handler) {
+	handler := NewHandler(db, router, r.BasePath(), config.GetGraphQLConfig())
+
+	query := module.RequirePermission("graphql.query")
+	graphql := r.Group("/graphql")
+	{
+		graphql.POST("", query, middleware.RateLimit(ratelimit.PolicyBulk), handler.Execute)
+		graphql.GET("", query, middleware.RateLimit(ratelimit.PolicyBulk), handler.Query)
+	}
+}
@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// schemaSource 模式定义，顶层查询与变更经路由调用 REST 接口，关联字段经批量加载器查询
+//
+//go:embed schema.graphql
+var schemaSource string
+
+// Schema 可执行的模式：执行与校验由 graphql-go 完成，
+// gqlparser 解析出的文档只用于执行前的深度、复杂度与操作类型检查
+type Schema struct {
+	exec *graphql.Schema
+	ast  *ast.Schema
+}
+
+// newSchema 解析模式并绑定解析器
+func newSchema() *Schema {
+	return &Schema{
+		exec: graphql.MustParseSchema(schemaSource, &resolver{},
+			graphql.UseStringDescriptions(),
+			graphql.UseFieldResolvers(),
+		),
+		ast: gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSource}),
+	}
+}
+
+// DateTime RFC 3339 格式的时间
+type DateTime struct {
+	time.Time
+}
+
+// ImplementsGraphQLType 实现 decode.Unmarshaler 接口
+func (DateTime) ImplementsGraphQLType(name string) bool {
+	return name == "DateTime"
+}
+
+// UnmarshalGraphQL 实现 decode.Unmarshaler 接口
+func (t *DateTime) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("无法将 %v 转换为 DateTime", input)
+	}
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// JSON 任意 JSON 值，如商品的动态属性；作为输入时 Set 区分未提供与显式传 null
+type JSON struct {
+	Value interface{}
+	Set   bool
+}
+
+// ImplementsGraphQLType 实现 decode.Unmarshaler 接口
+func (JSON) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+// UnmarshalGraphQL 实现 decode.Unmarshaler 接口
+func (j *JSON) UnmarshalGraphQL(input interface{}) error {
+	j.Value, j.Set = input, true
+	return nil
+}
+
+// Nullable 允许输入 null
+func (j *JSON) Nullable() {}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
@@ -0,0 +1,276 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"erp_backend/modules/attribute"
+	"erp_backend/modules/category"
+	"erp_backend/modules/link"
+	"erp_backend/modules/product"
+	"erp_backend/modules/shop"
+	"erp_backend/modules/supplier"
+)
+
+// 对象解析器嵌入模型，与模型字段同名且类型一致的字段（name、remark、isEnabled 等）由执行器直接读取；
+// ID、时间、整数与关联字段由方法解析。batch 为与该对象一同加载的对象，关联字段按批次批量查询
+
+// limitArgs 一对多关联字段的参数
+type limitArgs struct {
+	Limit int32
+}
+
+// wrap 为批次 batch 中的对象 items 创建解析器
+func wrap[T, R any](items, batch []*T, fn func(T, []*T) *R) []*R {
+	result := make([]*R, len(items))
+	for i, item := range items {
+		result[i] = fn(*item, batch)
+	}
+	return result
+}
+
+// one 为单个对象创建解析器，对象为空时返回 nil
+func one[T, R any](entity *T, batch []*T, fn func(T, []*T) *R) *R {
+	if entity == nil {
+		return nil
+	}
+	return fn(*entity, batch)
+}
+
+// toID 转换为 GraphQL ID
+func toID(id uint) graphql.ID {
+	return graphql.ID(strconv.FormatUint(uint64(id), 10))
+}
+
+type supplierResolver struct {
+	supplier.Supplier
+	batch []*supplier.Supplier
+}
+
+func newSupplier(s supplier.Supplier, batch []*supplier.Supplier) *supplierResolver {
+	return &supplierResolver{Supplier: s, batch: batch}
+}
+
+func (r *supplierResolver) ID() graphql.ID      { return toID(r.Supplier.ID) }
+func (r *supplierResolver) CreatedAt() DateTime { return DateTime{r.Supplier.CreatedAt} }
+func (r *supplierResolver) UpdatedAt() DateTime { return DateTime{r.Supplier.UpdatedAt} }
+
+func (r *supplierResolver) Shops(ctx context.Context, args limitArgs) ([]*shopResolver, error) {
+	items, batch, err := hasMany(ctx, "supplier_id", func(s *shop.Shop) uint { return s.SupplierID },
+		args.Limit, r.Supplier.ID, keys(r.batch, func(s *supplier.Supplier) uint { return s.ID }))
+	return wrap(items, batch, newShop), err
+}
+
+func (r *supplierResolver) Products(ctx context.Context, args limitArgs) ([]*productResolver, error) {
+	items, batch, err := hasMany(ctx, "supplier_id", func(p *product.Product) uint { return p.SupplierID },
+		args.Limit, r.Supplier.ID, keys(r.batch, func(s *supplier.Supplier) uint { return s.ID }))
+	return wrap(items, batch, newProduct), err
+}
+
+type shopResolver struct {
+	shop.Shop
+	batch []*shop.Shop
+}
+
+func newShop(s shop.Shop, batch []*shop.Shop) *shopResolver {
+	return &shopResolver{Shop: s, batch: batch}
+}
+
+func (r *shopResolver) ID() graphql.ID         { return toID(r.Shop.ID) }
+func (r *shopResolver) CreatedAt() DateTime    { return DateTime{r.Shop.CreatedAt} }
+func (r *shopResolver) UpdatedAt() DateTime    { return DateTime{r.Shop.UpdatedAt} }
+func (r *shopResolver) SupplierID() graphql.ID { return toID(r.Shop.SupplierID) }
+
+func (r *shopResolver) Supplier(ctx context.Context) (*supplierResolver, error) {
+	entity, batch, err := belongsTo[supplier.Supplier](ctx, r.Shop.SupplierID,
+		keys(r.batch, func(s *shop.Shop) uint { return s.SupplierID }))
+	return one(entity, batch, newSupplier), err
+}
+
+func (r *shopResolver) Links(ctx context.Context, args limitArgs) ([]*linkResolver, error) {
+	items, batch, err := hasMany(ctx, "shop_id", func(l *link.Link) uint { return l.ShopID },
+		args.Limit, r.Shop.ID, keys(r.batch, func(s *shop.Shop) uint { return s.ID }))
+	return wrap(items, batch, newLink), err
+}
+
+type productResolver struct {
+	product.Product
+	batch []*product.Product
+}
+
+func newProduct(p product.Product, batch []*product.Product) *productResolver {
+	return &productResolver{Product: p, batch: batch}
+}
+
+func (r *productResolver) ID() graphql.ID         { return toID(r.Product.ID) }
+func (r *productResolver) CreatedAt() DateTime    { return DateTime{r.Product.CreatedAt} }
+func (r *productResolver) UpdatedAt() DateTime    { return DateTime{r.Product.UpdatedAt} }
+func (r *productResolver) SupplierID() graphql.ID { return toID(r.Product.SupplierID) }
+func (r *productResolver) CategoryID() graphql.ID { return toID(r.Product.CategoryID) }
+func (r *productResolver) Type() int32            { return int32(r.Product.Type) }
+func (r *productResolver) Stock() int32           { return int32(r.Product.Stock) }
+
+func (r *productResolver) DynamicAttrs() *JSON {
+	if r.Product.DynamicAttrs == nil {
+		return nil
+	}
+	return &JSON{Value: map[string]interface{}(r.Product.DynamicAttrs)}
+}
+
+func (r *productResolver) Supplier(ctx context.Context) (*supplierResolver, error) {
+	entity, batch, err := belongsTo[supplier.Supplier](ctx, r.Product.SupplierID,
+		keys(r.batch, func(p *product.Product) uint { return p.SupplierID }))
+	return one(entity, batch, newSupplier), err
+}
+
+func (r *productResolver) Category(ctx context.Context) (*categoryResolver, error) {
+	entity, batch, err := belongsTo[category.Category](ctx, r.Product.CategoryID,
+		keys(r.batch, func(p *product.Product) uint { return p.CategoryID }))
+	return one(entity, batch, newCategory), err
+}
+
+func (r *productResolver) Attributes(ctx context.Context, args limitArgs) ([]*productAttributeResolver, error) {
+	items, batch, err := hasMany(ctx, "product_id", func(pa *attribute.ProductAttribute) uint { return pa.ProductID },
+		args.Limit, r.Product.ID, keys(r.batch, func(p *product.Product) uint { return p.ID }))
+	return wrap(items, batch, newProductAttribute), err
+}
+
+type categoryResolver struct {
+	category.Category
+	batch []*category.Category
+}
+
+func newCategory(c category.Category, batch []*category.Category) *categoryResolver {
+	return &categoryResolver{Category: c, batch: batch}
+}
+
+func (r *categoryResolver) ID() graphql.ID      { return toID(r.Category.ID) }
+func (r *categoryResolver) CreatedAt() DateTime { return DateTime{r.Category.CreatedAt} }
+func (r *categoryResolver) UpdatedAt() DateTime { return DateTime{r.Category.UpdatedAt} }
+
+func (r *categoryResolver) ParentID() *graphql.ID {
+	if r.Category.ParentID == nil {
+		return nil
+	}
+	id := toID(*r.Category.ParentID)
+	return &id
+}
+
+// parentID 父级分类ID，没有父级时为 0
+func parentID(c *category.Category) uint {
+	if c.ParentID == nil {
+		return 0
+	}
+	return *c.ParentID
+}
+
+func (r *categoryResolver) Parent(ctx context.Context) (*categoryResolver, error) {
+	entity, batch, err := belongsTo[category.Category](ctx, parentID(&r.Category), keys(r.batch, parentID))
+	return one(entity, batch, newCategory), err
+}
+
+func (r *categoryResolver) Children(ctx context.Context, args limitArgs) ([]*categoryResolver, error) {
+	items, batch, err := hasMany(ctx, "parent_id", parentID,
+		args.Limit, r.Category.ID, keys(r.batch, func(c *category.Category) uint { return c.ID }))
+	return wrap(items, batch, newCategory), err
+}
+
+func (r *categoryResolver) Products(ctx context.Context, args limitArgs) ([]*productResolver, error) {
+	items, batch, err := hasMany(ctx, "category_id", func(p *product.Product) uint { return p.CategoryID },
+		args.Limit, r.Category.ID, keys(r.batch, func(c *category.Category) uint { return c.ID }))
+	return wrap(items, batch, newProduct), err
+}
+
+func (r *categoryResolver) Attributes(ctx context.Context, args limitArgs) ([]*attributeResolver, error) {
+	items, batch, err := hasMany(ctx, "category_id", func(a *attribute.Attribute) uint { return a.CategoryID },
+		args.Limit, r.Category.ID, keys(r.batch, func(c *category.Category) uint { return c.ID }))
+	return wrap(items, batch, newAttribute), err
+}
+
+func (r *categoryResolver) Links(ctx context.Context, args limitArgs) ([]*linkResolver, error) {
+	items, batch, err := hasMany(ctx, "category_id", func(l *link.Link) uint { return l.CategoryID },
+		args.Limit, r.Category.ID, keys(r.batch, func(c *category.Category) uint { return c.ID }))
+	return wrap(items, batch, newLink), err
+}
+
+type linkResolver struct {
+	link.Link
+	batch []*link.Link
+}
+
+func newLink(l link.Link, batch []*link.Link) *linkResolver {
+	return &linkResolver{Link: l, batch: batch}
+}
+
+func (r *linkResolver) ID() graphql.ID         { return toID(r.Link.ID) }
+func (r *linkResolver) CreatedAt() DateTime    { return DateTime{r.Link.CreatedAt} }
+func (r *linkResolver) UpdatedAt() DateTime    { return DateTime{r.Link.UpdatedAt} }
+func (r *linkResolver) ShopID() graphql.ID     { return toID(r.Link.ShopID) }
+func (r *linkResolver) CategoryID() graphql.ID { return toID(r.Link.CategoryID) }
+
+func (r *linkResolver) Shop(ctx context.Context) (*shopResolver, error) {
+	entity, batch, err := belongsTo[shop.Shop](ctx, r.Link.ShopID,
+		keys(r.batch, func(l *link.Link) uint { return l.ShopID }))
+	return one(entity, batch, newShop), err
+}
+
+func (r *linkResolver) Category(ctx context.Context) (*categoryResolver, error) {
+	entity, batch, err := belongsTo[category.Category](ctx, r.Link.CategoryID,
+		keys(r.batch, func(l *link.Link) uint { return l.CategoryID }))
+	return one(entity, batch, newCategory), err
+}
+
+type attributeResolver struct {
+	attribute.Attribute
+	batch []*attribute.Attribute
+}
+
+func newAttribute(a attribute.Attribute, batch []*attribute.Attribute) *attributeResolver {
+	return &attributeResolver{Attribute: a, batch: batch}
+}
+
+func (r *attributeResolver) ID() graphql.ID         { return toID(r.Attribute.ID) }
+func (r *attributeResolver) CreatedAt() DateTime    { return DateTime{r.Attribute.CreatedAt} }
+func (r *attributeResolver) UpdatedAt() DateTime    { return DateTime{r.Attribute.UpdatedAt} }
+func (r *attributeResolver) CategoryID() graphql.ID { return toID(r.Attribute.CategoryID) }
+
+func (r *attributeResolver) Category(ctx context.Context) (*categoryResolver, error) {
+	entity, batch, err := belongsTo[category.Category](ctx, r.Attribute.CategoryID,
+		keys(r.batch, func(a *attribute.Attribute) uint { return a.CategoryID }))
+	return one(entity, batch, newCategory), err
+}
+
+type productAttributeResolver struct {
+	attribute.ProductAttribute
+	batch []*attribute.ProductAttribute
+}
+
+func newProductAttribute(pa attribute.ProductAttribute, batch []*attribute.ProductAttribute) *productAttributeResolver {
+	return &productAttributeResolver{ProductAttribute: pa, batch: batch}
+}
+
+func (r *productAttributeResolver) ID() graphql.ID { return toID(r.ProductAttribute.ID) }
+func (r *productAttributeResolver) CreatedAt() DateTime {
+	return DateTime{r.ProductAttribute.CreatedAt}
+}
+func (r *productAttributeResolver) UpdatedAt() DateTime {
+	return DateTime{r.ProductAttribute.UpdatedAt}
+}
+func (r *productAttributeResolver) ProductID() graphql.ID { return toID(r.ProductAttribute.ProductID) }
+func (r *productAttributeResolver) AttributeID() graphql.ID {
+	return toID(r.ProductAttribute.AttributeID)
+}
+
+func (r *productAttributeResolver) Product(ctx context.Context) (*productResolver, error) {
+	entity, batch, err := belongsTo[product.Product](ctx, r.ProductAttribute.ProductID,
+		keys(r.batch, func(pa *attribute.ProductAttribute) uint { return pa.ProductID }))
+	return one(entity, batch, newProduct), err
+}
+
+func (r *productAttributeResolver) Attribute(ctx context.Context) (*attributeResolver, error) {
+	entity, batch, err := belongsTo[attribute.Attribute](ctx, r.ProductAttribute.AttributeID,
+		keys(r.batch, func(pa *attribute.ProductAttribute) uint { return pa.AttributeID }))
+	return one(entity, batch, newAttribute), err
+}
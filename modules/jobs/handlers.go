@@ -0,0 +1,307 @@
+package jobs
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	jobq "erp_backend/pkg/jobs"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/response"
+)
+
+// listConfig 任务列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"created_at", "updated_at", "run_at", "attempts", "finished_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 任务列表筛选字段
+var listFilters = filter.Fields{
+	"id":          filter.Number,
+	"kind":        filter.String,
+	"status":      filter.String,
+	"schedule":    filter.String,
+	"unique_key":  filter.String,
+	"attempts":    filter.Number,
+	"run_at":      filter.Time,
+	"finished_at": filter.Time,
+	"created_at":  filter.Time,
+}
+
+// scheduleListConfig 定时计划列表排序配置
+var scheduleListConfig = pagination.Config{
+	SortFields:  []string{"name", "next_run_at", "last_run_at"},
+	DefaultSort: "name",
+}
+
+// scheduleListFilters 定时计划列表筛选字段
+var scheduleListFilters = filter.Fields{
+	"name":        filter.String,
+	"kind":        filter.String,
+	"is_enabled":  filter.Bool,
+	"next_run_at": filter.Time,
+	"last_run_at": filter.Time,
+}
+
+type Handler struct {
+	db    *gorm.DB
+	queue *jobq.Queue
+}
+
+func NewHandler(db *gorm.DB, queue *jobq.Queue) *Handler {
+	return &Handler{db: db, queue: queue}
+}
+
+// List 获取任务列表
+// @Summary 获取后台任务列表
+// @Description 获取后台任务，可按类型、状态（pending、running、succeeded、failed、cancelled）、定时计划筛选
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[status]=failed"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]jobq.Job}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /jobs [get]
+func (h *Handler) List(c *gin.Context) {
+	params, err := pagination.Parse(c, listConfig)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	conditions, err := filter.Parse(c, listFilters)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	var jobs []jobq.Job
+	page, err := params.Find(conditions.Apply(h.db.WithContext(c).Model(&jobq.Job{})), &jobs)
+	if err != nil {
+		response.DBError(c, err, errcode.Job.ListFailed)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// Get 获取单个任务
+// @Summary 获取后台任务详情
+// @Description 根据ID获取后台任务，包括参数、执行次数与最近一次错误
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "任务ID"
+// @Success 200 {object} response.Response{data=jobq.Job} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Router /jobs/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	var job jobq.Job
+	if err := h.db.WithContext(c).First(&job, id).Error; err != nil {
+		response.DBError(c, err, errcode.Job.NotFound)
+		return
+	}
+
+	response.Success(c, job)
+}
+
+// Retry 重试任务
+// @Summary 重试后台任务
+// @Description 将失败或已取消的任务重置为等待执行并清零执行次数，由后台立即执行
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "任务ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=jobq.Job} "已重新排队"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "任务状态不允许重试，或已有相同唯一键的任务未结束"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /jobs/{id}/retry [post]
+func (h *Handler) Retry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	job, err := jobq.Retry(c, h.db, uint(id))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		response.Fail(c, errcode.Job.NotFound)
+	case errors.Is(err, jobq.ErrNotRetryable):
+		response.Fail(c, errcode.JobNotRetryable)
+	case err != nil:
+		response.DBError(c, err, errcode.JobRetryFailed)
+	default:
+		response.Success(c, job)
+	}
+}
+
+// Cancel 取消任务
+// @Summary 取消后台任务
+// @Description 取消等待中或执行中的任务；在其他实例执行中的任务会继续执行到结束，但结果不再写入
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "任务ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=jobq.Job} "已取消"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "任务不存在"
+// @Failure 409 {object} response.Response "任务已结束"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /jobs/{id}/cancel [post]
+func (h *Handler) Cancel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	job, err := jobq.Cancel(c, h.db, uint(id))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		response.Fail(c, errcode.Job.NotFound)
+	case errors.Is(err, jobq.ErrNotCancellable):
+		response.Fail(c, errcode.JobNotCancellable)
+	case err != nil:
+		response.DBError(c, err, errcode.JobCancelFailed)
+	default:
+		response.Success(c, job)
+	}
+}
+
+// Kinds 获取任务类型
+// @Summary 获取后台任务类型
+// @Description 获取本实例注册的任务类型
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response{data=[]string} "获取成功"
+// @Router /jobs/kinds [get]
+func (h *Handler) Kinds(c *gin.Context) {
+	kinds := []string{}
+	if h.queue != nil {
+		kinds = h.queue.Kinds()
+	}
+	response.Success(c, kinds)
+}
+
+// ListSchedules 获取定时计划
+// @Summary 获取定时计划列表
+// @Description 获取各模块声明的定时计划及下次执行时间
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[is_enabled]=false"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]jobq.Schedule}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /jobs/schedules [get]
+func (h *Handler) ListSchedules(c *gin.Context) {
+	params, err := pagination.Parse(c, scheduleListConfig)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	conditions, err := filter.Parse(c, scheduleListFilters)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	var schedules []jobq.Schedule
+	page, err := params.Find(conditions.Apply(h.db.WithContext(c).Model(&jobq.Schedule{})), &schedules)
+	if err != nil {
+		response.DBError(c, err, errcode.JobSchedule.ListFailed)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// ToggleSchedule 切换定时计划状态
+// @Summary 切换定时计划状态
+// @Description 启用/停用定时计划，停用期间不再按计划入队，重启服务后保持停用
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param name path string true "计划名称"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=jobq.Schedule} "切换成功"
+// @Failure 404 {object} response.Response "定时计划不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /jobs/schedules/{name}/toggle [patch]
+func (h *Handler) ToggleSchedule(c *gin.Context) {
+	var schedule jobq.Schedule
+	if err := h.db.WithContext(c).First(&schedule, "name = ?", c.Param("name")).Error; err != nil {
+		response.DBError(c, err, errcode.JobSchedule.NotFound)
+		return
+	}
+
+	schedule.IsEnabled = !schedule.IsEnabled
+	if err := h.db.WithContext(c).Model(&schedule).Update("is_enabled", schedule.IsEnabled).Error; err != nil {
+		response.DBError(c, err, errcode.JobSchedule.ToggleFailed)
+		return
+	}
+
+	response.Success(c, schedule)
+}
+
+// RunSchedule 立即执行定时计划
+// @Summary 立即执行定时计划
+// @Description 立即按定时计划入队一次，不影响下次执行时间；上次产生的任务未结束时返回该任务
+// @Tags 后台任务管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param name path string true "计划名称"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=jobq.Job} "已入队"
+// @Failure 404 {object} response.Response "定时计划不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /jobs/schedules/{name}/run [post]
+func (h *Handler) RunSchedule(c *gin.Context) {
+	job, err := jobq.RunSchedule(c, h.db, c.Param("name"))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Fail(c, errcode.JobSchedule.NotFound)
+		return
+	}
+	if err != nil {
+		response.DBError(c, err, errcode.JobEnqueueFailed)
+		return
+	}
+
+	response.Success(c, job)
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 后台任务管理模块：查看任务与定时计划，重试、取消任务
+// 任务表由 main 统一迁移，关闭本模块不影响其他模块入队与执行任务
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "jobs" }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app) }
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "jobs.manage", Description: "查看后台任务与定时计划，重试、取消任务", UserTypes: module.Admins},
+	}
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+// RegisterRoutes 注册后台任务相关路由，仅管理员可访问
+func RegisterRoutes(r *middleware.Router, app *module.App) {
+	handler := NewHandler(app.DB, app.Queue)
+
+	manage := module.RequirePermission("jobs.manage")
+	jobs := r.Group("/jobs")
+	{
+		jobs.GET("/kinds", manage, handler.Kinds)
+		jobs.GET("/schedules", manage, handler.ListSchedules)
+		jobs.PATCH("/schedules/:name/toggle", manage, handler.ToggleSchedule)
+		jobs.POST("/schedules/:name/run", manage, handler.RunSchedule)
+
+		jobs.GET("", manage, handler.List)
+		jobs.GET("/:id", manage, handler.Get)
+		jobs.POST("/:id/retry", manage, handler.Retry)
+		jobs.POST("/:id/cancel", manage, handler.Cancel)
+	}
+}
@@ -0,0 +1,26 @@
+package link
+
+import "erp_backend/pkg/events"
+
+// AggregateType 实现 events.Aggregate 接口
+func (Link) AggregateType() string { return "link" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (l Link) AggregateID() uint { return l.ID }
+
+// Owners 实现 events.Owned 接口，删除事件携带所属店铺，实时事件据此确定供应商ID
+func (l Link) Owners() map[string]uint { return map[string]uint{"shop_id": l.ShopID} }
+
+// 链接领域事件
+type (
+	LinkCreated  = events.Created[Link]  // 链接创建
+	LinkUpdated  = events.Updated[Link]  // 链接更新
+	LinkToggled  = events.Toggled[Link]  // 链接启用状态切换
+	LinkDeleted  = events.Deleted[Link]  // 链接删除（移入回收站）
+	LinkRestored = events.Restored[Link] // 链接从回收站恢复
+)
+
+// Events 实现 module.EventsModule 接口
+func (Module) Events() []events.Event {
+	return []events.Event{LinkCreated{}, LinkUpdated{}, LinkToggled{}, LinkDeleted{}, LinkRestored{}}
+}
@@ -1,21 +1,50 @@
 package link
 
 import (
-	"net/http"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
-	"erp_backend/pkg/response"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/resource"
 )
 
+// listConfig 链接列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "shop_id", "category_id", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 链接列表筛选字段
+var listFilters = filter.Fields{
+	"id":          filter.Number,
+	"shop_id":     filter.Number,
+	"category_id": filter.Number,
+	"name":        filter.String,
+	"url":         filter.String,
+	"remark":      filter.String,
+	"is_enabled":  filter.Bool,
+	"created_at":  filter.Time,
+	"updated_at":  filter.Time,
+}
+
+// patchFields 链接允许通过 PATCH 修改的字段及校验规则
+var patchFields = patch.Fields{
+	"name":        "required,max=100",
+	"url":         "required,max=500",
+	"base_remark": "",
+	"shop_id":     "required",
+	"category_id": "required",
+	"remark":      "",
+	"is_enabled":  "",
+}
+
 type Handler struct {
-	db *gorm.DB
+	resource *resource.Handler[Link]
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(service *Service) *Handler {
+	return &Handler{resource: resource.NewHandler(service.Resource)}
 }
 
 // Create 创建链接
@@ -24,60 +53,67 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Tags 链接管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param link body Link true "链接信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Link} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /links [post]
 func (h *Handler) Create(c *gin.Context) {
-	var link Link
-	if err := c.ShouldBindJSON(&link); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Create(&link).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建链接失败")
-		return
-	}
-
-	response.Success(c, link)
+	h.resource.Create(c)
 }
 
 // List 获取链接列表
 // @Summary 获取链接列表
-// @Description 获取所有链接的列表
+// @Description 分页获取链接列表，回收站中的链接不包含在内
 // @Tags 链接管理
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Response{data=[]Link} "获取成功"
+// @Security ApiKeyAuth
+// @Param shop_id query string false "店铺ID"
+// @Param category_id query string false "类目ID"
+// @Param is_enabled query string false "是否启用"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Link}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /links [get]
 func (h *Handler) List(c *gin.Context) {
-	var links []Link
-	query := h.db.Model(&Link{})
-
-	// 支持按店铺ID筛选
-	if shopID := c.Query("shop_id"); shopID != "" {
-		query = query.Where("shop_id = ?", shopID)
-	}
-
-	// 支持按分类ID筛选
-	if categoryID := c.Query("category_id"); categoryID != "" {
-		query = query.Where("category_id = ?", categoryID)
-	}
-
-	// 支持按启用状态筛选
-	if isEnabled := c.Query("is_enabled"); isEnabled != "" {
-		query = query.Where("is_enabled = ?", isEnabled)
-	}
-
-	if err := query.Find(&links).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取链接列表失败")
-		return
-	}
-
-	response.Success(c, links)
+	h.resource.List(c)
+}
+
+// Trash 获取回收站中的链接
+// @Summary 获取回收站中的链接
+// @Description 分页获取已删除的链接，查询参数同列表接口
+// @Tags 链接管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param shop_id query string false "店铺ID"
+// @Param category_id query string false "类目ID"
+// @Param is_enabled query string false "是否启用"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Link}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /links/trash [get]
+func (h *Handler) Trash(c *gin.Context) {
+	h.resource.Trash(c)
 }
 
 // Get 获取单个链接
@@ -86,90 +122,97 @@ func (h *Handler) List(c *gin.Context) {
 // @Tags 链接管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "链接ID"
 // @Success 200 {object} response.Response{data=Link} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "链接不存在"
 // @Router /links/{id} [get]
 func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var link Link
-	if err := h.db.First(&link, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "链接不存在")
-		return
-	}
-
-	response.Success(c, link)
+	h.resource.Get(c)
 }
 
 // Update 更新链接
 // @Summary 更新链接
-// @Description 更新链接信息
+// @Description 全量更新链接信息，主键与创建时间不可修改
 // @Tags 链接管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "链接ID"
 // @Param link body Link true "链接信息"
 // @Success 200 {object} response.Response{data=Link} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "链接不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /links/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var link Link
-	if err := h.db.First(&link, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "链接不存在")
-		return
-	}
-
-	if err := c.ShouldBindJSON(&link); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Save(&link).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新链接失败")
-		return
-	}
-
-	response.Success(c, link)
+	h.resource.Update(c)
+}
+
+// Patch 部分更新链接
+// @Summary 部分更新链接
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改链接，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 链接管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "链接ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Link} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "链接不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /links/{id} [patch]
+func (h *Handler) Patch(c *gin.Context) {
+	h.resource.Patch(c)
 }
 
 // Delete 删除链接
 // @Summary 删除链接
-// @Description 删除链接
+// @Description 将链接移入回收站，可通过恢复接口恢复；链接不存在时同样返回成功
 // @Tags 链接管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "链接ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /links/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	if err := h.db.Delete(&Link{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除链接失败")
-		return
-	}
-
-	response.Success(c, gin.H{"message": "删除成功"})
+	h.resource.Delete(c)
+}
+
+// Restore 恢复链接
+// @Summary 恢复链接
+// @Description 将回收站中的链接恢复
+// @Tags 链接管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "链接ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Link} "恢复成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "链接不存在或不在回收站中"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /links/{id}/restore [post]
+func (h *Handler) Restore(c *gin.Context) {
+	h.resource.Restore(c)
 }
 
 // ToggleStatus 切换链接状态
@@ -178,30 +221,16 @@ func (h *Handler) Delete(c *gin.Context) {
 // @Tags 链接管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "链接ID"
-// @Success 200 {object} response.Response{data=Link} "切换成功"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Link} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "链接不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /links/{id}/toggle [patch]
 func (h *Handler) ToggleStatus(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var link Link
-	if err := h.db.First(&link, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "链接不存在")
-		return
-	}
-
-	link.IsEnabled = !link.IsEnabled
-	if err := h.db.Save(&link).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新状态失败")
-		return
-	}
-
-	response.Success(c, link)
+	h.resource.Toggle(c)
 }
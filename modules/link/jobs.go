@@ -0,0 +1,102 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/jobs"
+	"erp_backend/pkg/module"
+)
+
+// healthCheckKind 链接可用性检查任务类型
+const healthCheckKind = "link.health_check"
+
+// 链接可用性检查的并发数与单个请求的超时时间
+const (
+	healthCheckWorkers = 4
+	healthCheckTimeout = 10 * time.Second
+)
+
+// registerJobs 注册链接的后台任务：每 6 小时检查链接可用性，每天清理回收站
+func registerJobs(app *module.App) {
+	app.Queue.Register(jobs.Definition{Kind: healthCheckKind, Handler: healthCheck(app.DB), Timeout: 30 * time.Minute})
+	app.Queue.Schedule(healthCheckKind, "0 */6 * * *", healthCheckKind, nil)
+	app.Queue.PurgeTrash("link", &Link{})
+}
+
+// healthCheck 请求全部启用链接的地址，无法访问或返回 4xx/5xx 的链接记录警告日志
+func healthCheck(db *gorm.DB) jobs.Handler {
+	client := &http.Client{Timeout: healthCheckTimeout}
+	return func(ctx context.Context, job *jobs.Job) error {
+		var links []Link
+		if err := db.WithContext(ctx).Select("id", "name", "url").Where("is_enabled = ?", true).Order("id").Find(&links).Error; err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		var broken atomic.Int64
+		sem := make(chan struct{}, healthCheckWorkers)
+		for _, link := range links {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(link Link) {
+				defer func() { <-sem; wg.Done() }()
+				if err := probe(ctx, client, link.URL); err != nil && ctx.Err() == nil {
+					broken.Add(1)
+					slog.WarnContext(ctx, "链接无法访问",
+						slog.Uint64("link_id", uint64(link.ID)),
+						slog.String("name", link.Name),
+						slog.String("url", link.URL),
+						slog.Any("error", err),
+					)
+				}
+			}(link)
+		}
+		wg.Wait()
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		slog.InfoContext(ctx, "链接可用性检查完成", slog.Int("total", len(links)), slog.Int64("broken", broken.Load()))
+		return nil
+	}
+}
+
+// probe 以 HEAD 请求地址，不支持 HEAD 时改用 GET
+func probe(ctx context.Context, client *http.Client, url string) error {
+	status, err := request(ctx, client, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = request(ctx, client, http.MethodGet, url)
+	}
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		return fmt.Errorf("返回 %d", status)
+	}
+	return nil
+}
+
+// request 发送请求并返回响应状态码
+func request(ctx context.Context, client *http.Client, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "erp-link-check/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, nil
+}
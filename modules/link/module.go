@@ -0,0 +1,41 @@
+package link
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 链接管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "link" }
+
+// DependsOn 实现 module.Module 接口
+func (Module) DependsOn() []string { return []string{"shop", "category"} }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&Link{}} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// Jobs 实现 module.Module 接口，注册链接可用性检查与回收站清理任务
+func (Module) Jobs(app *module.App) []module.Job {
+	registerJobs(app)
+	return nil
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "link.read", Description: "查看链接"},
+		{Code: "link.write", Description: "创建、修改与删除链接", UserTypes: module.Staff},
+	}
+}
@@ -0,0 +1,17 @@
+package link
+
+import (
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/repository"
+)
+
+// Repository 链接数据访问接口
+type Repository interface {
+	repository.Trashable[Link]
+}
+
+// NewRepository 创建基于 GORM 的链接仓储
+func NewRepository(db *gorm.DB) Repository {
+	return repository.NewGorm[Link](db)
+}
@@ -1,21 +1,29 @@
 package link
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
 )
 
 // RegisterRoutes 注册链接相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db)))
 
+	read, write := module.RequirePermission("link.read"), module.RequirePermission("link.write")
 	links := r.Group("/links")
 	{
-		links.POST("", handler.Create)
-		links.GET("", handler.List)
-		links.GET("/:id", handler.Get)
-		links.PUT("/:id", handler.Update)
-		links.DELETE("/:id", handler.Delete)
-		links.PATCH("/:id/toggle", handler.ToggleStatus)
+		links.POST("", write, handler.Create)
+		links.GET("", read, handler.List)
+		links.GET("/trash", read, handler.Trash)
+		links.GET("/:id", read, handler.Get)
+		links.PUT("/:id", write, handler.Update)
+		links.PATCH("/:id", write, handler.Patch)
+		links.DELETE("/:id", write, handler.Delete)
+		links.POST("/:id/restore", write, handler.Restore)
+		links.PATCH("/:id/toggle", write, handler.ToggleStatus)
 	}
 }
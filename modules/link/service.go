@@ -0,0 +1,24 @@
+package link
+
+import (
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/resource"
+)
+
+// Service 链接业务逻辑，供 HTTP 接口、命令行与后台任务共用，增删改查、切换状态与回收站等通用操作由 resource.Resource 提供
+type Service struct {
+	*resource.Resource[Link]
+}
+
+// NewService 创建链接服务
+func NewService(repo Repository, tx database.Transactor, publisher events.Publisher) *Service {
+	return &Service{Resource: resource.New[Link](repo, tx, publisher, resource.Config[Link]{
+		Errors:      errcode.Link,
+		List:        listConfig,
+		Filters:     listFilters,
+		Legacy:      []string{"shop_id", "category_id", "is_enabled"},
+		PatchFields: patchFields,
+	})}
+}
@@ -0,0 +1,20 @@
+// Package modules 导入全部业务模块，各模块在 init 中向 module 包注册
+// 新增模块时在此处添加导入即可，启用与装配顺序由 MODULES_ENABLED、MODULES_DISABLED 与模块声明的依赖决定
+package modules
+
+import (
+	_ "erp_backend/modules/attribute"
+	_ "erp_backend/modules/batch"
+	_ "erp_backend/modules/category"
+	_ "erp_backend/modules/graphql"
+	_ "erp_backend/modules/jobs"
+	_ "erp_backend/modules/link"
+	_ "erp_backend/modules/product"
+	_ "erp_backend/modules/settings"
+	_ "erp_backend/modules/shop"
+	_ "erp_backend/modules/stream"
+	_ "erp_backend/modules/supplier"
+	_ "erp_backend/modules/system"
+	_ "erp_backend/modules/user"
+	_ "erp_backend/modules/webhook"
+)
@@ -0,0 +1,160 @@
+package product
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/module"
+)
+
+// Commands 商品运维命令：导出、导入与重建搜索索引
+func Commands() []module.Command {
+	return []module.Command{
+		{
+			Name:        "export products",
+			Usage:       "[-out <文件>] [-query <查询参数>]",
+			Description: "按主键顺序导出商品为 JSON Lines，-query 与列表接口的筛选参数一致，如 filter[stock][lte]=5",
+			Run:         runExport,
+		},
+		{
+			Name:        "import products",
+			Usage:       "[-in <文件>]",
+			Description: "从 JSON Lines 导入商品，逐行创建并发布领域事件，忽略文件中的主键与删除时间",
+			Run:         runImport,
+		},
+		{
+			Name:        "reindex-search",
+			Usage:       "[-rebuild]",
+			Description: "重建商品搜索索引，-rebuild 按当前分词规则重新生成全部商品的搜索数据",
+			Run:         runReindex,
+		},
+	}
+}
+
+// runExport 导出商品
+func runExport(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("export products", flag.ContinueOnError)
+	out := fs.String("out", "-", "输出文件，- 表示标准输出")
+	query := fs.String("query", "", "筛选参数，格式同列表接口的查询字符串")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	values, err := url.ParseQuery(*query)
+	if err != nil {
+		return fmt.Errorf("-query 格式错误: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	count := 0
+	err = newCommandService(app.DB).Export(ctx, values, config.GetGRPCConfig().ExportBatchSize, func(products []Product) error {
+		for i := range products {
+			if err := encoder.Encode(&products[i]); err != nil {
+				return err
+			}
+		}
+		count += len(products)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "已导出 %d 个商品\n", count)
+	return nil
+}
+
+// runImport 导入商品，单行失败不影响其他行，存在失败时返回错误
+func runImport(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("import products", flag.ContinueOnError)
+	in := fs.String("in", "-", "输入文件，- 表示标准输入")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	service := newCommandService(app.DB)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line, created, failed := 0, 0, 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var product Product
+		err := json.Unmarshal(scanner.Bytes(), &product)
+		if err == nil {
+			// 与创建接口相同，主键、创建与更新时间由数据库重新生成，导入的商品均不在回收站中
+			err = service.Create(ctx, &product)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "第 %d 行导入失败: %v\n", line, err)
+			continue
+		}
+		created++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	fmt.Printf("已导入 %d 个商品，失败 %d 个\n", created, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d 个商品导入失败", failed)
+	}
+	return nil
+}
+
+// runReindex 重建搜索索引
+func runReindex(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("reindex-search", flag.ContinueOnError)
+	rebuild := fs.Bool("rebuild", false, "删除并重新生成搜索列")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if err := Reindex(ctx, app.DB, *rebuild); err != nil {
+		return err
+	}
+	fmt.Println("商品搜索索引已重建")
+	return nil
+}
+
+// newCommandService 创建命令使用的商品服务
+func newCommandService(db *gorm.DB) *Service {
+	return NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db))
+}
@@ -0,0 +1,75 @@
+package product
+
+import "erp_backend/pkg/events"
+
+// AggregateType 实现 events.Aggregate 接口
+func (Product) AggregateType() string { return "product" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (p Product) AggregateID() uint { return p.ID }
+
+// Owners 实现 events.Owned 接口，删除事件携带所属供应商ID
+func (p Product) Owners() map[string]uint { return map[string]uint{"supplier_id": p.SupplierID} }
+
+// 商品领域事件
+type (
+	ProductCreated  = events.Created[Product]  // 商品创建
+	ProductUpdated  = events.Updated[Product]  // 商品更新
+	ProductToggled  = events.Toggled[Product]  // 商品启用状态切换
+	ProductDeleted  = events.Deleted[Product]  // 商品删除（移入回收站）
+	ProductRestored = events.Restored[Product] // 商品从回收站恢复
+)
+
+// StockAdjusted 库存调整事件
+type StockAdjusted struct {
+	ProductID uint    `json:"product_id"` // 商品ID
+	Before    int     `json:"before"`     // 调整前库存
+	After     int     `json:"after"`      // 调整后库存
+	Product   Product `json:"product"`    // 调整后的商品
+}
+
+func (StockAdjusted) EventName() string     { return "product.stock_changed" }
+func (StockAdjusted) AggregateType() string { return "product" }
+func (e StockAdjusted) AggregateID() uint   { return e.ProductID }
+
+// PriceChanged 价格变更事件
+type PriceChanged struct {
+	ProductID uint    `json:"product_id"` // 商品ID
+	Before    float64 `json:"before"`     // 变更前价格
+	After     float64 `json:"after"`      // 变更后价格
+	Product   Product `json:"product"`    // 变更后的商品
+}
+
+func (PriceChanged) EventName() string     { return "product.price_changed" }
+func (PriceChanged) AggregateType() string { return "product" }
+func (e PriceChanged) AggregateID() uint   { return e.ProductID }
+
+// updated 全量或部分更新后的事件：商品更新，库存、价格有变化时附带对应事件
+func updated(before, after *Product) []events.Event {
+	changes := []events.Event{ProductUpdated{Entity: *after}}
+	if before.Stock != after.Stock {
+		changes = append(changes, stockAdjusted(before, after)...)
+	}
+	if before.Price != after.Price {
+		changes = append(changes, priceChanged(before, after)...)
+	}
+	return changes
+}
+
+// stockAdjusted 库存调整后的事件
+func stockAdjusted(before, after *Product) []events.Event {
+	return []events.Event{StockAdjusted{ProductID: after.ID, Before: before.Stock, After: after.Stock, Product: *after}}
+}
+
+// priceChanged 价格变更后的事件
+func priceChanged(before, after *Product) []events.Event {
+	return []events.Event{PriceChanged{ProductID: after.ID, Before: before.Price, After: after.Price, Product: *after}}
+}
+
+// Events 实现 module.EventsModule 接口
+func (Module) Events() []events.Event {
+	return []events.Event{
+		ProductCreated{}, ProductUpdated{}, ProductToggled{}, ProductDeleted{}, ProductRestored{},
+		StockAdjusted{}, PriceChanged{},
+	}
+}
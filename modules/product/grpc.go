@@ -0,0 +1,290 @@
+package product
+
+import (
+	"context"
+	"net/url"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/rpc"
+	"erp_backend/pkg/rpc/erpv1"
+)
+
+// maxStockItems GetStock 一次最多查询的商品数
+const maxStockItems = pagination.MaxPageSize
+
+// RegisterGRPC 注册商品与库存 gRPC 服务
+func RegisterGRPC(s grpc.ServiceRegistrar, db *gorm.DB) {
+	service := NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db))
+	erpv1.RegisterProductServiceServer(s, &grpcServer{service: service, batchSize: config.GetGRPCConfig().ExportBatchSize})
+	erpv1.RegisterStockServiceServer(s, &stockServer{service: service})
+}
+
+// grpcPermissions 商品与库存 gRPC 方法所需的权限，与对应 REST 接口一致
+var grpcPermissions = map[string]string{
+	erpv1.ProductService_GetProduct_FullMethodName:     "product.read",
+	erpv1.ProductService_ListProducts_FullMethodName:   "product.read",
+	erpv1.ProductService_ExportProducts_FullMethodName: "product.read",
+	erpv1.ProductService_CreateProduct_FullMethodName:  "product.write",
+	erpv1.ProductService_UpdateProduct_FullMethodName:  "product.write",
+	erpv1.ProductService_DeleteProduct_FullMethodName:  "product.write",
+	erpv1.ProductService_ToggleProduct_FullMethodName:  "product.write",
+	erpv1.StockService_GetStock_FullMethodName:         "product.read",
+	erpv1.StockService_UpdateStock_FullMethodName:      "product.stock",
+	erpv1.StockService_UpdatePrice_FullMethodName:      "product.price",
+}
+
+// grpcServer 商品 gRPC 服务
+type grpcServer struct {
+	erpv1.UnimplementedProductServiceServer
+	service   *Service
+	batchSize int // 流式导出每批读取的条数上限
+}
+
+// GetProduct 获取单个商品
+func (s *grpcServer) GetProduct(ctx context.Context, req *erpv1.GetProductRequest) (*erpv1.Product, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.service.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(product), nil
+}
+
+// ListProducts 分页查询商品
+func (s *grpcServer) ListProducts(ctx context.Context, req *erpv1.ListProductsRequest) (*erpv1.ListProductsResponse, error) {
+	page, err := s.service.List(ctx, rpc.Query(req.Options))
+	if err != nil {
+		return nil, err
+	}
+	products := *page.Items.(*[]Product)
+	resp := &erpv1.ListProductsResponse{Items: make([]*erpv1.Product, len(products)), Page: rpc.PageInfo(page)}
+	for i := range products {
+		resp.Items[i] = toProto(&products[i])
+	}
+	return resp, nil
+}
+
+// CreateProduct 创建商品
+func (s *grpcServer) CreateProduct(ctx context.Context, req *erpv1.CreateProductRequest) (*erpv1.Product, error) {
+	if req.Product == nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	product := fromProto(req.Product)
+	if err := s.service.Create(ctx, product); err != nil {
+		return nil, err
+	}
+	return toProto(product), nil
+}
+
+// UpdateProduct 按字段掩码更新商品，与 PATCH 接口使用相同的白名单、校验规则与领域事件
+func (s *grpcServer) UpdateProduct(ctx context.Context, req *erpv1.UpdateProductRequest) (*erpv1.Product, error) {
+	if req.Product == nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	id, err := rpc.ID(req.Product.Id)
+	if err != nil {
+		return nil, err
+	}
+	values, err := rpc.Fields(patchValues(req.Product), req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.service.Merge(ctx, id, values)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(product), nil
+}
+
+// DeleteProduct 删除商品
+func (s *grpcServer) DeleteProduct(ctx context.Context, req *erpv1.DeleteProductRequest) (*emptypb.Empty, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ToggleProduct 切换商品启用状态
+func (s *grpcServer) ToggleProduct(ctx context.Context, req *erpv1.ToggleProductRequest) (*erpv1.Product, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.service.Toggle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(product), nil
+}
+
+// ExportProducts 分批读取并逐条发送商品，内存占用与商品总数无关
+func (s *grpcServer) ExportProducts(req *erpv1.ExportProductsRequest, stream grpc.ServerStreamingServer[erpv1.Product]) error {
+	query := url.Values{}
+	rpc.Filter(query, req.Filter)
+
+	batchSize := s.batchSize
+	if req.BatchSize > 0 && int(req.BatchSize) < batchSize {
+		batchSize = int(req.BatchSize)
+	}
+
+	return s.service.Export(stream.Context(), query, batchSize, func(products []Product) error {
+		for i := range products {
+			if err := stream.Send(toProto(&products[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// stockServer 库存 gRPC 服务
+type stockServer struct {
+	erpv1.UnimplementedStockServiceServer
+	service *Service
+}
+
+// GetStock 批量查询商品库存与价格
+func (s *stockServer) GetStock(ctx context.Context, req *erpv1.GetStockRequest) (*erpv1.GetStockResponse, error) {
+	if len(req.ProductIds) > maxStockItems {
+		return nil, errcode.GRPCStockTooManyItems.New(maxStockItems)
+	}
+	ids := make([]uint, 0, len(req.ProductIds))
+	for _, productID := range req.ProductIds {
+		id, err := rpc.ID(productID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	resp := &erpv1.GetStockResponse{Items: []*erpv1.StockLevel{}}
+	if len(ids) == 0 {
+		return resp, nil
+	}
+	products, err := s.service.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range products {
+		resp.Items = append(resp.Items, toStockLevel(&products[i]))
+	}
+	return resp, nil
+}
+
+// UpdateStock 更新库存
+func (s *stockServer) UpdateStock(ctx context.Context, req *erpv1.UpdateStockRequest) (*erpv1.StockLevel, error) {
+	id, err := rpc.ID(req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.service.UpdateStock(ctx, id, int(req.Stock))
+	if err != nil {
+		return nil, err
+	}
+	return toStockLevel(product), nil
+}
+
+// UpdatePrice 更新价格
+func (s *stockServer) UpdatePrice(ctx context.Context, req *erpv1.UpdatePriceRequest) (*erpv1.StockLevel, error) {
+	id, err := rpc.ID(req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	product, err := s.service.UpdatePrice(ctx, id, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	return toStockLevel(product), nil
+}
+
+// toProto 转换为 protobuf 消息
+func toProto(p *Product) *erpv1.Product {
+	msg := &erpv1.Product{
+		Id:         uint64(p.ID),
+		SupplierId: uint64(p.SupplierID),
+		CategoryId: uint64(p.CategoryID),
+		Name:       p.Name,
+		Sku:        p.SKU,
+		Type:       int32(p.Type),
+		Price:      p.Price,
+		Stock:      int32(p.Stock),
+		Remark:     p.Remark,
+		IsEnabled:  p.IsEnabled,
+		CreatedAt:  timestamppb.New(p.CreatedAt),
+		UpdatedAt:  timestamppb.New(p.UpdatedAt),
+	}
+	if p.DynamicAttrs != nil {
+		// 动态属性来自 JSON，总能转换为 Struct
+		msg.DynamicAttrs, _ = structpb.NewStruct(p.DynamicAttrs)
+	}
+	return msg
+}
+
+// fromProto 转换为待创建的商品，忽略 id 与时间字段
+func fromProto(msg *erpv1.Product) *Product {
+	product := &Product{
+		SupplierID: uint(msg.SupplierId),
+		CategoryID: uint(msg.CategoryId),
+		Name:       msg.Name,
+		SKU:        msg.Sku,
+		Type:       int(msg.Type),
+		Price:      msg.Price,
+		Stock:      int(msg.Stock),
+		Remark:     msg.Remark,
+		IsEnabled:  msg.IsEnabled,
+	}
+	if msg.DynamicAttrs != nil {
+		product.DynamicAttrs = msg.DynamicAttrs.AsMap()
+	}
+	return product
+}
+
+// patchValues 消息中可修改的字段，键为 JSON 字段名（与 protobuf 字段名相同）
+func patchValues(msg *erpv1.Product) map[string]interface{} {
+	var attrs interface{}
+	if msg.DynamicAttrs != nil {
+		attrs = msg.DynamicAttrs.AsMap()
+	}
+	return map[string]interface{}{
+		"supplier_id":   msg.SupplierId,
+		"category_id":   msg.CategoryId,
+		"name":          msg.Name,
+		"sku":           msg.Sku,
+		"type":          msg.Type,
+		"price":         msg.Price,
+		"stock":         msg.Stock,
+		"dynamic_attrs": attrs,
+		"remark":        msg.Remark,
+		"is_enabled":    msg.IsEnabled,
+	}
+}
+
+// toStockLevel 转换为库存消息
+func toStockLevel(p *Product) *erpv1.StockLevel {
+	return &erpv1.StockLevel{
+		ProductId: uint64(p.ID),
+		Sku:       p.SKU,
+		Name:      p.Name,
+		Stock:     int32(p.Stock),
+		Price:     p.Price,
+		IsEnabled: p.IsEnabled,
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+}
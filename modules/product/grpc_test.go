@@ -0,0 +1,90 @@
+package product
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/rpc"
+	"erp_backend/pkg/rpc/erpv1"
+)
+
+// testAPIKey 测试使用的 API 密钥
+const testAPIKey = "test-api-key"
+
+// newTestGRPCClient 启动使用内存仓储、按模块声明检查权限的 gRPC 服务，返回商品服务客户端
+func newTestGRPCClient(t *testing.T, products ...Product) erpv1.ProductServiceClient {
+	t.Helper()
+	service, _ := newTestService(products...)
+	cfg := &config.GRPCConfig{MaxRecvMsgSize: 4 << 20, ShutdownTimeout: time.Second}
+	s := rpc.New(cfg, rpc.NewAuthenticator([]string{testAPIKey}, module.GRPCAccess([]module.Module{Module{}})))
+	erpv1.RegisterProductServiceServer(s, &grpcServer{service: service, batchSize: 100})
+
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Serve(ctx, lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() 错误 = %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+		<-done
+	})
+	return erpv1.NewProductServiceClient(conn)
+}
+
+// withToken 以指定用户类型的令牌发起调用
+func withToken(t *testing.T, userType string) context.Context {
+	t.Helper()
+	token, err := middleware.GenerateToken(1, userType, 0, "")
+	if err != nil {
+		t.Fatalf("GenerateToken() 错误 = %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), rpc.AuthorizationKey, "Bearer "+token)
+}
+
+func TestGRPCPermissions(t *testing.T) {
+	client := newTestGRPCClient(t, Product{Name: "a", SKU: "A-1", SupplierID: 3, Stock: 10})
+	create := &erpv1.CreateProductRequest{Product: &erpv1.Product{Name: "b", Sku: "B-1", SupplierId: 3}}
+
+	// 普通用户可以查看商品，但不能创建
+	if _, err := client.GetProduct(withToken(t, "user"), &erpv1.GetProductRequest{Id: 1}); err != nil {
+		t.Errorf("user GetProduct() 错误 = %v, 期望成功", err)
+	}
+	if _, err := client.CreateProduct(withToken(t, "user"), create); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("user CreateProduct() 错误 = %v, 期望 PermissionDenied", err)
+	}
+
+	// 员工拥有 product.write 权限
+	if _, err := client.CreateProduct(withToken(t, "员工"), create); err != nil {
+		t.Errorf("员工 CreateProduct() 错误 = %v, 期望成功", err)
+	}
+
+	// API 密钥调用方为内部服务，不检查权限
+	ctx := metadata.AppendToOutgoingContext(context.Background(), rpc.APIKeyKey, testAPIKey)
+	create.Product.Sku = "C-1"
+	if _, err := client.CreateProduct(ctx, create); err != nil {
+		t.Errorf("API 密钥 CreateProduct() 错误 = %v, 期望成功", err)
+	}
+}
@@ -1,21 +1,61 @@
 package product
 
 import (
-	"net/http"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/resource"
 	"erp_backend/pkg/response"
 )
 
+// listConfig 商品列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "sku", "price", "stock", "type", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 商品列表筛选字段
+var listFilters = filter.Fields{
+	"id":          filter.Number,
+	"supplier_id": filter.Number,
+	"category_id": filter.Number,
+	"name":        filter.String,
+	"sku":         filter.String,
+	"type":        filter.Number,
+	"price":       filter.Number,
+	"currency":    filter.String,
+	"stock":       filter.Number,
+	"remark":      filter.String,
+	"is_enabled":  filter.Bool,
+	"created_at":  filter.Time,
+	"updated_at":  filter.Time,
+}
+
+// patchFields 商品允许通过 PATCH 修改的字段及校验规则
+var patchFields = patch.Fields{
+	"supplier_id":   "required",
+	"category_id":   "required",
+	"name":          "required,max=200",
+	"sku":           "max=50",
+	"type":          "",
+	"price":         "min=0",
+	"currency":      "required,iso4217",
+	"stock":         "",
+	"dynamic_attrs": "",
+	"remark":        "",
+	"is_enabled":    "",
+}
+
 type Handler struct {
-	db *gorm.DB
+	resource *resource.Handler[Product]
+	service  *Service
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(service *Service) *Handler {
+	return &Handler{resource: resource.NewHandler(service.Resource), service: service}
 }
 
 // Create 创建商品
@@ -24,65 +64,70 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Tags 商品管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param product body Product true "商品信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Product} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 409 {object} response.Response "商品SKU已存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /products [post]
 func (h *Handler) Create(c *gin.Context) {
-	var product Product
-	if err := c.ShouldBindJSON(&product); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Create(&product).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建商品失败")
-		return
-	}
-
-	response.Success(c, product)
+	h.resource.Create(c)
 }
 
 // List 获取商品列表
 // @Summary 获取商品列表
-// @Description 获取所有商品的列表
+// @Description 分页获取商品列表，回收站中的商品不包含在内
 // @Tags 商品管理
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Response{data=[]Product} "获取成功"
+// @Security ApiKeyAuth
+// @Param supplier_id query string false "供应商ID"
+// @Param category_id query string false "分类ID"
+// @Param type query string false "商品类型"
+// @Param is_enabled query string false "是否启用"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Product}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /products [get]
 func (h *Handler) List(c *gin.Context) {
-	var products []Product
-	query := h.db.Model(&Product{})
-
-	// 支持按供应商ID筛选
-	if supplierID := c.Query("supplier_id"); supplierID != "" {
-		query = query.Where("supplier_id = ?", supplierID)
-	}
-
-	// 支持按分类ID筛选
-	if categoryID := c.Query("category_id"); categoryID != "" {
-		query = query.Where("category_id = ?", categoryID)
-	}
-
-	// 支持按商品类型筛选
-	if productType := c.Query("type"); productType != "" {
-		query = query.Where("type = ?", productType)
-	}
-
-	// 支持按启用状态筛选
-	if isEnabled := c.Query("is_enabled"); isEnabled != "" {
-		query = query.Where("is_enabled = ?", isEnabled)
-	}
-
-	if err := query.Find(&products).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取商品列表失败")
-		return
-	}
+	h.resource.List(c)
+}
 
-	response.Success(c, products)
+// Trash 获取回收站中的商品
+// @Summary 获取回收站中的商品
+// @Description 分页获取已删除的商品，查询参数同列表接口
+// @Tags 商品管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param supplier_id query string false "供应商ID"
+// @Param category_id query string false "分类ID"
+// @Param type query string false "商品类型"
+// @Param is_enabled query string false "是否启用"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Product}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /products/trash [get]
+func (h *Handler) Trash(c *gin.Context) {
+	h.resource.Trash(c)
 }
 
 // Get 获取单个商品
@@ -91,90 +136,99 @@ func (h *Handler) List(c *gin.Context) {
 // @Tags 商品管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "商品ID"
 // @Success 200 {object} response.Response{data=Product} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "商品不存在"
 // @Router /products/{id} [get]
 func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var product Product
-	if err := h.db.First(&product, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "商品不存在")
-		return
-	}
-
-	response.Success(c, product)
+	h.resource.Get(c)
 }
 
 // Update 更新商品
 // @Summary 更新商品
-// @Description 更新商品信息
+// @Description 全量更新商品信息，主键与创建时间不可修改
 // @Tags 商品管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "商品ID"
 // @Param product body Product true "商品信息"
 // @Success 200 {object} response.Response{data=Product} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "商品不存在"
+// @Failure 409 {object} response.Response "商品SKU已存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /products/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var product Product
-	if err := h.db.First(&product, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "商品不存在")
-		return
-	}
-
-	if err := c.ShouldBindJSON(&product); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Save(&product).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新商品失败")
-		return
-	}
+	h.resource.Update(c)
+}
 
-	response.Success(c, product)
+// Patch 部分更新商品
+// @Summary 部分更新商品
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改商品，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 商品管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "商品ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Product} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "商品不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /products/{id} [patch]
+func (h *Handler) Patch(c *gin.Context) {
+	h.resource.Patch(c)
 }
 
 // Delete 删除商品
 // @Summary 删除商品
-// @Description 删除商品
+// @Description 将商品移入回收站，可通过恢复接口恢复；商品不存在时同样返回成功
 // @Tags 商品管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "商品ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /products/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	if err := h.db.Delete(&Product{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除商品失败")
-		return
-	}
+	h.resource.Delete(c)
+}
 
-	response.Success(c, gin.H{"message": "删除成功"})
+// Restore 恢复商品
+// @Summary 恢复商品
+// @Description 将回收站中的商品恢复
+// @Tags 商品管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "商品ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Product} "恢复成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "商品不存在或不在回收站中"
+// @Failure 409 {object} response.Response "商品SKU已存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /products/{id}/restore [post]
+func (h *Handler) Restore(c *gin.Context) {
+	h.resource.Restore(c)
 }
 
 // ToggleStatus 切换商品状态
@@ -183,80 +237,131 @@ func (h *Handler) Delete(c *gin.Context) {
 // @Tags 商品管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "商品ID"
-// @Success 200 {object} response.Response{data=Product} "切换成功"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Product} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "商品不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /products/{id}/toggle [patch]
 func (h *Handler) ToggleStatus(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	h.resource.Toggle(c)
+}
+
+// Search 搜索商品
+// @Summary 搜索商品
+// @Description 按名称、SKU、备注和动态属性值搜索商品，按相关度排序，支持中文子串匹配与拼写容错；回收站中的商品不包含在内。只支持页码分页，不支持 cursor 与 sort
+// @Tags 商品管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "搜索关键词"
+// @Param supplier_id query string false "供应商ID"
+// @Param category_id query string false "分类ID"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[price][lte]=100"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]SearchResult}} "搜索成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /products/search [get]
+func (h *Handler) Search(c *gin.Context) {
+	page, err := h.service.Search(c, c.Request.URL.Query())
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.FailWithError(c, err)
 		return
 	}
 
-	var product Product
-	if err := h.db.First(&product, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "商品不存在")
-		return
-	}
+	response.Success(c, page)
+}
 
-	product.IsEnabled = !product.IsEnabled
-	if err := h.db.Save(&product).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新状态失败")
-		return
-	}
+// StockUpdate 更新库存请求
+type StockUpdate struct {
+	Stock *int `json:"stock" binding:"required" example:"100"` // 库存数量
+}
 
-	response.Success(c, product)
+// PriceUpdate 更新价格请求
+type PriceUpdate struct {
+	Price *float64 `json:"price" binding:"required" example:"99.9"` // 价格
 }
 
 // UpdateStock 更新库存
+// @Summary 更新库存
+// @Description 设置商品库存，库存有变化时发布库存调整事件；与价格接口共用批量限流额度，适合同步脚本调用。供应商只能修改所属供应商的商品
+// @Tags 商品管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "商品ID"
+// @Param stock body StockUpdate true "库存信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "商品不存在"
+// @Failure 429 {object} response.Response "请求过于频繁"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /products/{id}/stock [patch]
 func (h *Handler) UpdateStock(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+	id, ok := resource.ID(c)
+	if !ok {
 		return
 	}
 
-	var stockUpdate struct {
-		Stock int `json:"stock" binding:"required"`
-	}
-
-	if err := c.ShouldBindJSON(&stockUpdate); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+	var req StockUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
 		return
 	}
 
-	if err := h.db.Model(&Product{}).Where("id = ?", id).Update("stock", stockUpdate.Stock).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新库存失败")
+	if _, err := h.service.UpdateStock(c, id, *req.Stock); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, gin.H{"message": "更新库存成功"})
+	response.Success(c, gin.H{"message": i18n.T(c, "message.stock_updated")})
 }
 
 // UpdatePrice 更新价格
+// @Summary 更新价格
+// @Description 设置商品价格，价格有变化时发布价格变更事件；与库存接口共用批量限流额度，适合同步脚本调用。供应商只能修改所属供应商的商品
+// @Tags 商品管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "商品ID"
+// @Param price body PriceUpdate true "价格信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "商品不存在"
+// @Failure 429 {object} response.Response "请求过于频繁"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /products/{id}/price [patch]
 func (h *Handler) UpdatePrice(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+	id, ok := resource.ID(c)
+	if !ok {
 		return
 	}
 
-	var priceUpdate struct {
-		Price float64 `json:"price" binding:"required"`
-	}
-
-	if err := c.ShouldBindJSON(&priceUpdate); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+	var req PriceUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
 		return
 	}
 
-	if err := h.db.Model(&Product{}).Where("id = ?", id).Update("price", priceUpdate.Price).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新价格失败")
+	if _, err := h.service.UpdatePrice(c, id, *req.Price); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, gin.H{"message": "更新价格成功"})
+	response.Success(c, gin.H{"message": i18n.T(c, "message.price_updated")})
 }
@@ -0,0 +1,64 @@
+package product
+
+import (
+	"context"
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/jobs"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/settings"
+)
+
+// lowStockScanKind 低库存检查任务类型
+const lowStockScanKind = "product.low_stock_scan"
+
+// lowStockScanLimit 日志中列出的低库存商品数
+const lowStockScanLimit = 50
+
+// LowStockScanPayload 低库存检查任务参数，手动入队时可指定阈值
+type LowStockScanPayload struct {
+	Threshold *int `json:"threshold,omitempty"` // 低库存阈值，为空时使用设置 product.low_stock_threshold
+}
+
+// registerJobs 注册商品的后台任务：每小时检查低库存，每天清理回收站
+func registerJobs(app *module.App) {
+	app.Queue.Register(jobs.Definition{Kind: lowStockScanKind, Handler: lowStockScan(app.DB)})
+	app.Queue.Schedule(lowStockScanKind, "0 * * * *", lowStockScanKind, LowStockScanPayload{})
+	app.Queue.PurgeTrash("product", &Product{})
+}
+
+// lowStockScan 检查库存小于等于阈值的启用商品，存在时记录警告日志
+func lowStockScan(db *gorm.DB) jobs.Handler {
+	return func(ctx context.Context, job *jobs.Job) error {
+		var payload LowStockScanPayload
+		if err := job.Decode(&payload); err != nil {
+			return err
+		}
+		threshold := settings.Get[int](ctx, SettingLowStockThreshold)
+		if payload.Threshold != nil {
+			threshold = *payload.Threshold
+		}
+
+		query := db.WithContext(ctx).Model(&Product{}).Where("is_enabled AND stock <= ?", threshold).Session(&gorm.Session{})
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+
+		var skus []string
+		if err := query.Order("stock, id").Limit(lowStockScanLimit).Pluck("sku", &skus).Error; err != nil {
+			return err
+		}
+		slog.WarnContext(ctx, "商品库存不足",
+			slog.Int("threshold", threshold),
+			slog.Int64("count", count),
+			slog.Any("skus", skus),
+		)
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package product
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"erp_backend/pkg/metrics"
+	"erp_backend/pkg/settings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// metricsTimeout 采集业务指标的查询超时
+const metricsTimeout = 5 * time.Second
+
+var (
+	productCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "products", "total"),
+		"商品总数", []string{"enabled"}, nil,
+	)
+	stockUnitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "products", "stock_units"),
+		"启用商品的库存总量", nil, nil,
+	)
+	lowStockDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metrics.Namespace, "products", "low_stock"),
+		"库存不高于低库存阈值的启用商品数", nil, nil,
+	)
+)
+
+// MetricsCollector 商品业务指标，每次抓取时查询数据库
+type MetricsCollector struct {
+	db *gorm.DB
+}
+
+// NewMetricsCollector 创建商品业务指标采集器，低库存阈值取自设置 product.low_stock_threshold
+func NewMetricsCollector(db *gorm.DB) *MetricsCollector {
+	return &MetricsCollector{db: db}
+}
+
+// Describe 实现 prometheus.Collector
+func (m *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- productCountDesc
+	ch <- stockUnitsDesc
+	ch <- lowStockDesc
+}
+
+// Collect 实现 prometheus.Collector
+func (m *MetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsTimeout)
+	defer cancel()
+
+	var stats struct {
+		Enabled    int64
+		Disabled   int64
+		StockUnits int64
+		LowStock   int64
+	}
+	err := m.db.WithContext(ctx).Model(&Product{}).
+		Select(`COUNT(*) FILTER (WHERE is_enabled) AS enabled,
+			COUNT(*) FILTER (WHERE NOT is_enabled) AS disabled,
+			COALESCE(SUM(stock) FILTER (WHERE is_enabled), 0) AS stock_units,
+			COUNT(*) FILTER (WHERE is_enabled AND stock <= ?) AS low_stock`, settings.Get[int](ctx, SettingLowStockThreshold)).
+		Scan(&stats).Error
+	if err != nil {
+		slog.Error("采集商品指标失败", slog.Any("error", err))
+		ch <- prometheus.NewInvalidMetric(productCountDesc, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(productCountDesc, prometheus.GaugeValue, float64(stats.Enabled), "true")
+	ch <- prometheus.MustNewConstMetric(productCountDesc, prometheus.GaugeValue, float64(stats.Disabled), "false")
+	ch <- prometheus.MustNewConstMetric(stockUnitsDesc, prometheus.GaugeValue, float64(stats.StockUnits))
+	ch <- prometheus.MustNewConstMetric(lowStockDesc, prometheus.GaugeValue, float64(stats.LowStock))
+}
@@ -0,0 +1,74 @@
+package product
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// searchMigrations 商品搜索相关的数据库结构
+// search_vector 用于全文检索，search_text 用于三元组模糊匹配（中文与拼写容错）
+// 两者均为生成列，随商品数据自动维护，需要 PostgreSQL 12+
+// pg_trgm 按数据库的 LC_CTYPE 判断字母，C 或 POSIX 区域下中文字符不产生三元组，
+// 此时中文的相似度匹配与三元组索引不起作用，只能依靠 searchQuery 中的 ILIKE 子串匹配（全表扫描）
+var searchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(sku, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(remark, '')), 'C') ||
+		setweight(jsonb_to_tsvector('simple', coalesce(dynamic_attrs::jsonb, '{}'::jsonb), '["string", "numeric"]'), 'B')
+	) STORED`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_text text GENERATED ALWAYS AS (
+		coalesce(name, '') || ' ' || coalesce(sku, '') || ' ' || coalesce(remark, '') || ' ' || coalesce(dynamic_attrs::text, '')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING gin (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_products_search_text_trgm ON products USING gin (search_text gin_trgm_ops)`,
+}
+
+// skuMigrations SKU 唯一索引改为只约束未删除的商品（idx_products_sku_active），删除旧的全表唯一索引，
+// 使回收站中商品的 SKU 可以被新商品复用
+var skuMigrations = []string{
+	`DROP INDEX IF EXISTS idx_products_sku`,
+}
+
+// Migrate 执行商品模块的额外迁移（SKU 唯一索引与搜索索引）
+func Migrate(db *gorm.DB) error {
+	if err := execStatements(db, skuMigrations); err != nil {
+		return err
+	}
+	return execStatements(db, searchMigrations)
+}
+
+// execStatements 依次执行迁移语句
+func execStatements(db *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reindex 重建商品搜索索引并更新统计信息
+// rebuild 为 true 时先删除搜索生成列，再按当前表达式重新生成，用于修改分词规则后重新计算已有商品的搜索数据
+// 只处理搜索相关的列与索引，不执行 SKU 索引等其他迁移
+func Reindex(ctx context.Context, db *gorm.DB, rebuild bool) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if rebuild {
+			for _, column := range []string{"search_vector", "search_text"} {
+				if err := tx.Exec("ALTER TABLE products DROP COLUMN IF EXISTS " + column).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if err := execStatements(tx, searchMigrations); err != nil {
+			return err
+		}
+		return execStatements(tx, []string{
+			`REINDEX INDEX idx_products_search_vector`,
+			`REINDEX INDEX idx_products_search_text_trgm`,
+			`ANALYZE products`,
+		})
+	})
+}
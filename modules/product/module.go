@@ -0,0 +1,71 @@
+package product
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/settings"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 商品管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "product" }
+
+// DependsOn 实现 module.Module 接口
+func (Module) DependsOn() []string { return []string{"supplier", "category"} }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&Product{}} }
+
+// Migrate 实现 module.Module 接口，创建搜索索引
+func (Module) Migrate(db *gorm.DB) error { return Migrate(db) }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// RegisterGRPC 实现 module.GRPCModule 接口
+func (Module) RegisterGRPC(s grpc.ServiceRegistrar, app *module.App) { RegisterGRPC(s, app.DB) }
+
+// GRPCPermissions 实现 module.GRPCModule 接口
+func (Module) GRPCPermissions() map[string]string { return grpcPermissions }
+
+// Collectors 实现 module.MetricsModule 接口
+func (Module) Collectors(app *module.App) []prometheus.Collector {
+	return []prometheus.Collector{NewMetricsCollector(app.DB)}
+}
+
+// Settings 实现 module.SettingsModule 接口
+func (Module) Settings() []settings.Definition { return Settings() }
+
+// Jobs 实现 module.Module 接口，注册低库存检查与回收站清理任务
+func (Module) Jobs(app *module.App) []module.Job {
+	registerJobs(app)
+	return nil
+}
+
+// Commands 实现 module.CommandModule 接口
+func (Module) Commands() []module.Command { return Commands() }
+
+// suppliersAndStaff 管理员、员工与供应商，供应商只能修改所属供应商的商品
+var suppliersAndStaff = append(append([]string{}, module.Staff...), SupplierUserType)
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "product.read", Description: "查看与搜索商品"},
+		{Code: "product.write", Description: "创建、修改与删除商品", UserTypes: module.Staff},
+		{Code: "product.stock", Description: "修改商品库存，供应商可通过同步脚本更新所属供应商的商品", UserTypes: suppliersAndStaff},
+		{Code: "product.price", Description: "修改商品价格，供应商可通过同步脚本更新所属供应商的商品", UserTypes: suppliersAndStaff},
+	}
+}
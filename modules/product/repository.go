@@ -0,0 +1,65 @@
+package product
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/repository"
+)
+
+// Repository 商品数据访问接口
+type Repository interface {
+	repository.Trashable[Product]
+	// Export 按主键顺序分批读取符合条件的商品，每批调用一次 fn，fn 返回错误时停止并返回该错误
+	Export(ctx context.Context, conditions filter.Conditions, batchSize int, fn func([]Product) error) error
+	// Search 按关键词搜索商品，按相关度排序分页返回，同时返回命中总数
+	Search(ctx context.Context, keyword string, conditions filter.Conditions, params *pagination.Params) ([]SearchResult, int64, error)
+}
+
+// gormRepository 基于 GORM 的商品仓储
+type gormRepository struct {
+	repository.Gorm[Product]
+}
+
+// NewRepository 创建基于 GORM 的商品仓储
+func NewRepository(db *gorm.DB) Repository {
+	return gormRepository{Gorm: repository.NewGorm[Product](db)}
+}
+
+// Export 实现 Repository 接口
+func (r gormRepository) Export(ctx context.Context, conditions filter.Conditions, batchSize int, fn func([]Product) error) error {
+	var products []Product
+	var fnErr error
+	result := conditions.Apply(r.DB(ctx).Model(&Product{})).FindInBatches(&products, batchSize, func(*gorm.DB, int) error {
+		fnErr = fn(products)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return result.Error
+}
+
+// Search 实现 Repository 接口
+func (r gormRepository) Search(ctx context.Context, keyword string, conditions filter.Conditions, params *pagination.Params) ([]SearchResult, int64, error) {
+	query := conditions.Apply(searchQuery(r.DB(ctx), keyword))
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var results []SearchResult
+	if err := query.
+		Select("products.*, "+searchScore, keyword, keyword, keyword).
+		Order("score DESC, id DESC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&results).Error; err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
@@ -1,23 +1,35 @@
 package product
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/ratelimit"
 )
 
 // RegisterRoutes 注册商品相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db)))
 
+	read, write := module.RequirePermission("product.read"), module.RequirePermission("product.write")
+	stock, price := module.RequirePermission("product.stock"), module.RequirePermission("product.price")
 	products := r.Group("/products")
 	{
-		products.POST("", handler.Create)
-		products.GET("", handler.List)
-		products.GET("/:id", handler.Get)
-		products.PUT("/:id", handler.Update)
-		products.DELETE("/:id", handler.Delete)
-		products.PATCH("/:id/toggle", handler.ToggleStatus)
-		products.PATCH("/:id/stock", handler.UpdateStock)
-		products.PATCH("/:id/price", handler.UpdatePrice)
+		products.POST("", write, handler.Create)
+		products.GET("", read, handler.List)
+		products.GET("/search", read, handler.Search)
+		products.GET("/trash", read, handler.Trash)
+		products.GET("/:id", read, handler.Get)
+		products.PUT("/:id", write, handler.Update)
+		products.PATCH("/:id", write, handler.Patch)
+		products.DELETE("/:id", write, handler.Delete)
+		products.POST("/:id/restore", write, handler.Restore)
+		products.PATCH("/:id/toggle", write, handler.ToggleStatus)
+		// 库存、价格常由同步脚本高频调用，使用独立的限流额度
+		products.PATCH("/:id/stock", stock, middleware.RateLimit(ratelimit.PolicyBulk), handler.UpdateStock)
+		products.PATCH("/:id/price", price, middleware.RateLimit(ratelimit.PolicyBulk), handler.UpdatePrice)
 	}
 }
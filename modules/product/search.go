@@ -0,0 +1,79 @@
+package product
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SearchResult 商品搜索结果
+// @Description 商品搜索结果，包含相关度得分与高亮片段
+type SearchResult struct {
+	Product
+	Score      float64           `json:"score"`                         // 相关度得分
+	Highlights map[string]string `gorm:"-" json:"highlights,omitempty"` // 高亮片段，命中词以 <em> 包裹
+}
+
+// searchQuery 构造商品搜索查询
+// 全文检索负责英文与数字分词，三元组相似度负责中文子串匹配与拼写容错
+// 'simple' 分词把连续的中文视为一个词，全文检索只能命中完整的中文片段；
+// 三元组在 C 区域的数据库中不处理中文（见 searchMigrations），因此中文的召回以逐词 ILIKE 为准
+func searchQuery(db *gorm.DB, keyword string) *gorm.DB {
+	// 中文没有空格分词，逐个关键词做子串匹配
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	terms := strings.Fields(keyword)
+	likes := make([]string, len(terms))
+	args := []interface{}{keyword, keyword}
+	for i, term := range terms {
+		likes[i] = "search_text ILIKE ?"
+		args = append(args, "%"+escaper.Replace(term)+"%")
+	}
+
+	// 按表名查询不会自动排除回收站中的商品
+	return db.Table("products").
+		Where("deleted_at IS NULL").
+		Where("search_vector @@ websearch_to_tsquery('simple', ?) OR ? <% search_text OR ("+strings.Join(likes, " AND ")+")", args...)
+}
+
+// searchScore 相关度计算表达式：全文检索排名 + 三元组相似度 + SKU 精确命中加权
+const searchScore = "ts_rank_cd(search_vector, websearch_to_tsquery('simple', ?)) * 2" +
+	" + word_similarity(?, search_text)" +
+	" + CASE WHEN lower(sku) = lower(?) THEN 10 ELSE 0 END AS score"
+
+// highlight 为搜索结果生成高亮片段
+func highlight(result *SearchResult, keyword string) {
+	terms := strings.Fields(keyword)
+	if len(terms) == 0 {
+		return
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(html.EscapeString(term))
+	}
+	pattern := regexp.MustCompile("(?i)(" + strings.Join(quoted, "|") + ")")
+
+	fields := map[string]string{
+		"name":   result.Name,
+		"sku":    result.SKU,
+		"remark": result.Remark,
+	}
+	for key, value := range result.DynamicAttrs {
+		if s, ok := value.(string); ok {
+			fields["dynamic_attrs."+key] = s
+		}
+	}
+
+	for key, value := range fields {
+		escaped := html.EscapeString(value)
+		if !pattern.MatchString(escaped) {
+			continue
+		}
+		if result.Highlights == nil {
+			result.Highlights = make(map[string]string)
+		}
+		result.Highlights[key] = pattern.ReplaceAllString(escaped, "<em>$1</em>")
+	}
+}
@@ -0,0 +1,121 @@
+package product
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSearchQuery(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1"}), &gorm.Config{
+		DisableAutomaticPing: true,
+		DryRun:               true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+
+	// 中文依赖逐词 ILIKE 召回（C 区域下三元组不处理中文），通配符按字面匹配
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var results []SearchResult
+		return searchQuery(tx, "保温杯 50%_off").Find(&results)
+	})
+	for _, want := range []string{
+		`deleted_at IS NULL`,
+		`search_vector @@ websearch_to_tsquery('simple', '保温杯 50%_off')`,
+		`'保温杯 50%_off' <% search_text`,
+		`(search_text ILIKE '%保温杯%' AND search_text ILIKE '%50\%\_off%')`,
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("searchQuery() = %s, 期望包含 %s", sql, want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	result := &SearchResult{Product: Product{Name: "不锈钢保温杯", SKU: "CUP-01", Remark: "<b>保温</b> 12h"}}
+	highlight(result, "保温 cup")
+	want := map[string]string{
+		"name":   "不锈钢<em>保温</em>杯",
+		"sku":    "<em>CUP</em>-01",
+		"remark": "&lt;b&gt;<em>保温</em>&lt;/b&gt; 12h",
+	}
+	for key, value := range want {
+		if result.Highlights[key] != value {
+			t.Errorf("highlight() %s = %q, 期望 %q", key, result.Highlights[key], value)
+		}
+	}
+}
+
+// recordingConn 记录执行的语句而不连接数据库
+type recordingConn struct {
+	statements []string
+}
+
+func (c *recordingConn) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, _ ...interface{}) (sql.Result, error) {
+	c.statements = append(c.statements, query)
+	return driverResult{}, nil
+}
+
+func (c *recordingConn) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (c *recordingConn) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (c *recordingConn) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &recordingTx{c}, nil
+}
+
+// recordingTx 事务中的 recordingConn
+type recordingTx struct {
+	*recordingConn
+}
+
+func (recordingTx) Commit() error   { return nil }
+func (recordingTx) Rollback() error { return nil }
+
+// driverResult 空的执行结果
+type driverResult struct{}
+
+func (driverResult) LastInsertId() (int64, error) { return 0, nil }
+func (driverResult) RowsAffected() (int64, error) { return 0, nil }
+
+func TestReindex(t *testing.T) {
+	for _, rebuild := range []bool{false, true} {
+		conn := &recordingConn{}
+		db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Discard})
+		if err != nil {
+			t.Fatalf("gorm.Open() 错误 = %v", err)
+		}
+		if err := Reindex(context.Background(), db, rebuild); err != nil {
+			t.Fatalf("Reindex(%v) 错误 = %v", rebuild, err)
+		}
+
+		executed := strings.Join(conn.statements, "\n")
+		// 只处理搜索列与索引，不执行 SKU 索引迁移
+		if strings.Contains(executed, "idx_products_sku") {
+			t.Errorf("Reindex(%v) 执行了 SKU 索引迁移:\n%s", rebuild, executed)
+		}
+		for _, want := range []string{"search_vector tsvector GENERATED", "REINDEX INDEX idx_products_search_text_trgm", "ANALYZE products"} {
+			if !strings.Contains(executed, want) {
+				t.Errorf("Reindex(%v) 未执行 %s", rebuild, want)
+			}
+		}
+		if dropped := strings.Contains(executed, "DROP COLUMN IF EXISTS search_vector"); dropped != rebuild {
+			t.Errorf("Reindex(%v) 删除搜索列 = %v", rebuild, dropped)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package product
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/resource"
+)
+
+// Service 商品业务逻辑，供 HTTP、gRPC 与后台任务共用，增删改查、切换状态与回收站等通用操作由 resource.Resource 提供
+// 返回的错误均为 *errcode.Error（补丁、绑定等回调返回的非业务错误除外），由调用方按各自协议输出
+type Service struct {
+	*resource.Resource[Product]
+	repo Repository
+}
+
+// NewService 创建商品服务
+func NewService(repo Repository, tx database.Transactor, publisher events.Publisher) *Service {
+	s := &Service{repo: repo}
+	s.Resource = resource.New[Product](repo, tx, publisher, resource.Config[Product]{
+		Errors:      errcode.Product,
+		List:        listConfig,
+		Filters:     listFilters,
+		Legacy:      []string{"supplier_id", "category_id", "type", "is_enabled"},
+		PatchFields: patchFields,
+		Hooks: resource.Hooks[Product]{
+			Authorize: authorize,
+			Validate:  s.validate,
+			Changes:   updated,
+		},
+	})
+	return s
+}
+
+// SupplierUserType 供应商用户的类型，只能修改所属供应商的商品，实时推送等按所属供应商过滤数据的模块同样据此判断
+const SupplierUserType = "供应商"
+
+// authorize 供应商用户只能修改所属供应商的商品，查询不受限制
+func authorize(ctx context.Context, action resource.Action, product *Product) error {
+	if action == resource.ActionList || action == resource.ActionGet || action == resource.ActionTrash {
+		return nil
+	}
+	caller, ok := middleware.CallerFromContext(ctx)
+	if ok && caller.UserType == SupplierUserType && (product == nil || product.SupplierID != caller.SupplierID) {
+		return errcode.Forbidden.New()
+	}
+	return nil
+}
+
+// Export 按主键顺序分批读取符合筛选条件的商品，每批调用一次 fn，fn 返回错误时停止
+func (s *Service) Export(ctx context.Context, query url.Values, batchSize int, fn func([]Product) error) error {
+	conditions, err := s.Conditions(query)
+	if err != nil {
+		return err
+	}
+
+	var fnErr error
+	err = s.repo.Export(ctx, conditions, batchSize, func(products []Product) error {
+		fnErr = fn(products)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return errcode.FromDB(err, errcode.Product.ListFailed)
+	}
+	return nil
+}
+
+// Search 按关键词搜索商品，query 与 REST 搜索接口的查询参数一致，结果带有高亮片段
+// 结果固定按相关度排序并按页码分页，传入 cursor 或 sort 时返回错误，而不是静默忽略
+func (s *Service) Search(ctx context.Context, query url.Values) (*pagination.Page, error) {
+	keyword := strings.TrimSpace(query.Get("q"))
+	if keyword == "" {
+		return nil, errcode.SearchKeywordRequired.New()
+	}
+	if query.Get("cursor") != "" {
+		return nil, errcode.InvalidPagination.New("cursor")
+	}
+	if sort := query.Get("sort"); sort != "" {
+		return nil, errcode.InvalidSort.New(sort)
+	}
+
+	params, err := pagination.ParseValues(query, pagination.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := filter.ParseValues(query, listFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	// 支持按供应商ID、分类ID筛选
+	conditions = append(filter.Legacy(query, "supplier_id", "category_id"), conditions...)
+
+	results, total, err := s.repo.Search(ctx, keyword, conditions, params)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.ProductSearchFailed)
+	}
+	for i := range results {
+		highlight(&results[i], keyword)
+	}
+
+	return &pagination.Page{
+		Items:    results,
+		Total:    total,
+		Page:     params.Page,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// UpdateStock 更新库存
+func (s *Service) UpdateStock(ctx context.Context, id uint, stock int) (*Product, error) {
+	return s.Change(ctx, id, resource.ActionUpdate, errcode.ProductStockUpdateFailed, stockAdjusted, func(product *Product) (map[string]interface{}, error) {
+		return patch.Merge(ctx, product, patchFields, map[string]interface{}{"stock": stock})
+	})
+}
+
+// UpdatePrice 更新价格
+func (s *Service) UpdatePrice(ctx context.Context, id uint, price float64) (*Product, error) {
+	return s.Change(ctx, id, resource.ActionUpdate, errcode.ProductPriceUpdateFailed, priceChanged, func(product *Product) (map[string]interface{}, error) {
+		return patch.Merge(ctx, product, patchFields, map[string]interface{}{"price": price})
+	})
+}
@@ -0,0 +1,273 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/repository"
+	"erp_backend/pkg/settings"
+)
+
+// memoryRepository 基于内存的商品仓储，搜索依赖 PostgreSQL 全文检索，不在内存中实现
+type memoryRepository struct {
+	*repository.Memory[Product]
+}
+
+// Export 实现 Repository 接口，忽略筛选条件
+func (r memoryRepository) Export(_ context.Context, _ filter.Conditions, batchSize int, fn func([]Product) error) error {
+	products := r.Find(nil)
+	for start := 0; start < len(products); start += batchSize {
+		if err := fn(products[start:min(start+batchSize, len(products))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search 实现 Repository 接口
+func (r memoryRepository) Search(context.Context, string, filter.Conditions, *pagination.Params) ([]SearchResult, int64, error) {
+	return nil, 0, errors.New("search is not supported in memory")
+}
+
+// newTestService 创建使用内存仓储的商品服务，返回记录已发布事件的发布器
+func newTestService(products ...Product) (*Service, *events.Recorder) {
+	recorder := &events.Recorder{}
+	repo := memoryRepository{Memory: repository.NewMemory(products...)}
+	return NewService(repo, database.NopTransactor{}, recorder), recorder
+}
+
+// eventNames 返回已发布事件的名称
+func eventNames(recorder *events.Recorder) []string {
+	names := []string{}
+	for _, e := range recorder.Events() {
+		names = append(names, e.EventName())
+	}
+	return names
+}
+
+func TestServiceUpdateStock(t *testing.T) {
+	ctx := context.Background()
+	s, recorder := newTestService(Product{Name: "a", SKU: "A-1", SupplierID: 3, Stock: 10})
+
+	product, err := s.UpdateStock(ctx, 1, 4)
+	if err != nil {
+		t.Fatalf("UpdateStock() 错误 = %v", err)
+	}
+	if product.Stock != 4 {
+		t.Errorf("UpdateStock() 库存 = %d, 期望 4", product.Stock)
+	}
+
+	published := recorder.Events()
+	if len(published) != 1 {
+		t.Fatalf("发布的事件 = %v, 期望只有 product.stock_changed", eventNames(recorder))
+	}
+	adjusted, ok := published[0].(StockAdjusted)
+	if !ok || adjusted.ProductID != 1 || adjusted.Before != 10 || adjusted.After != 4 || adjusted.Product.Stock != 4 {
+		t.Errorf("发布的事件 = %+v, 期望库存从 10 调整为 4", published[0])
+	}
+
+	// 库存未变化时不写入也不发布事件
+	if _, err := s.UpdateStock(ctx, 1, 4); err != nil {
+		t.Fatalf("UpdateStock() 错误 = %v", err)
+	}
+	if n := len(recorder.Events()); n != 1 {
+		t.Errorf("库存未变化时发布了 %d 个事件, 期望 1", n)
+	}
+}
+
+func TestServiceUpdateStockNotFound(t *testing.T) {
+	s, recorder := newTestService()
+	_, err := s.UpdateStock(context.Background(), 1, 4)
+	var e *errcode.Error
+	if !errors.As(err, &e) || e.Code != errcode.Product.NotFound {
+		t.Errorf("UpdateStock() 错误 = %v, 期望 %s", err, errcode.Product.NotFound.Key)
+	}
+	if len(recorder.Events()) != 0 {
+		t.Errorf("发布的事件 = %v, 期望没有事件", eventNames(recorder))
+	}
+}
+
+func TestServiceSearchRejectsCursorAndSort(t *testing.T) {
+	s, _ := newTestService()
+	tests := []struct {
+		query url.Values
+		code  errcode.Code
+	}{
+		{url.Values{"q": {"杯"}, "cursor": {"abc"}}, errcode.InvalidPagination},
+		{url.Values{"q": {"杯"}, "sort": {"-price"}}, errcode.InvalidSort},
+	}
+	for _, tt := range tests {
+		_, err := s.Search(context.Background(), tt.query)
+		var e *errcode.Error
+		if !errors.As(err, &e) || e.Code != tt.code {
+			t.Errorf("Search(%v) 错误 = %v, 期望 %s", tt.query, err, tt.code.Key)
+		}
+	}
+}
+
+func TestServiceUpdatePriceLocale(t *testing.T) {
+	s, _ := newTestService(Product{Name: "a", SKU: "A-1", SupplierID: 3, Price: 5})
+	_, err := s.UpdatePrice(i18n.WithLocale(context.Background(), i18n.EnUS), 1, -1)
+	var e *errcode.Error
+	if !errors.As(err, &e) || len(e.Details) != 1 || e.Details[0].Message != "must be at least 0 (length or value)" {
+		t.Errorf("UpdatePrice() 错误 = %+v, 期望英文的字段校验提示", err)
+	}
+}
+
+func TestServiceSupplierScope(t *testing.T) {
+	s, recorder := newTestService(Product{Name: "a", SKU: "A-1", SupplierID: 3, Stock: 10, Price: 5})
+	own := middleware.WithCaller(context.Background(), &middleware.Caller{UserID: 7, UserType: "供应商", SupplierID: 3})
+	other := middleware.WithCaller(context.Background(), &middleware.Caller{UserID: 8, UserType: "供应商", SupplierID: 4})
+
+	var e *errcode.Error
+	if _, err := s.UpdateStock(other, 1, 4); !errors.As(err, &e) || e.Code != errcode.Forbidden {
+		t.Errorf("其他供应商 UpdateStock() 错误 = %v, 期望 %s", err, errcode.Forbidden.Key)
+	}
+	if _, err := s.UpdatePrice(other, 1, 9); !errors.As(err, &e) || e.Code != errcode.Forbidden {
+		t.Errorf("其他供应商 UpdatePrice() 错误 = %v, 期望 %s", err, errcode.Forbidden.Key)
+	}
+	if len(recorder.Events()) != 0 {
+		t.Errorf("发布的事件 = %v, 期望没有事件", eventNames(recorder))
+	}
+
+	// 所属供应商与员工可以修改，查询不受限制
+	if _, err := s.UpdateStock(own, 1, 4); err != nil {
+		t.Errorf("所属供应商 UpdateStock() 错误 = %v", err)
+	}
+	staff := middleware.WithCaller(context.Background(), &middleware.Caller{UserID: 9, UserType: "员工"})
+	if _, err := s.UpdatePrice(staff, 1, 9); err != nil {
+		t.Errorf("员工 UpdatePrice() 错误 = %v", err)
+	}
+	if _, err := s.Get(other, 1); err != nil {
+		t.Errorf("其他供应商 Get() 错误 = %v", err)
+	}
+
+	// 恢复时按回收站中的记录授权
+	if err := s.Delete(staff, 1); err != nil {
+		t.Fatalf("Delete() 错误 = %v", err)
+	}
+	if _, err := s.Restore(other, 1); !errors.As(err, &e) || e.Code != errcode.Forbidden {
+		t.Errorf("其他供应商 Restore() 错误 = %v, 期望 %s", err, errcode.Forbidden.Key)
+	}
+	if _, err := s.Restore(own, 1); err != nil {
+		t.Errorf("所属供应商 Restore() 错误 = %v", err)
+	}
+}
+
+func TestServiceUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(*Product)
+		want   []string
+	}{
+		{"只修改名称", func(p *Product) { p.Name = "b" }, []string{"product.updated"}},
+		{"修改库存与价格", func(p *Product) { p.Stock, p.Price = 1, 9.9 }, []string{"product.updated", "product.stock_changed", "product.price_changed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, recorder := newTestService(Product{Name: "a", SKU: "A-1", Stock: 10, Price: 5})
+			_, err := s.Update(context.Background(), 1, func(p *Product) error {
+				tt.update(p)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Update() 错误 = %v", err)
+			}
+			if got := eventNames(recorder); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("发布的事件 = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceDelete(t *testing.T) {
+	ctx := context.Background()
+	s, recorder := newTestService(Product{Name: "a", SKU: "A-1", SupplierID: 3})
+
+	if err := s.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() 错误 = %v", err)
+	}
+	published := recorder.Events()
+	if len(published) != 1 {
+		t.Fatalf("发布的事件 = %v, 期望只有 product.deleted", eventNames(recorder))
+	}
+	deleted, ok := published[0].(ProductDeleted)
+	if !ok || deleted.ID != 1 || deleted.Owners["supplier_id"] != 3 {
+		t.Errorf("发布的事件 = %+v, 期望携带供应商ID 3", published[0])
+	}
+
+	if _, err := s.Get(ctx, 1); err == nil {
+		t.Error("Get() 已删除的商品期望返回错误")
+	}
+	if _, err := s.Restore(ctx, 1); err != nil {
+		t.Fatalf("Restore() 错误 = %v", err)
+	}
+	if got := eventNames(recorder); !reflect.DeepEqual(got, []string{"product.deleted", "product.restored"}) {
+		t.Errorf("发布的事件 = %v, 期望 [product.deleted product.restored]", got)
+	}
+}
+
+func TestServiceCreateIgnoresManagedFields(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(Product{Name: "a", SKU: "A-1", SupplierID: 3})
+
+	// 请求中携带的主键与删除时间不能覆盖已有商品或直接创建到回收站中
+	product := &Product{ID: 1, Name: "b", SKU: "B-1", SupplierID: 3, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	if err := s.Create(ctx, product); err != nil {
+		t.Fatalf("Create() 错误 = %v", err)
+	}
+	if product.ID != 2 || product.DeletedAt.Valid {
+		t.Errorf("Create() = (主键 %d, 删除时间 %v), 期望主键 2 且未删除", product.ID, product.DeletedAt)
+	}
+	if original, err := s.Get(ctx, 1); err != nil || original.Name != "a" {
+		t.Errorf("Get() 原商品 = (%+v, %v), 期望未被覆盖", original, err)
+	}
+}
+
+// useDefaultSettings 设置全局设置存储，数据库不可达，读取时回退为商品模块声明的默认值
+func useDefaultSettings(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 connect_timeout=1"}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+	store := settings.NewStore(db, &config.SettingsConfig{CacheTTL: time.Hour})
+	store.Declare(Settings()...)
+	settings.SetStore(store)
+	t.Cleanup(func() { settings.SetStore(nil) })
+}
+
+func TestServiceCreateDefaultCurrency(t *testing.T) {
+	useDefaultSettings(t)
+	ctx := context.Background()
+	s, _ := newTestService()
+
+	// 未指定币种时使用 product.default_currency，指定时保持不变
+	for _, tt := range []struct{ currency, want string }{{"", "CNY"}, {"USD", "USD"}} {
+		product := &Product{Name: "a", SKU: "A-" + tt.want, Currency: tt.currency}
+		if err := s.Create(ctx, product); err != nil {
+			t.Fatalf("Create() 错误 = %v", err)
+		}
+		if product.Currency != tt.want {
+			t.Errorf("Create() 币种 %q = %q, 期望 %q", tt.currency, product.Currency, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package product
+
+import (
+	"context"
+	"regexp"
+	"sync/atomic"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/settings"
+)
+
+// 商品模块的设置键
+const (
+	SettingLowStockThreshold = "product.low_stock_threshold"
+	SettingSKUPattern        = "product.sku_pattern"
+	SettingDefaultCurrency   = "product.default_currency"
+)
+
+// Settings 商品模块声明的设置项，低库存阈值的默认值取自 METRICS_LOW_STOCK_THRESHOLD
+func Settings() []settings.Definition {
+	return []settings.Definition{
+		{
+			Key:         SettingLowStockThreshold,
+			Description: "低库存阈值，库存小于等于该值的启用商品计入低库存指标与低库存检查",
+			Schema:      &settings.Schema{Type: "integer", Minimum: settings.Float(0)},
+			Default:     config.GetMetricsConfig().LowStockThreshold,
+		},
+		{
+			Key:         SettingSKUPattern,
+			Description: "商品SKU需匹配的正则表达式，为空表示不限制；只检查新建的商品与修改了SKU的商品",
+			Schema:      &settings.Schema{Type: "string", Format: "regex", MaxLength: settings.Int(200)},
+			Default:     "",
+		},
+		{
+			Key:         SettingDefaultCurrency,
+			Description: "商品价格的默认币种，ISO 4217 代码，用于创建时未指定币种的商品",
+			Schema:      &settings.Schema{Type: "string", Pattern: "^[A-Z]{3}$"},
+			Default:     "CNY",
+		},
+	}
+}
+
+// validate 写入前为未指定币种的商品补全 product.default_currency，并检查SKU格式
+func (s *Service) validate(ctx context.Context, p *Product) error {
+	if p.Currency == "" {
+		p.Currency = settings.Get[string](ctx, SettingDefaultCurrency)
+	}
+	return s.validateSKU(ctx, p)
+}
+
+// skuPattern 编译后的 SKU 格式，设置修改后按新的表达式重新编译
+type skuPattern struct {
+	expr string
+	re   *regexp.Regexp
+}
+
+// currentSKUPattern 最近一次使用的 SKU 格式
+var currentSKUPattern atomic.Pointer[skuPattern]
+
+// validateSKU 新建商品或修改了SKU时检查SKU是否符合 product.sku_pattern
+func (s *Service) validateSKU(ctx context.Context, p *Product) error {
+	expr := settings.Get[string](ctx, SettingSKUPattern)
+	if expr == "" {
+		return nil
+	}
+	if p.ID != 0 {
+		if current, err := s.repo.Get(ctx, p.ID); err == nil && current.SKU == p.SKU {
+			return nil
+		}
+	}
+
+	pattern := currentSKUPattern.Load()
+	if pattern == nil || pattern.expr != expr {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			// 设置值已按 Schema 校验过，不会发生
+			return nil
+		}
+		pattern = &skuPattern{expr: expr, re: re}
+		currentSKUPattern.Store(pattern)
+	}
+	if !pattern.re.MatchString(p.SKU) {
+		return errcode.ProductSKUInvalid.New(expr)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package settings
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/response"
+	kv "erp_backend/pkg/settings"
+)
+
+// historyConfig 修改记录排序配置
+var historyConfig = pagination.Config{
+	SortFields:  []string{"id", "created_at"},
+	DefaultSort: "-id",
+}
+
+// historyFilters 修改记录筛选字段
+var historyFilters = filter.Fields{
+	"key":        filter.String,
+	"action":     filter.String,
+	"user_id":    filter.Number,
+	"created_at": filter.Time,
+}
+
+type Handler struct {
+	db    *gorm.DB
+	store *kv.Store
+}
+
+func NewHandler(db *gorm.DB, store *kv.Store) *Handler {
+	return &Handler{db: db, store: store}
+}
+
+// List 获取设置列表
+// @Summary 获取系统设置列表
+// @Description 获取各模块声明的设置项、JSON Schema、默认值与当前值，按设置键排序
+// @Tags 系统设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param namespace query string false "命名空间，如 product"
+// @Success 200 {object} response.Response{data=[]kv.Item} "获取成功"
+// @Router /settings [get]
+func (h *Handler) List(c *gin.Context) {
+	response.Success(c, h.store.List(c, c.Query("namespace")))
+}
+
+// Get 获取单个设置
+// @Summary 获取系统设置
+// @Description 根据设置键获取设置项及当前值
+// @Tags 系统设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key path string true "设置键，如 product.low_stock_threshold"
+// @Success 200 {object} response.Response{data=kv.Item} "获取成功"
+// @Failure 404 {object} response.Response "设置项不存在"
+// @Router /settings/{key} [get]
+func (h *Handler) Get(c *gin.Context) {
+	item, err := h.store.Get(c, c.Param("key"))
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, item)
+}
+
+// Update 修改设置
+// @Summary 修改系统设置
+// @Description 修改设置值并记录修改人，值需符合设置项的 JSON Schema；修改后所有实例立即生效
+// @Tags 系统设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key path string true "设置键，如 product.low_stock_threshold"
+// @Param setting body UpdateRequest true "新的值"
+// @Success 200 {object} response.Response{data=kv.Item} "修改成功"
+// @Failure 400 {object} response.Response "请求参数错误或值不符合 JSON Schema"
+// @Failure 404 {object} response.Response "设置项不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /settings/{key} [put]
+func (h *Handler) Update(c *gin.Context) {
+	var req UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	item, err := h.store.Set(c, c.Param("key"), req.Value, currentUser(c))
+	if err != nil {
+		response.DBError(c, err, errcode.Setting.UpdateFailed)
+		return
+	}
+
+	response.Success(c, item)
+}
+
+// Reset 恢复默认值
+// @Summary 恢复系统设置的默认值
+// @Description 删除修改过的值，恢复为模块声明的默认值并记录修改人
+// @Tags 系统设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key path string true "设置键，如 product.low_stock_threshold"
+// @Success 200 {object} response.Response{data=kv.Item} "已恢复默认值"
+// @Failure 404 {object} response.Response "设置项不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /settings/{key} [delete]
+func (h *Handler) Reset(c *gin.Context) {
+	item, err := h.store.Reset(c, c.Param("key"), currentUser(c))
+	if err != nil {
+		response.DBError(c, err, errcode.Setting.UpdateFailed)
+		return
+	}
+
+	response.Success(c, item)
+}
+
+// History 获取修改记录
+// @Summary 获取系统设置修改记录
+// @Description 获取设置的修改记录，包括修改前后的值与修改人，可按设置键、操作类型（update、reset）、修改人筛选
+// @Tags 系统设置
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[key]=user.password_policy"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]kv.Change}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /settings/history [get]
+func (h *Handler) History(c *gin.Context) {
+	params, err := pagination.Parse(c, historyConfig)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	conditions, err := filter.Parse(c, historyFilters)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	var changes []kv.Change
+	page, err := params.Find(conditions.Apply(h.db.WithContext(c).Model(&kv.Change{})), &changes)
+	if err != nil {
+		response.DBError(c, err, errcode.Setting.ListFailed)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// currentUser 当前登录用户的ID
+func currentUser(c *gin.Context) *uint {
+	if id, ok := c.Value("user_id").(uint); ok {
+		return &id
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package settings
+
+import "encoding/json"
+
+// UpdateRequest 修改设置的请求参数
+// @Description 修改系统设置的请求参数
+type UpdateRequest struct {
+	Value json.RawMessage `json:"value" binding:"required" swaggertype:"object"` // 新的值，需符合设置项的 JSON Schema
+}
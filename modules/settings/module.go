@@ -0,0 +1,29 @@
+package settings
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 系统设置管理模块：查看、修改设置与修改记录
+// 设置表由 main 统一迁移，设置项由各模块声明，关闭本模块后设置值保持不变
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "settings" }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app) }
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "settings.manage", Description: "查看与修改系统设置", UserTypes: module.Admins},
+	}
+}
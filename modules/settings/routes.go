@@ -0,0 +1,21 @@
+package settings
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+// RegisterRoutes 注册系统设置相关路由，仅管理员可访问
+func RegisterRoutes(r *middleware.Router, app *module.App) {
+	handler := NewHandler(app.DB, app.Settings)
+
+	manage := module.RequirePermission("settings.manage")
+	settings := r.Group("/settings")
+	{
+		settings.GET("", manage, handler.List)
+		settings.GET("/history", manage, handler.History)
+		settings.GET("/:key", manage, handler.Get)
+		settings.PUT("/:key", manage, handler.Update)
+		settings.DELETE("/:key", manage, handler.Reset)
+	}
+}
@@ -0,0 +1,26 @@
+package shop
+
+import "erp_backend/pkg/events"
+
+// AggregateType 实现 events.Aggregate 接口
+func (Shop) AggregateType() string { return "shop" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (s Shop) AggregateID() uint { return s.ID }
+
+// Owners 实现 events.Owned 接口，删除事件携带所属供应商ID
+func (s Shop) Owners() map[string]uint { return map[string]uint{"supplier_id": s.SupplierID} }
+
+// 店铺领域事件
+type (
+	ShopCreated  = events.Created[Shop]  // 店铺创建
+	ShopUpdated  = events.Updated[Shop]  // 店铺更新
+	ShopToggled  = events.Toggled[Shop]  // 店铺启用状态切换
+	ShopDeleted  = events.Deleted[Shop]  // 店铺删除（移入回收站）
+	ShopRestored = events.Restored[Shop] // 店铺从回收站恢复
+)
+
+// Events 实现 module.EventsModule 接口
+func (Module) Events() []events.Event {
+	return []events.Event{ShopCreated{}, ShopUpdated{}, ShopToggled{}, ShopDeleted{}, ShopRestored{}}
+}
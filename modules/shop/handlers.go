@@ -1,21 +1,45 @@
 package shop
 
 import (
-	"net/http"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
-	"erp_backend/pkg/response"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/resource"
 )
 
+// listConfig 店铺列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "supplier_id", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 店铺列表筛选字段
+var listFilters = filter.Fields{
+	"id":          filter.Number,
+	"supplier_id": filter.Number,
+	"name":        filter.String,
+	"remark":      filter.String,
+	"is_enabled":  filter.Bool,
+	"created_at":  filter.Time,
+	"updated_at":  filter.Time,
+}
+
+// patchFields 店铺允许通过 PATCH 修改的字段及校验规则
+var patchFields = patch.Fields{
+	"supplier_id": "required",
+	"name":        "required,max=100",
+	"remark":      "",
+	"is_enabled":  "",
+}
+
 type Handler struct {
-	db *gorm.DB
+	resource *resource.Handler[Shop]
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(service *Service) *Handler {
+	return &Handler{resource: resource.NewHandler(service.Resource)}
 }
 
 // Create 创建店铺
@@ -24,57 +48,65 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Tags 店铺管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param shop body Shop true "店铺信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Shop} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /shops [post]
 func (h *Handler) Create(c *gin.Context) {
-	var shop Shop
-	if err := c.ShouldBindJSON(&shop); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Create(&shop).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建店铺失败")
-		return
-	}
-
-	response.Success(c, shop)
+	h.resource.Create(c)
 }
 
 // List 获取店铺列表
 // @Summary 获取店铺列表
-// @Description 获取所有店铺的列表
+// @Description 分页获取店铺列表，回收站中的店铺不包含在内
 // @Tags 店铺管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param supplier_id query string false "供应商ID"
 // @Param is_enabled query string false "是否启用"
-// @Success 200 {object} response.Response{data=[]Shop} "获取成功"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Shop}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /shops [get]
 func (h *Handler) List(c *gin.Context) {
-	var shops []Shop
-	query := h.db.Model(&Shop{})
-
-	// 支持按供应商ID筛选
-	if supplierID := c.Query("supplier_id"); supplierID != "" {
-		query = query.Where("supplier_id = ?", supplierID)
-	}
-
-	// 支持按启用状态筛选
-	if isEnabled := c.Query("is_enabled"); isEnabled != "" {
-		query = query.Where("is_enabled = ?", isEnabled)
-	}
-
-	if err := query.Find(&shops).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取店铺列表失败")
-		return
-	}
+	h.resource.List(c)
+}
 
-	response.Success(c, shops)
+// Trash 获取回收站中的店铺
+// @Summary 获取回收站中的店铺
+// @Description 分页获取已删除的店铺，查询参数同列表接口
+// @Tags 店铺管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param supplier_id query string false "供应商ID"
+// @Param is_enabled query string false "是否启用"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Shop}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /shops/trash [get]
+func (h *Handler) Trash(c *gin.Context) {
+	h.resource.Trash(c)
 }
 
 // Get 获取单个店铺
@@ -83,90 +115,97 @@ func (h *Handler) List(c *gin.Context) {
 // @Tags 店铺管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "店铺ID"
 // @Success 200 {object} response.Response{data=Shop} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "店铺不存在"
 // @Router /shops/{id} [get]
 func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var shop Shop
-	if err := h.db.First(&shop, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "店铺不存在")
-		return
-	}
-
-	response.Success(c, shop)
+	h.resource.Get(c)
 }
 
 // Update 更新店铺
 // @Summary 更新店铺
-// @Description 更新店铺信息
+// @Description 全量更新店铺信息，主键与创建时间不可修改
 // @Tags 店铺管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "店铺ID"
 // @Param shop body Shop true "店铺信息"
 // @Success 200 {object} response.Response{data=Shop} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "店铺不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /shops/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var shop Shop
-	if err := h.db.First(&shop, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "店铺不存在")
-		return
-	}
-
-	if err := c.ShouldBindJSON(&shop); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Save(&shop).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新店铺失败")
-		return
-	}
-
-	response.Success(c, shop)
+	h.resource.Update(c)
+}
+
+// Patch 部分更新店铺
+// @Summary 部分更新店铺
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改店铺，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 店铺管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "店铺ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Shop} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "店铺不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /shops/{id} [patch]
+func (h *Handler) Patch(c *gin.Context) {
+	h.resource.Patch(c)
 }
 
 // Delete 删除店铺
 // @Summary 删除店铺
-// @Description 删除店铺
+// @Description 将店铺移入回收站，可通过恢复接口恢复；店铺不存在时同样返回成功
 // @Tags 店铺管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "店铺ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /shops/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	if err := h.db.Delete(&Shop{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除店铺失败")
-		return
-	}
-
-	response.Success(c, gin.H{"message": "删除成功"})
+	h.resource.Delete(c)
+}
+
+// Restore 恢复店铺
+// @Summary 恢复店铺
+// @Description 将回收站中的店铺恢复
+// @Tags 店铺管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "店铺ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Shop} "恢复成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "店铺不存在或不在回收站中"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /shops/{id}/restore [post]
+func (h *Handler) Restore(c *gin.Context) {
+	h.resource.Restore(c)
 }
 
 // ToggleStatus 切换店铺状态
@@ -175,30 +214,16 @@ func (h *Handler) Delete(c *gin.Context) {
 // @Tags 店铺管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "店铺ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Shop} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "店铺不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /shops/{id}/toggle [patch]
 func (h *Handler) ToggleStatus(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var shop Shop
-	if err := h.db.First(&shop, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "店铺不存在")
-		return
-	}
-
-	shop.IsEnabled = !shop.IsEnabled
-	if err := h.db.Save(&shop).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新状态失败")
-		return
-	}
-
-	response.Success(c, shop)
+	h.resource.Toggle(c)
 }
@@ -0,0 +1,41 @@
+package shop
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 店铺管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "shop" }
+
+// DependsOn 实现 module.Module 接口
+func (Module) DependsOn() []string { return []string{"supplier"} }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&Shop{}} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// Jobs 实现 module.Module 接口，定期永久删除回收站中过期的店铺
+func (Module) Jobs(app *module.App) []module.Job {
+	app.Queue.PurgeTrash("shop", &Shop{})
+	return nil
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "shop.read", Description: "查看店铺"},
+		{Code: "shop.write", Description: "创建、修改与删除店铺", UserTypes: module.Staff},
+	}
+}
@@ -0,0 +1,17 @@
+package shop
+
+import (
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/repository"
+)
+
+// Repository 店铺数据访问接口
+type Repository interface {
+	repository.Trashable[Shop]
+}
+
+// NewRepository 创建基于 GORM 的店铺仓储
+func NewRepository(db *gorm.DB) Repository {
+	return repository.NewGorm[Shop](db)
+}
@@ -1,21 +1,29 @@
 package shop
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
 )
 
 // RegisterRoutes 注册店铺相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db)))
 
+	read, write := module.RequirePermission("shop.read"), module.RequirePermission("shop.write")
 	shops := r.Group("/shops")
 	{
-		shops.POST("", handler.Create)
-		shops.GET("", handler.List)
-		shops.GET("/:id", handler.Get)
-		shops.PUT("/:id", handler.Update)
-		shops.DELETE("/:id", handler.Delete)
-		shops.PATCH("/:id/toggle", handler.ToggleStatus)
+		shops.POST("", write, handler.Create)
+		shops.GET("", read, handler.List)
+		shops.GET("/trash", read, handler.Trash)
+		shops.GET("/:id", read, handler.Get)
+		shops.PUT("/:id", write, handler.Update)
+		shops.PATCH("/:id", write, handler.Patch)
+		shops.DELETE("/:id", write, handler.Delete)
+		shops.POST("/:id/restore", write, handler.Restore)
+		shops.PATCH("/:id/toggle", write, handler.ToggleStatus)
 	}
 }
@@ -0,0 +1,24 @@
+package shop
+
+import (
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/resource"
+)
+
+// Service 店铺业务逻辑，供 HTTP 接口、命令行与后台任务共用，增删改查、切换状态与回收站等通用操作由 resource.Resource 提供
+type Service struct {
+	*resource.Resource[Shop]
+}
+
+// NewService 创建店铺服务
+func NewService(repo Repository, tx database.Transactor, publisher events.Publisher) *Service {
+	return &Service{Resource: resource.New[Shop](repo, tx, publisher, resource.Config[Shop]{
+		Errors:      errcode.Shop,
+		List:        listConfig,
+		Filters:     listFilters,
+		Legacy:      []string{"supplier_id", "is_enabled"},
+		PatchFields: patchFields,
+	})}
+}
@@ -0,0 +1,370 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"erp_backend/modules/product"
+	"erp_backend/modules/user"
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/response"
+	live "erp_backend/pkg/stream"
+)
+
+// sseRetry 建议 EventSource 断线后的重连间隔（毫秒）
+const sseRetry = 3000
+
+type Handler struct {
+	users *user.Service
+	cfg   *config.StreamConfig
+}
+
+func NewHandler(users *user.Service) *Handler {
+	return &Handler{users: users, cfg: config.GetStreamConfig()}
+}
+
+// SSE 通过 Server-Sent Events 接收变更事件
+// @Summary 实时推送（SSE）
+// @Description 以 text/event-stream 推送订阅主题内的变更事件，每条事件的 data 为 JSON 格式的 Event，id 为事件序号。
+// @Description 主题格式：*（全部）、资源:*、资源:ID、资源:关联资源:ID，如 products:*、products:123、links:shop:5；不传 topics 时订阅全部。
+// @Description 断线重连时携带 Last-Event-ID 请求头（EventSource 会自动携带）或 last_event_id 参数，补发缓冲中此后的事件；
+// @Description 该事件已超出缓冲时先发送 reset 事件，客户端应重新加载数据。客户端接收过慢或服务关闭时发送 error 事件后断开。
+// @Description 供应商类型的用户只能接收所属供应商的数据以及分类、属性等公共数据。
+// @Tags 实时推送
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param topics query string false "订阅主题，逗号分隔" example(products:*,links:shop:5)
+// @Param Last-Event-ID header string false "最后收到的事件序号"
+// @Param last_event_id query string false "最后收到的事件序号，无法设置请求头时使用"
+// @Param access_token query string false "访问令牌，无法设置 Authorization 请求头时使用"
+// @Success 200 {object} live.Event "事件流"
+// @Failure 400 {object} response.Response "订阅主题无效"
+// @Failure 401 {object} response.Response "未登录"
+// @Failure 403 {object} response.Response "无权订阅"
+// @Failure 503 {object} response.Response "实时推送未启用"
+// @Router /stream [get]
+func (h *Handler) SSE(c *gin.Context) {
+	raw := splitTopics(c.Query("topics"))
+	if len(raw) == 0 {
+		raw = []string{"*"}
+	}
+	sub, replay, reset, ok := h.subscribe(c, "sse", raw)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	// 长连接不受服务端读写超时限制，改为按条设置写超时
+	rc := http.NewResponseController(c.Writer)
+	rc.SetReadDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	write := func(format string, args ...interface{}) error {
+		rc.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+		if _, err := fmt.Fprintf(c.Writer, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	writeEvent := func(e *live.Event) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		return write("id: %d\ndata: %s\n\n", e.ID, data)
+	}
+	writeError := func(event string, code errcode.Code) error {
+		data, err := json.Marshal(response.ErrorResponse(c, code))
+		if err != nil {
+			return err
+		}
+		return write("event: %s\ndata: %s\n\n", event, data)
+	}
+
+	if err := write("retry: %d\n\n", sseRetry); err != nil {
+		return
+	}
+	if reset {
+		if err := writeError(FrameReset, errcode.StreamReset); err != nil {
+			return
+		}
+	}
+	for _, e := range replay {
+		if err := writeEvent(e); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.cfg.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				writeError(FrameError, sub.Reason())
+				return
+			}
+			err = writeEvent(e)
+		case <-heartbeat.C:
+			err = write(": ping\n\n")
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// WebSocket 通过 WebSocket 接收变更事件
+// @Summary 实时推送（WebSocket）
+// @Description 升级为 WebSocket 连接后，服务端以 JSON 文本消息发送 Frame：event 为变更事件，reset 表示断线期间的事件已超出回放范围，
+// @Description error 为错误（连接被断开前也会发送），ping 为心跳，subscribed 为订阅变更后的全部主题。
+// @Description 客户端可随时发送 ClientMessage 订阅或取消订阅主题，主题格式与 SSE 接口相同；topics 参数为连接时的初始主题。
+// @Description 断线重连时通过 last_event_id 参数补发缓冲中此后的事件。
+// @Tags 实时推送
+// @Security ApiKeyAuth
+// @Param topics query string false "初始订阅主题，逗号分隔" example(products:123)
+// @Param last_event_id query string false "最后收到的事件序号"
+// @Param access_token query string false "访问令牌，浏览器 WebSocket 无法设置 Authorization 请求头时使用"
+// @Success 101 {object} Frame "切换为 WebSocket 协议"
+// @Failure 400 {object} response.Response "订阅主题无效"
+// @Failure 401 {object} response.Response "未登录"
+// @Failure 403 {object} response.Response "无权订阅"
+// @Failure 503 {object} response.Response "实时推送未启用"
+// @Router /stream/ws [get]
+func (h *Handler) WebSocket(c *gin.Context) {
+	sub, replay, reset, ok := h.subscribe(c, "websocket", splitTopics(c.Query("topics")))
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{
+		// 身份由令牌而非 Cookie 确定，不存在跨站劫持风险，因此不校验 Origin
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			h.serveWebSocket(c, ws, sub, replay, reset)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// serveWebSocket 处理已升级的 WebSocket 连接，所有写入都在此协程中进行
+func (h *Handler) serveWebSocket(c *gin.Context, ws *websocket.Conn, sub *live.Subscription, replay []*live.Event, reset bool) {
+	defer ws.Close()
+	ws.SetReadDeadline(time.Time{})
+
+	send := func(frame Frame) error {
+		ws.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+		return websocket.JSON.Send(ws, frame)
+	}
+	sendError := func(err error) error {
+		resp := response.ErrorResponse(c, err)
+		return send(Frame{Type: FrameError, Error: &resp})
+	}
+
+	// 读取客户端消息，连接断开时关闭 messages
+	messages := make(chan ClientMessage)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(messages)
+		for {
+			var msg ClientMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				return
+			}
+			select {
+			case messages <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	if reset {
+		resp := response.ErrorResponse(c, errcode.StreamReset)
+		if err := send(Frame{Type: FrameReset, Error: &resp}); err != nil {
+			return
+		}
+	}
+	for _, e := range replay {
+		if err := send(Frame{Type: FrameEvent, Event: e}); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(h.cfg.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				sendError(sub.Reason())
+				return
+			}
+			err = send(Frame{Type: FrameEvent, Event: e})
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			if topics, topicErr := h.applyMessage(sub, msg); topicErr != nil {
+				err = sendError(topicErr)
+			} else {
+				err = send(Frame{Type: FrameSubscribed, Topics: topics})
+			}
+		case <-heartbeat.C:
+			err = send(Frame{Type: FramePing})
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// applyMessage 根据客户端消息调整订阅，返回调整后的全部主题
+func (h *Handler) applyMessage(sub *live.Subscription, msg ClientMessage) ([]string, error) {
+	current := sub.Topics()
+	switch msg.Action {
+	case "subscribe":
+		added, err := live.ParseTopics(msg.Topics, h.cfg.MaxTopics)
+		if err != nil {
+			return nil, err
+		}
+		topics := append([]live.Topic(nil), current...)
+		for _, topic := range added {
+			if !containsTopic(topics, topic.String()) {
+				topics = append(topics, topic)
+			}
+		}
+		if len(topics) > h.cfg.MaxTopics {
+			return nil, errcode.StreamTooManyTopics.New(h.cfg.MaxTopics)
+		}
+		current = topics
+	case "unsubscribe":
+		topics := make([]live.Topic, 0, len(current))
+		for _, topic := range current {
+			if !containsString(msg.Topics, topic.String()) {
+				topics = append(topics, topic)
+			}
+		}
+		current = topics
+	default:
+		return nil, errcode.InvalidParams.WithDetails(errcode.FieldError{Field: "action", Rule: "oneof", Param: "subscribe unsubscribe"})
+	}
+
+	sub.SetTopics(current)
+	names := make([]string, len(current))
+	for i, topic := range current {
+		names[i] = topic.String()
+	}
+	return names, nil
+}
+
+// subscribe 校验权限与主题并注册订阅，失败时输出错误响应
+func (h *Handler) subscribe(c *gin.Context, transport string, raw []string) (*live.Subscription, []*live.Event, bool, bool) {
+	hub := live.Default()
+	if hub == nil {
+		response.Fail(c, errcode.StreamUnavailable)
+		return nil, nil, false, false
+	}
+
+	scope, err := h.scope(c)
+	if err != nil {
+		response.FailWithError(c, err)
+		return nil, nil, false, false
+	}
+
+	topics, err := live.ParseTopics(raw, h.cfg.MaxTopics)
+	if err != nil {
+		response.FailWithError(c, err)
+		return nil, nil, false, false
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	sub, replay, reset, err := hub.Subscribe(transport, topics, scope, lastEventID)
+	if err != nil {
+		response.FailWithError(c, err)
+		return nil, nil, false, false
+	}
+	return sub, replay, reset, true
+}
+
+// scope 根据当前用户确定可接收的事件范围
+// 员工（含管理员）可接收全部事件，供应商只能接收所属供应商的事件，其他用户无权订阅
+// 用户经用户服务读取，令牌未过期但用户已被删除时拒绝订阅，用户类型与所属供应商的修改对新的订阅立即生效
+func (h *Handler) scope(c *gin.Context) (live.Scope, error) {
+	caller, ok := middleware.CallerFromContext(c)
+	if !ok {
+		return live.Scope{}, errcode.Unauthorized
+	}
+
+	u, err := h.users.Get(c, caller.UserID)
+	if err != nil {
+		return live.Scope{}, err
+	}
+	if u.DeletedAt != nil {
+		return live.Scope{}, errcode.User.NotFound.New()
+	}
+
+	switch {
+	case slices.Contains(module.Staff, u.UserType):
+		return live.Scope{All: true}, nil
+	case u.UserType == product.SupplierUserType && u.SupplierID != nil:
+		return live.Scope{Supplier: *u.SupplierID}, nil
+	}
+	return live.Scope{}, errcode.Forbidden
+}
+
+// splitTopics 拆分逗号分隔的主题参数
+func splitTopics(s string) []string {
+	var topics []string
+	for _, topic := range strings.Split(s, ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+// containsTopic 判断主题列表中是否已有该主题
+func containsTopic(topics []live.Topic, raw string) bool {
+	for _, topic := range topics {
+		if topic.String() == raw {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString 判断字符串列表中是否包含 s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if strings.TrimSpace(item) == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package stream
+
+import (
+	"erp_backend/pkg/response"
+	live "erp_backend/pkg/stream"
+)
+
+// WebSocket 服务端消息类型
+const (
+	FrameEvent      = "event"      // 变更事件
+	FrameReset      = "reset"      // 断线期间的事件已超出回放范围，客户端需重新加载数据
+	FrameError      = "error"      // 错误，连接被服务端断开前也会发送
+	FramePing       = "ping"       // 心跳
+	FrameSubscribed = "subscribed" // 订阅变更成功，携带当前全部主题
+)
+
+// Frame WebSocket 服务端消息
+// @Description WebSocket 连接中服务端发送的消息
+type Frame struct {
+	Type   string             `json:"type" example:"event"`                  // 消息类型：event、reset、error、ping、subscribed
+	Event  *live.Event        `json:"event,omitempty"`                       // 变更事件，type 为 event 时返回
+	Topics []string           `json:"topics,omitempty" example:"products:*"` // 当前订阅的主题，type 为 subscribed 时返回
+	Error  *response.Response `json:"error,omitempty"`                       // 错误信息，type 为 error、reset 时返回
+}
+
+// ClientMessage WebSocket 客户端消息
+// @Description WebSocket 连接中客户端发送的订阅变更
+type ClientMessage struct {
+	Action string   `json:"action" example:"subscribe"`    // 操作：subscribe 订阅、unsubscribe 取消订阅
+	Topics []string `json:"topics" example:"products:123"` // 主题列表
+}
@@ -0,0 +1,49 @@
+package stream
+
+import (
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	live "erp_backend/pkg/stream"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 实时推送模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "stream" }
+
+// DependsOn 实现 module.Module 接口，按用户所属供应商过滤推送的事件
+func (Module) DependsOn() []string { return []string{"user"} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// Jobs 实现 module.Module 接口，订阅领域事件并在后台监听其他实例转发的事件
+// 事件由转发器所在实例通过 NOTIFY 广播，因此每个实例都会收到全部事件，与本实例是否开启转发无关
+func (Module) Jobs(app *module.App) []module.Job {
+	cfg := config.GetStreamConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	hub := live.NewHub(cfg)
+	live.SetHub(hub)
+	app.Relay.SubscribeAll("stream", live.NewNotifier(app.DB).Handle)
+	return []module.Job{
+		{Name: "stream-listener", Run: live.NewListener(app.DB, hub).Run, Stop: hub.Close},
+	}
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "stream.subscribe", Description: "通过 SSE 或 WebSocket 订阅数据变更"},
+	}
+}
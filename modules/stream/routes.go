@@ -0,0 +1,22 @@
+package stream
+
+import (
+	"gorm.io/gorm"
+
+	"erp_backend/modules/user"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+// RegisterRoutes 注册实时推送路由
+// 浏览器 EventSource 与 WebSocket 无法设置请求头，令牌可通过 access_token 查询参数传递
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(user.NewService(user.NewRepository(db)))
+
+	subscribe := module.RequirePermission("stream.subscribe")
+	stream := r.Group("/stream", middleware.TokenFromQuery("access_token"))
+	{
+		stream.GET("", subscribe, handler.SSE)
+		stream.GET("/ws", subscribe, handler.WebSocket)
+	}
+}
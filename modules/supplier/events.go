@@ -0,0 +1,23 @@
+package supplier
+
+import "erp_backend/pkg/events"
+
+// AggregateType 实现 events.Aggregate 接口
+func (Supplier) AggregateType() string { return "supplier" }
+
+// AggregateID 实现 events.Aggregate 接口
+func (s Supplier) AggregateID() uint { return s.ID }
+
+// 供应商领域事件
+type (
+	SupplierCreated  = events.Created[Supplier]  // 供应商创建
+	SupplierUpdated  = events.Updated[Supplier]  // 供应商更新
+	SupplierToggled  = events.Toggled[Supplier]  // 供应商启用状态切换
+	SupplierDeleted  = events.Deleted[Supplier]  // 供应商删除（移入回收站）
+	SupplierRestored = events.Restored[Supplier] // 供应商从回收站恢复
+)
+
+// Events 实现 module.EventsModule 接口
+func (Module) Events() []events.Event {
+	return []events.Event{SupplierCreated{}, SupplierUpdated{}, SupplierToggled{}, SupplierDeleted{}, SupplierRestored{}}
+}
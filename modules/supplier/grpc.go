@@ -0,0 +1,137 @@
+package supplier
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/rpc"
+	"erp_backend/pkg/rpc/erpv1"
+)
+
+// RegisterGRPC 注册供应商 gRPC 服务
+func RegisterGRPC(s grpc.ServiceRegistrar, db *gorm.DB) {
+	erpv1.RegisterSupplierServiceServer(s, &grpcServer{service: NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db))})
+}
+
+// grpcPermissions 供应商 gRPC 方法所需的权限，与对应 REST 接口一致
+var grpcPermissions = map[string]string{
+	erpv1.SupplierService_GetSupplier_FullMethodName:    "supplier.read",
+	erpv1.SupplierService_ListSuppliers_FullMethodName:  "supplier.read",
+	erpv1.SupplierService_CreateSupplier_FullMethodName: "supplier.write",
+	erpv1.SupplierService_UpdateSupplier_FullMethodName: "supplier.write",
+	erpv1.SupplierService_DeleteSupplier_FullMethodName: "supplier.write",
+	erpv1.SupplierService_ToggleSupplier_FullMethodName: "supplier.write",
+}
+
+// grpcServer 供应商 gRPC 服务
+type grpcServer struct {
+	erpv1.UnimplementedSupplierServiceServer
+	service *Service
+}
+
+// GetSupplier 获取单个供应商
+func (s *grpcServer) GetSupplier(ctx context.Context, req *erpv1.GetSupplierRequest) (*erpv1.Supplier, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := s.service.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(supplier), nil
+}
+
+// ListSuppliers 分页查询供应商
+func (s *grpcServer) ListSuppliers(ctx context.Context, req *erpv1.ListSuppliersRequest) (*erpv1.ListSuppliersResponse, error) {
+	page, err := s.service.List(ctx, rpc.Query(req.Options))
+	if err != nil {
+		return nil, err
+	}
+	suppliers := *page.Items.(*[]Supplier)
+	resp := &erpv1.ListSuppliersResponse{Items: make([]*erpv1.Supplier, len(suppliers)), Page: rpc.PageInfo(page)}
+	for i := range suppliers {
+		resp.Items[i] = toProto(&suppliers[i])
+	}
+	return resp, nil
+}
+
+// CreateSupplier 创建供应商
+func (s *grpcServer) CreateSupplier(ctx context.Context, req *erpv1.CreateSupplierRequest) (*erpv1.Supplier, error) {
+	if req.Supplier == nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	supplier := &Supplier{Name: req.Supplier.Name, Remark: req.Supplier.Remark, IsEnabled: req.Supplier.IsEnabled}
+	if err := s.service.Create(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return toProto(supplier), nil
+}
+
+// UpdateSupplier 按字段掩码更新供应商，与 PATCH 接口使用相同的白名单、校验规则与领域事件
+func (s *grpcServer) UpdateSupplier(ctx context.Context, req *erpv1.UpdateSupplierRequest) (*erpv1.Supplier, error) {
+	if req.Supplier == nil {
+		return nil, errcode.InvalidParams.New()
+	}
+	id, err := rpc.ID(req.Supplier.Id)
+	if err != nil {
+		return nil, err
+	}
+	values, err := rpc.Fields(map[string]interface{}{
+		"name":       req.Supplier.Name,
+		"remark":     req.Supplier.Remark,
+		"is_enabled": req.Supplier.IsEnabled,
+	}, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := s.service.Merge(ctx, id, values)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(supplier), nil
+}
+
+// DeleteSupplier 删除供应商
+func (s *grpcServer) DeleteSupplier(ctx context.Context, req *erpv1.DeleteSupplierRequest) (*emptypb.Empty, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ToggleSupplier 切换供应商启用状态
+func (s *grpcServer) ToggleSupplier(ctx context.Context, req *erpv1.ToggleSupplierRequest) (*erpv1.Supplier, error) {
+	id, err := rpc.ID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	supplier, err := s.service.Toggle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(supplier), nil
+}
+
+// toProto 转换为 protobuf 消息
+func toProto(s *Supplier) *erpv1.Supplier {
+	return &erpv1.Supplier{
+		Id:        uint64(s.ID),
+		Name:      s.Name,
+		Remark:    s.Remark,
+		IsEnabled: s.IsEnabled,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+}
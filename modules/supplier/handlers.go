@@ -1,21 +1,43 @@
 package supplier
 
 import (
-	"net/http"
-	"strconv"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
-	"erp_backend/pkg/response"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/resource"
 )
 
+// listConfig 供应商列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 供应商列表筛选字段
+var listFilters = filter.Fields{
+	"id":         filter.Number,
+	"name":       filter.String,
+	"remark":     filter.String,
+	"is_enabled": filter.Bool,
+	"created_at": filter.Time,
+	"updated_at": filter.Time,
+}
+
+// patchFields 供应商允许通过 PATCH 修改的字段及校验规则
+var patchFields = patch.Fields{
+	"name":       "required,max=100",
+	"remark":     "",
+	"is_enabled": "",
+}
+
 type Handler struct {
-	db *gorm.DB
+	resource *resource.Handler[Supplier]
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(service *Service) *Handler {
+	return &Handler{resource: resource.NewHandler(service.Resource)}
 }
 
 // Create 创建供应商
@@ -24,43 +46,61 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Tags 供应商管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param supplier body Supplier true "供应商信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=Supplier} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /suppliers [post]
 func (h *Handler) Create(c *gin.Context) {
-	var supplier Supplier
-	if err := c.ShouldBindJSON(&supplier); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Create(&supplier).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建供应商失败")
-		return
-	}
-
-	response.Success(c, supplier)
+	h.resource.Create(c)
 }
 
 // List 获取供应商列表
 // @Summary 获取供应商列表
-// @Description 获取所有供应商的列表
+// @Description 分页获取供应商列表，回收站中的供应商不包含在内
 // @Tags 供应商管理
 // @Accept json
 // @Produce json
-// @Success 200 {object} response.Response{data=[]Supplier} "获取成功"
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Supplier}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /suppliers [get]
 func (h *Handler) List(c *gin.Context) {
-	var suppliers []Supplier
-	if err := h.db.Find(&suppliers).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取供应商列表失败")
-		return
-	}
+	h.resource.List(c)
+}
 
-	response.Success(c, suppliers)
+// Trash 获取回收站中的供应商
+// @Summary 获取回收站中的供应商
+// @Description 分页获取已删除的供应商，查询参数同列表接口
+// @Tags 供应商管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]Supplier}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /suppliers/trash [get]
+func (h *Handler) Trash(c *gin.Context) {
+	h.resource.Trash(c)
 }
 
 // Get 获取单个供应商
@@ -69,90 +109,97 @@ func (h *Handler) List(c *gin.Context) {
 // @Tags 供应商管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "供应商ID"
 // @Success 200 {object} response.Response{data=Supplier} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "供应商不存在"
 // @Router /suppliers/{id} [get]
 func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var supplier Supplier
-	if err := h.db.First(&supplier, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "供应商不存在")
-		return
-	}
-
-	response.Success(c, supplier)
+	h.resource.Get(c)
 }
 
 // Update 更新供应商
 // @Summary 更新供应商
-// @Description 更新供应商信息
+// @Description 全量更新供应商信息，主键与创建时间不可修改
 // @Tags 供应商管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "供应商ID"
 // @Param supplier body Supplier true "供应商信息"
 // @Success 200 {object} response.Response{data=Supplier} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "供应商不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /suppliers/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var supplier Supplier
-	if err := h.db.First(&supplier, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "供应商不存在")
-		return
-	}
-
-	if err := c.ShouldBindJSON(&supplier); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	if err := h.db.Save(&supplier).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新供应商失败")
-		return
-	}
-
-	response.Success(c, supplier)
+	h.resource.Update(c)
+}
+
+// Patch 部分更新供应商
+// @Summary 部分更新供应商
+// @Description 使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改供应商，只写入变更的字段。合并补丁中字段值为 null 表示清空该字段
+// @Tags 供应商管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "供应商ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Supplier} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "供应商不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /suppliers/{id} [patch]
+func (h *Handler) Patch(c *gin.Context) {
+	h.resource.Patch(c)
 }
 
 // Delete 删除供应商
 // @Summary 删除供应商
-// @Description 删除供应商
+// @Description 将供应商移入回收站，可通过恢复接口恢复；供应商不存在时同样返回成功
 // @Tags 供应商管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "供应商ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /suppliers/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	if err := h.db.Delete(&Supplier{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除供应商失败")
-		return
-	}
-
-	response.Success(c, gin.H{"message": "删除成功"})
+	h.resource.Delete(c)
+}
+
+// Restore 恢复供应商
+// @Summary 恢复供应商
+// @Description 将回收站中的供应商恢复
+// @Tags 供应商管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "供应商ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Supplier} "恢复成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Failure 404 {object} response.Response "供应商不存在或不在回收站中"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /suppliers/{id}/restore [post]
+func (h *Handler) Restore(c *gin.Context) {
+	h.resource.Restore(c)
 }
 
 // ToggleStatus 切换供应商状态
@@ -161,30 +208,16 @@ func (h *Handler) Delete(c *gin.Context) {
 // @Tags 供应商管理
 // @Accept json
 // @Produce json
+// @Security ApiKeyAuth
 // @Param id path int true "供应商ID"
-// @Success 200 {object} response.Response{data=Supplier} "切换成功"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=Supplier} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
 // @Failure 404 {object} response.Response "供应商不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /suppliers/{id}/toggle [patch]
 func (h *Handler) ToggleStatus(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
-		return
-	}
-
-	var supplier Supplier
-	if err := h.db.First(&supplier, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "供应商不存在")
-		return
-	}
-
-	supplier.IsEnabled = !supplier.IsEnabled
-	if err := h.db.Save(&supplier).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新状态失败")
-		return
-	}
-
-	response.Success(c, supplier)
+	h.resource.Toggle(c)
 }
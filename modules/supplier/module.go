@@ -0,0 +1,46 @@
+package supplier
+
+import (
+	"google.golang.org/grpc"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 供应商管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "supplier" }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&Supplier{}} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// RegisterGRPC 实现 module.GRPCModule 接口
+func (Module) RegisterGRPC(s grpc.ServiceRegistrar, app *module.App) { RegisterGRPC(s, app.DB) }
+
+// GRPCPermissions 实现 module.GRPCModule 接口
+func (Module) GRPCPermissions() map[string]string { return grpcPermissions }
+
+// Jobs 实现 module.Module 接口，定期永久删除回收站中过期的供应商
+func (Module) Jobs(app *module.App) []module.Job {
+	app.Queue.PurgeTrash("supplier", &Supplier{})
+	return nil
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "supplier.read", Description: "查看供应商"},
+		{Code: "supplier.write", Description: "创建、修改与删除供应商", UserTypes: module.Staff},
+	}
+}
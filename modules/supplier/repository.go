@@ -0,0 +1,17 @@
+package supplier
+
+import (
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/repository"
+)
+
+// Repository 供应商数据访问接口
+type Repository interface {
+	repository.Trashable[Supplier]
+}
+
+// NewRepository 创建基于 GORM 的供应商仓储
+func NewRepository(db *gorm.DB) Repository {
+	return repository.NewGorm[Supplier](db)
+}
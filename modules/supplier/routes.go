@@ -1,21 +1,29 @@
 package supplier
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
 )
 
 // RegisterRoutes 注册供应商相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(NewService(NewRepository(db), database.NewTransactor(db), events.NewOutbox(db)))
 
+	read, write := module.RequirePermission("supplier.read"), module.RequirePermission("supplier.write")
 	suppliers := r.Group("/suppliers")
 	{
-		suppliers.POST("", handler.Create)
-		suppliers.GET("", handler.List)
-		suppliers.GET("/:id", handler.Get)
-		suppliers.PUT("/:id", handler.Update)
-		suppliers.DELETE("/:id", handler.Delete)
-		suppliers.PATCH("/:id/toggle", handler.ToggleStatus)
+		suppliers.POST("", write, handler.Create)
+		suppliers.GET("", read, handler.List)
+		suppliers.GET("/trash", read, handler.Trash)
+		suppliers.GET("/:id", read, handler.Get)
+		suppliers.PUT("/:id", write, handler.Update)
+		suppliers.PATCH("/:id", write, handler.Patch)
+		suppliers.DELETE("/:id", write, handler.Delete)
+		suppliers.POST("/:id/restore", write, handler.Restore)
+		suppliers.PATCH("/:id/toggle", write, handler.ToggleStatus)
 	}
 }
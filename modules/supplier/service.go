@@ -0,0 +1,23 @@
+package supplier
+
+import (
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/resource"
+)
+
+// Service 供应商业务逻辑，供 HTTP、gRPC 与后台任务共用，增删改查、切换状态与回收站等通用操作由 resource.Resource 提供
+type Service struct {
+	*resource.Resource[Supplier]
+}
+
+// NewService 创建供应商服务
+func NewService(repo Repository, tx database.Transactor, publisher events.Publisher) *Service {
+	return &Service{Resource: resource.New[Supplier](repo, tx, publisher, resource.Config[Supplier]{
+		Errors:      errcode.Supplier,
+		List:        listConfig,
+		Filters:     listFilters,
+		PatchFields: patchFields,
+	})}
+}
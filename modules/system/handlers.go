@@ -1,11 +1,17 @@
 package system
 
 import (
+	"context"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/module"
 	"erp_backend/pkg/response"
+	"erp_backend/pkg/server"
 )
 
 // @Summary 健康检查
@@ -22,6 +28,37 @@ func HealthCheck(c *gin.Context) {
 	})
 }
 
+// Readiness 就绪检查
+// @Summary 就绪检查
+// @Description 检查服务是否可以接收流量：服务正在关闭或数据库不可用时返回 503，供负载均衡与容器编排摘除实例
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=map[string]string} "服务就绪"
+// @Failure 503 {object} response.Response "服务未就绪"
+// @Router /ready [get]
+func Readiness(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !server.Ready() {
+			response.Fail(c, errcode.ServiceUnavailable)
+			return
+		}
+
+		sqlDB, err := db.DB()
+		if err == nil {
+			ctx, cancel := context.WithTimeout(c, 2*time.Second)
+			defer cancel()
+			err = sqlDB.PingContext(ctx)
+		}
+		if err != nil {
+			response.Fail(c, errcode.ServiceUnavailable)
+			return
+		}
+
+		response.Success(c, gin.H{"status": "ready"})
+	}
+}
+
 // SystemInfo 系统信息
 func SystemInfo(c *gin.Context) {
 	response.Success(c, gin.H{
@@ -30,3 +67,63 @@ func SystemInfo(c *gin.Context) {
 		"env":     gin.Mode(),
 	})
 }
+
+// ErrorCodeInfo 错误码说明
+// @Description 业务错误码说明
+type ErrorCodeInfo struct {
+	Code    string `json:"code" example:"PRODUCT_SKU_DUPLICATE"` // 业务错误码
+	Status  int    `json:"status" example:"409"`                 // HTTP 状态码
+	Message string `json:"message" example:"商品SKU已存在"`           // 提示信息（按请求语言）
+}
+
+// ErrorCodes 错误码列表
+// @Summary 错误码列表
+// @Description 列出全部业务错误码。错误响应中的 error_code 字段取值于此列表，校验失败（VALIDATION_FAILED）时 details 给出字段级详情
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.Response{data=[]ErrorCodeInfo} "获取成功"
+// @Router /error-codes [get]
+func ErrorCodes(c *gin.Context) {
+	codes := errcode.All()
+	locale := i18n.Locale(c)
+	infos := make([]ErrorCodeInfo, len(codes))
+	for i, code := range codes {
+		infos[i] = ErrorCodeInfo{Code: code.Key, Status: code.Status, Message: i18n.Translate(locale, code.Key, code.Message)}
+	}
+	response.Success(c, infos)
+}
+
+// ModuleInfo 已启用模块
+// @Description 已启用的业务模块
+type ModuleInfo struct {
+	Name        string              `json:"name" example:"product"`                 // 模块名
+	DependsOn   []string            `json:"depends_on" example:"supplier,category"` // 依赖的模块
+	Permissions []module.Permission `json:"permissions"`                            // 模块定义的权限及拥有权限的用户类型
+}
+
+// Modules 已启用模块列表
+// @Summary 已启用模块列表
+// @Description 按启动顺序列出已启用的业务模块及其依赖与权限，模块通过 MODULES_ENABLED、MODULES_DISABLED 配置启用
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Response{data=[]ModuleInfo} "获取成功"
+// @Failure 401 {object} response.Response "未认证"
+// @Failure 403 {object} response.Response "无权限"
+// @Router /modules [get]
+func Modules(c *gin.Context) {
+	enabled := module.Enabled()
+	infos := make([]ModuleInfo, len(enabled))
+	for i, m := range enabled {
+		infos[i] = ModuleInfo{Name: m.Name(), DependsOn: m.DependsOn(), Permissions: m.Permissions()}
+		if infos[i].DependsOn == nil {
+			infos[i].DependsOn = []string{}
+		}
+		if infos[i].Permissions == nil {
+			infos[i].Permissions = []module.Permission{}
+		}
+	}
+	response.Success(c, infos)
+}
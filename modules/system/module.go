@@ -0,0 +1,41 @@
+package system
+
+import (
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/settings"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 系统模块：健康检查、就绪检查、错误码与模块列表
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "system" }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// Settings 实现 module.SettingsModule 接口
+func (Module) Settings() []settings.Definition {
+	return []settings.Definition{
+		{
+			Key:         middleware.MaintenanceModeKey,
+			Description: "维护模式，开启后除登录与修改设置外只能查询数据，写请求返回 503",
+			Schema:      &settings.Schema{Type: "boolean"},
+			Default:     false,
+		},
+	}
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "system.modules", Description: "查看已启用的模块及其权限", UserTypes: module.Admins},
+	}
+}
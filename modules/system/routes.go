@@ -5,20 +5,32 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
 )
 
 // RegisterRoutes 注册系统相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
 	// 健康检查
-	r.GET("/health", func(c *gin.Context) {
+	r.GET("/health", middleware.Public, func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status": "ok",
 			"time":   time.Now(),
 		})
 	})
 
+	// 就绪检查
+	r.GET("/ready", middleware.Public, Readiness(db))
+
+	// 错误码列表
+	r.GET("/error-codes", middleware.Public, ErrorCodes)
+
+	// 已启用模块列表
+	r.GET("/modules", module.RequirePermission("system.modules"), Modules)
+
 	// 系统信息
-	r.GET("/info", func(c *gin.Context) {
+	r.GET("/info", middleware.Public, func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"name":    "ERP Backend",
 			"version": "1.0.0",
@@ -0,0 +1,158 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"erp_backend/pkg/module"
+)
+
+// userTypes 命令行允许设置的用户类型，与登录令牌及模块权限声明中使用的取值一致
+var userTypes = []string{"admin", "user", "管理员", "供应商", "员工"}
+
+// Commands 用户运维命令：创建用户、重置密码、修改用户类型与解锁，无需登录即可修复账号
+func Commands() []module.Command {
+	return []module.Command{
+		{
+			Name:        "user create",
+			Usage:       "-name <用户名> -password <密码> [-email <邮箱>] [-phone <电话>] [-type <用户类型>] [-language <语言>]",
+			Description: "创建用户",
+			Run:         runCreate,
+		},
+		{
+			Name:        "user reset-password",
+			Usage:       "-name <用户名> -password <新密码>",
+			Description: "重置用户密码，无需旧密码",
+			Run:         runResetPassword,
+		},
+		{
+			Name:        "user set-role",
+			Usage:       "-name <用户名> -type <用户类型>",
+			Description: "修改用户类型（admin、user、管理员、供应商、员工），重新登录后生效",
+			Run:         runSetRole,
+		},
+		{
+			Name:        "user unlock",
+			Usage:       "-name <用户名>",
+			Description: "解除连续输错密码导致的登录锁定",
+			Run:         runUnlock,
+		},
+	}
+}
+
+// runCreate 创建用户
+func runCreate(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	name := fs.String("name", "", "用户名")
+	password := fs.String("password", "", "密码，需符合密码策略 user.password_policy")
+	email := fs.String("email", "", "邮箱")
+	phone := fs.String("phone", "", "电话号码")
+	userType := fs.String("type", "user", "用户类型")
+	language := fs.String("language", "", "语言偏好：zh-CN、en-US")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errors.New("缺少 -name")
+	}
+	if err := checkUserType(*userType); err != nil {
+		return err
+	}
+
+	user := User{Name: *name, Password: *password, Email: *email, Phone: *phone, UserType: *userType, Language: *language}
+	if err := newCommandService(app).Register(ctx, &user); err != nil {
+		return err
+	}
+	fmt.Printf("已创建用户 %s（ID %d，类型 %s）\n", user.Name, user.ID, user.UserType)
+	return nil
+}
+
+// runResetPassword 重置密码
+func runResetPassword(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+	name := fs.String("name", "", "用户名")
+	password := fs.String("password", "", "新密码，需符合密码策略 user.password_policy")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	service := newCommandService(app)
+	user, err := lookup(ctx, service, *name)
+	if err != nil {
+		return err
+	}
+	if err := service.ResetPassword(ctx, user.ID, *password); err != nil {
+		return err
+	}
+	fmt.Printf("已重置用户 %s 的密码\n", user.Name)
+	return nil
+}
+
+// runSetRole 修改用户类型
+func runSetRole(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("user set-role", flag.ContinueOnError)
+	name := fs.String("name", "", "用户名")
+	userType := fs.String("type", "", "用户类型")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkUserType(*userType); err != nil {
+		return err
+	}
+
+	service := newCommandService(app)
+	user, err := lookup(ctx, service, *name)
+	if err != nil {
+		return err
+	}
+	if user, err = service.SetUserType(ctx, user.ID, *userType); err != nil {
+		return err
+	}
+	fmt.Printf("用户 %s 的类型已设为 %s，重新登录后生效\n", user.Name, user.UserType)
+	return nil
+}
+
+// runUnlock 解除用户的登录失败锁定
+func runUnlock(ctx context.Context, app *module.App, args []string) error {
+	fs := flag.NewFlagSet("user unlock", flag.ContinueOnError)
+	name := fs.String("name", "", "用户名")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	service := newCommandService(app)
+	user, err := lookup(ctx, service, *name)
+	if err != nil {
+		return err
+	}
+	if _, err := service.Unlock(ctx, user.ID); err != nil {
+		return err
+	}
+	fmt.Printf("已解除用户 %s 的登录锁定\n", user.Name)
+	return nil
+}
+
+// newCommandService 创建命令使用的用户服务
+func newCommandService(app *module.App) *Service {
+	return NewService(NewRepository(app.DB))
+}
+
+// lookup 按用户名查询用户
+func lookup(ctx context.Context, service *Service, name string) (*User, error) {
+	if name == "" {
+		return nil, errors.New("缺少 -name")
+	}
+	return service.GetByName(ctx, name)
+}
+
+// checkUserType 检查用户类型
+func checkUserType(userType string) error {
+	for _, t := range userTypes {
+		if userType == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("不支持的用户类型 %s，可选 %v", userType, userTypes)
+}
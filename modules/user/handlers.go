@@ -1,19 +1,48 @@
 package user
 
 import (
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 
-	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
 	"erp_backend/pkg/response"
 )
 
+// listConfig 用户列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "email", "user_type", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 用户列表筛选字段
+var listFilters = filter.Fields{
+	"id":          filter.Number,
+	"name":        filter.String,
+	"email":       filter.String,
+	"phone":       filter.String,
+	"user_type":   filter.String,
+	"supplier_id": filter.Number,
+	"is_delete":   filter.Bool,
+	"created_at":  filter.Time,
+	"updated_at":  filter.Time,
+}
+
+// patchFields 用户允许通过 PATCH 修改的字段及校验规则，密码只能通过修改密码接口变更，
+// 用户类型与所属供应商决定访问权限与数据范围，只能由管理员通过修改访问权限接口变更
+var patchFields = patch.Fields{
+	"name":     "required,max=100",
+	"email":    "required,email,max=100",
+	"phone":    "max=20",
+	"language": "omitempty,oneof=zh-CN en-US",
+}
+
 type Handler struct {
-	db *gorm.DB
+	service *Service
 }
 
 // LoginResponse 登录响应
@@ -23,8 +52,8 @@ type LoginResponse struct {
 	User  UserResponse `json:"user"`                                                    // 用户信息
 }
 
-func NewHandler(db *gorm.DB) *Handler {
-	return &Handler{db: db}
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
 }
 
 // Login 用户登录
@@ -37,79 +66,48 @@ func NewHandler(db *gorm.DB) *Handler {
 // @Success 200 {object} response.Response{data=LoginResponse} "登录成功"
 // @Failure 400 {object} response.Response "请求参数错误"
 // @Failure 401 {object} response.Response "用户名或密码错误"
+// @Failure 423 {object} response.Response "登录失败次数过多，账号已锁定"
+// @Failure 429 {object} response.Response "请求过于频繁"
 // @Router /auth/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var loginData LoginRequest
 	if err := c.ShouldBindJSON(&loginData); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+		response.BindError(c, err)
 		return
 	}
 
-	var user User
-	if err := h.db.Where("name = ?", loginData.Username).First(&user).Error; err != nil {
-		response.Error(c, http.StatusUnauthorized, "用户名或密码错误")
-		return
-	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginData.Password)); err != nil {
-		response.Error(c, http.StatusUnauthorized, "用户名或密码错误")
-		return
-	}
-
-	// 生成JWT token
-	tokenString, err := middleware.GenerateToken(user.ID, user.UserType)
+	result, err := h.service.Login(c, loginData.Username, loginData.Password)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "生成token失败")
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, LoginResponse{
-		Token: tokenString,
-		User:  user.ToResponse(),
-	})
+	response.Success(c, result)
 }
 
 // Register 用户注册
 // @Summary 用户注册
-// @Description 注册新用户
+// @Description 注册新用户，注册的用户为默认类型
 // @Tags 用户认证
 // @Accept json
 // @Produce json
-// @Param data body CreateUserRequest true "用户信息"
+// @Param data body RegisterRequest true "用户信息"
 // @Success 200 {object} response.Response{data=UserResponse} "注册成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 409 {object} response.Response "用户名或邮箱已存在"
+// @Failure 429 {object} response.Response "请求过于频繁"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /auth/register [post]
 func (h *Handler) Register(c *gin.Context) {
-	var user User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	// 检查用户名是否已存在
-	var count int64
-	h.db.Model(&User{}).Where("name = ?", user.Name).Count(&count)
-	if count > 0 {
-		response.Error(c, http.StatusBadRequest, "用户名已存在")
-		return
-	}
-
-	// 加密密码
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "密码加密失败")
+	var req RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
 		return
 	}
-	user.Password = string(hashedPassword)
-
-	// 设置默认用户类型
-	if user.UserType == "" {
-		user.UserType = "user"
-	}
 
-	if err := h.db.Create(&user).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建用户失败")
+	user := req.user()
+	if err := h.service.Register(c, user); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -118,27 +116,34 @@ func (h *Handler) Register(c *gin.Context) {
 
 // List 获取用户列表
 // @Summary 获取用户列表
-// @Description 获取所有用户的列表
+// @Description 获取所有用户的列表，仅管理员与员工可调用
 // @Tags 用户管理
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} response.Response{data=[]UserResponse} "获取成功"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[name][contains]=杯"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]UserResponse}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /users [get]
 func (h *Handler) List(c *gin.Context) {
-	var users []User
-	if err := h.db.Find(&users).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "获取用户列表失败")
+	page, err := h.service.List(c, c.Request.URL.Query())
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, users)
+	response.Success(c, page)
 }
 
 // Get 获取单个用户
 // @Summary 获取单个用户
-// @Description 根据ID获取用户信息
+// @Description 根据ID获取用户信息，仅管理员与员工可调用
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -146,18 +151,19 @@ func (h *Handler) List(c *gin.Context) {
 // @Param id path int true "用户ID"
 // @Success 200 {object} response.Response{data=UserResponse} "获取成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
 // @Failure 404 {object} response.Response "用户不存在"
 // @Router /users/{id} [get]
 func (h *Handler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	var user User
-	if err := h.db.First(&user, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "用户不存在")
+	user, err := h.service.Get(c, uint(id))
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -166,33 +172,28 @@ func (h *Handler) Get(c *gin.Context) {
 
 // Create 创建用户
 // @Summary 创建用户
-// @Description 创建新用户
+// @Description 创建新用户，仅管理员可调用
 // @Tags 用户管理
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param data body CreateUserRequest true "用户信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
 // @Success 200 {object} response.Response{data=UserResponse} "创建成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /users [post]
 func (h *Handler) Create(c *gin.Context) {
-	var user User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	// 加密密码
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "密码加密失败")
+	var req CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
 		return
 	}
-	user.Password = string(hashedPassword)
 
-	if err := h.db.Create(&user).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "创建用户失败")
+	user := req.user()
+	if err := h.service.Create(c, user); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -201,7 +202,7 @@ func (h *Handler) Create(c *gin.Context) {
 
 // Update 更新用户
 // @Summary 更新用户
-// @Description 更新用户信息
+// @Description 更新用户信息，仅管理员可调用，修改自己的资料使用 /users/profile
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -210,29 +211,100 @@ func (h *Handler) Create(c *gin.Context) {
 // @Param data body UpdateUserRequest true "用户信息"
 // @Success 200 {object} response.Response{data=UserResponse} "更新成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
 // @Failure 404 {object} response.Response "用户不存在"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /users/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	var user User
-	if err := h.db.First(&user, id).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "用户不存在")
+	user, err := h.service.Update(c, uint(id), func(user *User) error {
+		var req UpdateUserRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return errcode.FromBinding(err)
+		}
+		req.apply(user)
+		return nil
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&user); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+	response.Success(c, user)
+}
+
+// Patch 部分更新用户
+// @Summary 部分更新用户
+// @Description 仅管理员可调用，使用 JSON Merge Patch（application/merge-patch+json 或 application/json）或 JSON Patch（application/json-patch+json）修改用户，只写入变更的字段。仅允许修改 name、email、phone、language
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "用户ID"
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=UserResponse} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /users/{id} [patch]
+func (h *Handler) Patch(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	if err := h.db.Save(&user).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新用户失败")
+	user, err := h.service.Patch(c, uint(id), func(user *User) (map[string]interface{}, error) {
+		return patch.Apply(c, user, patchFields)
+	})
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, user)
+}
+
+// UpdateAccess 修改用户的访问权限
+// @Summary 修改用户访问权限
+// @Description 修改用户类型与所属供应商，仅管理员可调用，用户重新登录后生效
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "用户ID"
+// @Param data body UpdateAccessRequest true "访问权限"
+// @Success 200 {object} response.Response{data=UserResponse} "修改成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /users/{id}/access [put]
+func (h *Handler) UpdateAccess(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	var req UpdateAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	user, err := h.service.SetAccess(c, uint(id), req.UserType, req.SupplierID)
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -241,7 +313,7 @@ func (h *Handler) Update(c *gin.Context) {
 
 // Delete 删除用户
 // @Summary 删除用户
-// @Description 删除指定用户
+// @Description 删除指定用户，仅管理员可调用
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -249,21 +321,22 @@ func (h *Handler) Update(c *gin.Context) {
 // @Param id path int true "用户ID"
 // @Success 200 {object} response.Response "删除成功"
 // @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 403 {object} response.Response "权限不足"
 // @Failure 500 {object} response.Response "服务器内部错误"
 // @Router /users/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的ID")
+		response.Fail(c, errcode.InvalidID)
 		return
 	}
 
-	if err := h.db.Delete(&User{}, id).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "删除用户失败")
+	if err := h.service.Delete(c, uint(id)); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, gin.H{"message": "删除成功"})
+	response.Success(c, gin.H{"message": i18n.T(c, "message.deleted")})
 }
 
 // GetProfile 获取用户个人资料
@@ -280,13 +353,13 @@ func (h *Handler) Delete(c *gin.Context) {
 func (h *Handler) GetProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		response.Error(c, http.StatusUnauthorized, "未认证")
+		response.Fail(c, errcode.Unauthorized)
 		return
 	}
 
-	var user User
-	if err := h.db.First(&user, userID).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "用户不存在")
+	user, err := h.service.Get(c, userID.(uint))
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -309,23 +382,54 @@ func (h *Handler) GetProfile(c *gin.Context) {
 func (h *Handler) UpdateProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		response.Error(c, http.StatusUnauthorized, "未认证")
+		response.Fail(c, errcode.Unauthorized)
 		return
 	}
 
-	var user User
-	if err := h.db.First(&user, userID).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "用户不存在")
+	user, err := h.service.UpdateProfile(c, userID.(uint), func(user *User) error {
+		var req UpdateUserRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			return errcode.FromBinding(err)
+		}
+		req.apply(user)
+		return nil
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&user); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
+	response.Success(c, user)
+}
+
+// PatchProfile 部分更新个人资料
+// @Summary 部分更新个人资料
+// @Description 使用 JSON Merge Patch 或 JSON Patch 修改当前登录用户的个人资料，仅允许修改 name、email、phone、language
+// @Tags 个人中心
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param patch body object true "补丁内容"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=UserResponse} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未登录"
+// @Failure 404 {object} response.Response "用户不存在"
+// @Failure 409 {object} response.Response "JSON Patch test 操作未通过"
+// @Failure 413 {object} response.Response "请求体过大"
+// @Router /users/profile [patch]
+func (h *Handler) PatchProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.Fail(c, errcode.Unauthorized)
 		return
 	}
 
-	if err := h.db.Save(&user).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新个人资料失败")
+	user, err := h.service.PatchProfile(c, userID.(uint), func(user *User) (map[string]interface{}, error) {
+		return patch.Apply(c, user, patchFields)
+	})
+	if err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
@@ -348,7 +452,7 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 func (h *Handler) UpdatePassword(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		response.Error(c, http.StatusUnauthorized, "未认证")
+		response.Fail(c, errcode.Unauthorized)
 		return
 	}
 
@@ -358,32 +462,14 @@ func (h *Handler) UpdatePassword(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&passwordData); err != nil {
-		response.Error(c, http.StatusBadRequest, "无效的请求参数")
-		return
-	}
-
-	var user User
-	if err := h.db.First(&user, userID).Error; err != nil {
-		response.Error(c, http.StatusNotFound, "用户不存在")
-		return
-	}
-
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(passwordData.OldPassword)); err != nil {
-		response.Error(c, http.StatusBadRequest, "原密码错误")
-		return
-	}
-
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(passwordData.NewPassword), bcrypt.DefaultCost)
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "密码加密失败")
+		response.BindError(c, err)
 		return
 	}
 
-	user.Password = string(hashedPassword)
-	if err := h.db.Save(&user).Error; err != nil {
-		response.Error(c, http.StatusInternalServerError, "更新密码失败")
+	if err := h.service.UpdatePassword(c, userID.(uint), passwordData.OldPassword, passwordData.NewPassword); err != nil {
+		response.FailWithError(c, err)
 		return
 	}
 
-	response.Success(c, gin.H{"message": "密码更新成功"})
+	response.Success(c, gin.H{"message": i18n.T(c, "message.password_updated")})
 }
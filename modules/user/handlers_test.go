@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/response"
+)
+
+// testServer 使用内存仓储的用户路由
+type testServer struct {
+	router  *gin.Engine
+	service *Service
+}
+
+// newTestServer 创建包含指定用户的测试服务
+func newTestServer(users ...User) *testServer {
+	gin.SetMode(gin.TestMode)
+	service := NewService(newMemoryRepository(users...))
+	router := gin.New()
+	registerRoutes(middleware.NewRouter(router.Group("/api/v1")), NewHandler(service))
+	return &testServer{router: router, service: service}
+}
+
+// do 以指定用户的身份发起请求，userID 为 0 时不携带令牌，返回状态码与响应体
+func (s *testServer) do(t *testing.T, method, path string, userID uint, userType, body string) (int, response.Response) {
+	t.Helper()
+	req := httptest.NewRequest(method, "/api/v1"+path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != 0 {
+		token, err := middleware.GenerateToken(userID, userType, 0, "")
+		if err != nil {
+			t.Fatalf("GenerateToken() 错误 = %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var resp response.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("响应不是 JSON: %s", w.Body.String())
+	}
+	return w.Code, resp
+}
+
+func TestHandlerAccessFields(t *testing.T) {
+	supplierID := uint(1)
+	s := newTestServer(User{Name: "vendor", Email: "v@example.com", UserType: "供应商", SupplierID: &supplierID})
+	id := "/users/1"
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		userType string
+		body     string
+		status   int
+		code     string
+	}{
+		{"PATCH 不能修改用户类型", http.MethodPatch, id, "管理员", `{"user_type": "员工"}`, http.StatusBadRequest, "FIELD_NOT_PATCHABLE"},
+		{"PATCH 不能修改所属供应商", http.MethodPatch, id, "管理员", `{"supplier_id": 2}`, http.StatusBadRequest, "FIELD_NOT_PATCHABLE"},
+		{"非管理员不能修改其他用户", http.MethodPut, id, "user", `{"name": "x", "email": "x@example.com", "phone": "1"}`, http.StatusForbidden, "FORBIDDEN"},
+		{"非管理员不能部分更新其他用户", http.MethodPatch, id, "员工", `{"email": "x@example.com"}`, http.StatusForbidden, "FORBIDDEN"},
+		{"非管理员不能删除用户", http.MethodDelete, id, "user", ``, http.StatusForbidden, "FORBIDDEN"},
+		{"普通用户不能查看用户列表", http.MethodGet, "/users", "user", ``, http.StatusForbidden, "FORBIDDEN"},
+		{"普通用户不能查看其他用户", http.MethodGet, id, "供应商", ``, http.StatusForbidden, "FORBIDDEN"},
+		{"非管理员不能修改访问权限", http.MethodPut, id + "/access", "供应商", `{"user_type": "管理员"}`, http.StatusForbidden, "FORBIDDEN"},
+		{"非管理员不能创建用户", http.MethodPost, "/users", "员工", `{"name": "x", "user_type": "管理员", "password": "secret1", "email": "x@example.com", "phone": "1"}`, http.StatusForbidden, "FORBIDDEN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := s.do(t, tt.method, tt.path, 2, tt.userType, tt.body)
+			if status != tt.status || resp.ErrorCode != tt.code {
+				t.Errorf("%s %s = (%d, %s), 期望 (%d, %s)", tt.method, tt.path, status, resp.ErrorCode, tt.status, tt.code)
+			}
+		})
+	}
+
+	// PUT 忽略请求中的用户类型与所属供应商
+	status, _ := s.do(t, http.MethodPut, id, 2, "管理员", `{"name": "vendor", "email": "v@example.com", "phone": "1", "user_type": "管理员", "supplier_id": 2}`)
+	user, _ := s.service.Get(context.Background(), 1)
+	if status != http.StatusOK || user.UserType != "供应商" || *user.SupplierID != 1 {
+		t.Errorf("PUT %s = %d, 用户类型 %s、供应商 %d, 期望保持 供应商、1", id, status, user.UserType, *user.SupplierID)
+	}
+
+	// 员工可以查看用户
+	if status, resp := s.do(t, http.MethodGet, id, 2, "员工", ""); status != http.StatusOK {
+		t.Errorf("员工 GET %s = (%d, %s), 期望 200", id, status, resp.ErrorCode)
+	}
+
+	// 管理员可以修改访问权限
+	status, _ = s.do(t, http.MethodPut, id+"/access", 2, "管理员", `{"user_type": "员工"}`)
+	user, _ = s.service.Get(context.Background(), 1)
+	if status != http.StatusOK || user.UserType != "员工" || user.SupplierID != nil {
+		t.Errorf("管理员 PUT %s/access = %d, 用户 %+v, 期望改为员工且无所属供应商", id, status, user)
+	}
+}
+
+func TestHandlerRegister(t *testing.T) {
+	s := newTestServer()
+
+	// 请求中的用户类型被忽略，注册的用户为默认类型
+	status, resp := s.do(t, http.MethodPost, "/auth/register", 0, "", `{"name": "alice", "password": "secret1", "email": "alice@example.com", "user_type": "管理员"}`)
+	if status != http.StatusOK {
+		t.Fatalf("POST /auth/register = (%d, %s), 期望 200", status, resp.ErrorCode)
+	}
+	user, err := s.service.GetByName(context.Background(), "alice")
+	if err != nil || user.UserType != "user" {
+		t.Fatalf("注册的用户 = (%+v, %v), 期望默认类型 user", user, err)
+	}
+
+	status, resp = s.do(t, http.MethodPost, "/auth/login", 0, "", `{"username": "alice", "password": "secret1"}`)
+	if status != http.StatusOK {
+		t.Errorf("注册后 POST /auth/login = (%d, %s), 期望 200", status, resp.ErrorCode)
+	}
+
+	status, resp = s.do(t, http.MethodPost, "/auth/register", 0, "", `{"name": "bob", "email": "bob@example.com"}`)
+	if status != http.StatusBadRequest {
+		t.Errorf("缺少密码 POST /auth/register = (%d, %s), 期望 400", status, resp.ErrorCode)
+	}
+}
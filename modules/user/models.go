@@ -7,16 +7,20 @@ import (
 // User 用户模型
 // @Description 用户信息
 type User struct {
-	ID        uint       `gorm:"primarykey" json:"id"`                                           // 主键ID
-	CreatedAt time.Time  `json:"created_at"`                                                     // 创建时间
-	UpdatedAt time.Time  `json:"updated_at"`                                                     // 更新时间
-	DeletedAt *time.Time `gorm:"index" json:"deleted_at"`                                        // 删除时间
-	Name      string     `gorm:"type:varchar(100);not null;uniqueIndex;comment:用户名" json:"name"` // 用户名
-	Email     string     `gorm:"type:varchar(100);uniqueIndex;comment:邮箱" json:"email"`          // 邮箱
-	Password  string     `gorm:"type:varchar(100);not null;comment:密码" json:"-"`                 // 密码
-	UserType  string     `gorm:"type:varchar(20);default:user;comment:用户类型" json:"user_type"`    // 用户类型（管理员、供应商、员工）
-	IsDelete  bool       `gorm:"default:false;comment:是否删除" json:"is_delete"`                    // 是否删除
-	Phone     string     `gorm:"size:20;comment:电话号码" json:"phone"`                              // 电话号码
+	ID           uint       `gorm:"primarykey" json:"id"`                                                                // 主键ID
+	CreatedAt    time.Time  `json:"created_at"`                                                                          // 创建时间
+	UpdatedAt    time.Time  `json:"updated_at"`                                                                          // 更新时间
+	DeletedAt    *time.Time `gorm:"index" json:"deleted_at"`                                                             // 删除时间
+	Name         string     `gorm:"type:varchar(100);not null;uniqueIndex;comment:用户名" json:"name"`                      // 用户名
+	Email        string     `gorm:"type:varchar(100);uniqueIndex;comment:邮箱" json:"email"`                               // 邮箱
+	Password     string     `gorm:"type:varchar(100);not null;comment:密码" json:"-"`                                      // 密码
+	UserType     string     `gorm:"type:varchar(20);default:user;comment:用户类型" json:"user_type"`                         // 用户类型（管理员、供应商、员工）
+	IsDelete     bool       `gorm:"default:false;comment:是否删除" json:"is_delete"`                                         // 是否删除
+	Phone        string     `gorm:"size:20;comment:电话号码" json:"phone"`                                                   // 电话号码
+	Language     string     `gorm:"type:varchar(10);comment:语言偏好" json:"language" binding:"omitempty,oneof=zh-CN en-US"` // 语言偏好
+	SupplierID   *uint      `gorm:"index;comment:所属供应商ID" json:"supplier_id"`                                            // 所属供应商ID，供应商类型的用户只能接收该供应商的数据
+	FailedLogins int        `gorm:"default:0;comment:连续登录失败次数" json:"-"`                                                 // 连续登录失败次数，登录成功或锁定后清零
+	LockedUntil  *time.Time `gorm:"comment:锁定截止时间" json:"locked_until"`                                                  // 锁定截止时间，为空或已过期表示未锁定
 }
 
 // LoginRequest 登录请求
@@ -26,24 +30,68 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required" example:"password123"` // 密码
 }
 
+// RegisterRequest 注册请求
+// @Description 用户注册的请求参数，注册的用户为默认类型，用户类型由管理员修改
+type RegisterRequest struct {
+	Name     string `json:"name" binding:"required,max=100" example:"张三"`                          // 用户名
+	Password string `json:"password" binding:"required" example:"123456"`                          // 密码，需符合密码策略
+	Email    string `json:"email" binding:"required,email,max=100" example:"zhangsan@example.com"` // 邮箱
+	Phone    string `json:"phone" binding:"max=20" example:"13800138000"`                          // 电话号码
+	Language string `json:"language" binding:"omitempty,oneof=zh-CN en-US" example:"zh-CN"`        // 语言偏好
+}
+
+// user 转换为用户模型，密码为明文，由服务校验密码策略后加密
+func (r *RegisterRequest) user() *User {
+	return &User{Name: r.Name, Password: r.Password, Email: r.Email, Phone: r.Phone, Language: r.Language}
+}
+
 // CreateUserRequest 创建用户请求
-// @Description 创建用户的请求参数
+// @Description 创建用户的请求参数，仅管理员可调用
 type CreateUserRequest struct {
-	Name     string `json:"name" binding:"required" example:"张三"`                          // 用户名
-	UserType string `json:"user_type" binding:"required,oneof=管理员 供应商 员工" example:"员工"`    // 用户类型
-	Password string `json:"password" binding:"required,min=6" example:"123456"`            // 密码
-	Email    string `json:"email" binding:"required,email" example:"zhangsan@example.com"` // 邮箱
-	Phone    string `json:"phone" binding:"required" example:"13800138000"`                // 电话号码
+	Name       string `json:"name" binding:"required" example:"张三"`                           // 用户名
+	UserType   string `json:"user_type" binding:"required,oneof=管理员 供应商 员工" example:"员工"`     // 用户类型
+	Password   string `json:"password" binding:"required,min=6" example:"123456"`             // 密码
+	Email      string `json:"email" binding:"required,email" example:"zhangsan@example.com"`  // 邮箱
+	Phone      string `json:"phone" binding:"required" example:"13800138000"`                 // 电话号码
+	Language   string `json:"language" binding:"omitempty,oneof=zh-CN en-US" example:"zh-CN"` // 语言偏好
+	SupplierID *uint  `json:"supplier_id" example:"1"`                                        // 所属供应商ID，供应商类型的用户需设置
+}
+
+// user 转换为用户模型，密码为明文，由服务校验密码策略后加密
+func (r *CreateUserRequest) user() *User {
+	return &User{
+		Name:       r.Name,
+		UserType:   r.UserType,
+		Password:   r.Password,
+		Email:      r.Email,
+		Phone:      r.Phone,
+		Language:   r.Language,
+		SupplierID: r.SupplierID,
+	}
 }
 
 // UpdateUserRequest 更新用户请求
-// @Description 更新用户的请求参数
+// @Description 更新用户的请求参数，用户类型与所属供应商只能由管理员通过修改访问权限接口变更，密码通过修改密码接口变更
 type UpdateUserRequest struct {
-	Name     string `json:"name" binding:"required" example:"张三"`                          // 用户名
-	UserType string `json:"user_type" binding:"required,oneof=管理员 供应商 员工" example:"员工"`    // 用户类型
-	Password string `json:"password,omitempty" binding:"omitempty,min=6" example:"123456"` // 密码（可选）
-	Email    string `json:"email" binding:"required,email" example:"zhangsan@example.com"` // 邮箱
-	Phone    string `json:"phone" binding:"required" example:"13800138000"`                // 电话号码
+	Name     string `json:"name" binding:"required" example:"张三"`                           // 用户名
+	Email    string `json:"email" binding:"required,email" example:"zhangsan@example.com"`  // 邮箱
+	Phone    string `json:"phone" binding:"required" example:"13800138000"`                 // 电话号码
+	Language string `json:"language" binding:"omitempty,oneof=zh-CN en-US" example:"en-US"` // 语言偏好
+}
+
+// apply 将请求中的字段写入用户
+func (r *UpdateUserRequest) apply(user *User) {
+	user.Name = r.Name
+	user.Email = r.Email
+	user.Phone = r.Phone
+	user.Language = r.Language
+}
+
+// UpdateAccessRequest 修改访问权限请求
+// @Description 修改用户类型与所属供应商的请求参数，仅管理员可调用
+type UpdateAccessRequest struct {
+	UserType   string `json:"user_type" binding:"required,oneof=管理员 供应商 员工" example:"供应商"` // 用户类型
+	SupplierID *uint  `json:"supplier_id" example:"1"`                                     // 所属供应商ID，供应商类型的用户需设置
 }
 
 // UpdatePasswordRequest 修改密码请求
@@ -56,26 +104,32 @@ type UpdatePasswordRequest struct {
 // UserResponse 用户响应
 // @Description 用户信息的响应格式
 type UserResponse struct {
-	ID        uint      `json:"id" example:"1"`                                 // 用户ID
-	Name      string    `json:"name" example:"张三"`                              // 用户名
-	UserType  string    `json:"user_type" example:"员工"`                         // 用户类型
-	Email     string    `json:"email" example:"zhangsan@example.com"`           // 邮箱
-	Phone     string    `json:"phone" example:"13800138000"`                    // 电话号码
-	IsDelete  bool      `json:"is_delete" example:"false"`                      // 是否删除
-	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00+08:00"` // 创建时间
-	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00+08:00"` // 更新时间
+	ID          uint       `json:"id" example:"1"`                                 // 用户ID
+	Name        string     `json:"name" example:"张三"`                              // 用户名
+	UserType    string     `json:"user_type" example:"员工"`                         // 用户类型
+	Email       string     `json:"email" example:"zhangsan@example.com"`           // 邮箱
+	Phone       string     `json:"phone" example:"13800138000"`                    // 电话号码
+	IsDelete    bool       `json:"is_delete" example:"false"`                      // 是否删除
+	Language    string     `json:"language" example:"zh-CN"`                       // 语言偏好
+	SupplierID  *uint      `json:"supplier_id" example:"1"`                        // 所属供应商ID
+	LockedUntil *time.Time `json:"locked_until"`                                   // 登录失败锁定截止时间，为空表示未锁定
+	CreatedAt   time.Time  `json:"created_at" example:"2024-01-01T00:00:00+08:00"` // 创建时间
+	UpdatedAt   time.Time  `json:"updated_at" example:"2024-01-01T00:00:00+08:00"` // 更新时间
 }
 
 // ToResponse 转换为响应格式
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Name:      u.Name,
-		UserType:  u.UserType,
-		Email:     u.Email,
-		Phone:     u.Phone,
-		IsDelete:  u.IsDelete,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:          u.ID,
+		Name:        u.Name,
+		UserType:    u.UserType,
+		Email:       u.Email,
+		Phone:       u.Phone,
+		IsDelete:    u.IsDelete,
+		Language:    u.Language,
+		SupplierID:  u.SupplierID,
+		LockedUntil: u.LockedUntil,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
 	}
 }
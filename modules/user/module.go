@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/settings"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module 用户管理模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "user" }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} { return []interface{}{&User{}} }
+
+// RegisterRoutes 实现 module.Module 接口
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) { RegisterRoutes(r, app.DB) }
+
+// Settings 实现 module.SettingsModule 接口
+func (Module) Settings() []settings.Definition { return Settings() }
+
+// Commands 实现 module.CommandModule 接口
+func (Module) Commands() []module.Command { return Commands() }
+
+// Seed 实现 module.Module 接口，用户表为空时创建默认管理员
+func (Module) Seed(ctx context.Context, db *gorm.DB) error {
+	var count int64
+	if err := db.WithContext(ctx).Model(&User{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		slog.InfoContext(ctx, "用户表已有数据，跳过创建默认管理员")
+		return nil
+	}
+
+	admin := User{
+		Name:     "evansun",
+		Email:    "admin@example.com",
+		Password: "test1234",
+		UserType: "admin",
+	}
+	if err := NewService(NewRepository(db)).Create(ctx, &admin); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "默认管理员创建成功", slog.String("name", admin.Name))
+	return nil
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "user.read", Description: "查看用户列表与详情", UserTypes: module.Staff},
+		{Code: "user.write", Description: "创建、修改与删除用户，修改用户类型与所属供应商", UserTypes: module.Admins},
+	}
+}
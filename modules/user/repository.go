@@ -1,93 +1,71 @@
 package user
 
 import (
-	"errors"
-
-	"erp_backend/pkg/database"
+	"context"
+	"time"
 
 	"gorm.io/gorm"
+
+	"erp_backend/pkg/repository"
 )
 
 // Repository 用户数据访问接口
 type Repository interface {
-	Create(user *User) error
-	GetByID(id uint) (*User, error)
-	GetByEmail(email string) (*User, error)
-	GetByName(name string) (*User, error)
-	GetAll() ([]User, error)
-	Update(user *User) error
-	Delete(id uint) error
+	repository.CRUD[User]
+	// GetByName 根据用户名获取用户，不存在时返回 repository.ErrNotFound
+	GetByName(ctx context.Context, name string) (*User, error)
+	// GetByEmail 根据邮箱获取用户，不存在时返回 repository.ErrNotFound
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	// RecordFailedLogin 原子地累加连续登录失败次数，达到 maxAttempts 时清零并锁定到 lockUntil，
+	// 返回更新后的失败次数与锁定截止时间；用户不存在时返回 repository.ErrNotFound
+	RecordFailedLogin(ctx context.Context, id uint, maxAttempts int, lockUntil time.Time) (int, *time.Time, error)
 }
 
-// repository 用户数据访问实现
-type repository struct {
-	db *gorm.DB
-}
-
-// NewRepository 创建用户repository
-func NewRepository() Repository {
-	return &repository{
-		db: database.GetDB(),
-	}
+// gormRepository 基于 GORM 的用户仓储
+type gormRepository struct {
+	repository.Gorm[User]
 }
 
-// Create 创建用户
-func (r *repository) Create(user *User) error {
-	return r.db.Create(user).Error
+// NewRepository 创建基于 GORM 的用户仓储
+func NewRepository(db *gorm.DB) Repository {
+	return gormRepository{Gorm: repository.NewGorm[User](db)}
 }
 
-// GetByID 根据ID获取用户
-func (r *repository) GetByID(id uint) (*User, error) {
+// GetByName 实现 Repository 接口
+func (r gormRepository) GetByName(ctx context.Context, name string) (*User, error) {
 	var user User
-	err := r.db.First(&user, id).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
+	if err := r.DB(ctx).Where("name = ?", name).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-// GetByEmail 根据邮箱获取用户
-func (r *repository) GetByEmail(email string) (*User, error) {
+// GetByEmail 实现 Repository 接口
+func (r gormRepository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
-	err := r.db.Where("email = ?", email).First(&user).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
+	if err := r.DB(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-// GetByName 根据名称获取用户
-func (r *repository) GetByName(name string) (*User, error) {
-	var user User
-	err := r.db.Where("name = ?", name).First(&user).Error
+// RecordFailedLogin 实现 Repository 接口，读取与写入在同一条语句中完成，并发的失败登录不会互相覆盖
+func (r gormRepository) RecordFailedLogin(ctx context.Context, id uint, maxAttempts int, lockUntil time.Time) (int, *time.Time, error) {
+	var rows []struct {
+		FailedLogins int
+		LockedUntil  *time.Time
+	}
+	err := r.DB(ctx).Raw(`UPDATE users SET
+		failed_logins = CASE WHEN failed_logins + 1 >= ? THEN 0 ELSE failed_logins + 1 END,
+		locked_until = CASE WHEN failed_logins + 1 >= ? THEN ? ELSE locked_until END
+		WHERE id = ? RETURNING failed_logins, locked_until`,
+		maxAttempts, maxAttempts, lockUntil, id,
+	).Scan(&rows).Error
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
-		return nil, err
+		return 0, nil, err
 	}
-	return &user, nil
-}
-
-// GetAll 获取所有用户
-func (r *repository) GetAll() ([]User, error) {
-	var users []User
-	err := r.db.Find(&users).Error
-	return users, err
-}
-
-// Update 更新用户
-func (r *repository) Update(user *User) error {
-	return r.db.Save(user).Error
-}
-
-// Delete 删除用户
-func (r *repository) Delete(id uint) error {
-	return r.db.Delete(&User{}, id).Error
+	if len(rows) == 0 {
+		return 0, nil, repository.ErrNotFound
+	}
+	return rows[0].FailedLogins, rows[0].LockedUntil, nil
 }
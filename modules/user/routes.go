@@ -1,10 +1,11 @@
 package user
 
 import (
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
 	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	"erp_backend/pkg/ratelimit"
 )
 
 // @title ERP系统 API
@@ -16,26 +17,34 @@ import (
 // @name Authorization
 
 // RegisterRoutes 注册用户相关路由
-func RegisterRoutes(r *gin.RouterGroup, db *gorm.DB) {
-	handler := NewHandler(db)
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	registerRoutes(r, NewHandler(NewService(NewRepository(db))))
+}
 
-	// 认证相关路由
-	auth := r.Group("/auth")
+// registerRoutes 注册处理器的路由
+func registerRoutes(r *middleware.Router, handler *Handler) {
+	// 认证相关路由，使用独立的限流额度防止暴力破解
+	auth := r.Group("/auth", middleware.RateLimit(ratelimit.PolicyLogin))
 	{
-		auth.POST("/login", handler.Login)       // @Summary 用户登录
-		auth.POST("/register", handler.Register) // @Summary 用户注册
+		auth.POST("/login", middleware.Public, handler.Login)       // @Summary 用户登录
+		auth.POST("/register", middleware.Public, handler.Register) // @Summary 用户注册
 	}
 
-	// 用户管理路由，需要 JWT 认证
-	users := r.Group("/users", middleware.JWTAuth())
+	// 用户管理路由，均需登录；查看用户列表需要 user.read 权限，创建、修改与删除任意用户需要 user.write 权限，
+	// 普通用户只能通过 /profile 与 /password 管理自己的资料
+	read, write := module.RequirePermission("user.read"), module.RequirePermission("user.write")
+	users := r.Group("/users")
 	{
-		users.GET("", handler.List)                    // @Summary 获取用户列表
-		users.GET("/:id", handler.Get)                 // @Summary 获取单个用户
-		users.POST("", handler.Create)                 // @Summary 创建用户
-		users.PUT("/:id", handler.Update)              // @Summary 更新用户
-		users.DELETE("/:id", handler.Delete)           // @Summary 删除用户
-		users.GET("/profile", handler.GetProfile)      // @Summary 获取个人资料
-		users.PUT("/profile", handler.UpdateProfile)   // @Summary 更新个人资料
-		users.PUT("/password", handler.UpdatePassword) // @Summary 修改密码
+		users.GET("", read, handler.List)                                        // @Summary 获取用户列表
+		users.GET("/:id", read, handler.Get)                                     // @Summary 获取单个用户
+		users.POST("", write, handler.Create)                                    // @Summary 创建用户
+		users.PUT("/:id/access", write, handler.UpdateAccess)                    // @Summary 修改用户访问权限
+		users.PUT("/:id", write, handler.Update)                                 // @Summary 更新用户
+		users.PATCH("/:id", write, handler.Patch)                                // @Summary 部分更新用户
+		users.DELETE("/:id", write, handler.Delete)                              // @Summary 删除用户
+		users.GET("/profile", middleware.Authenticated, handler.GetProfile)      // @Summary 获取个人资料
+		users.PUT("/profile", middleware.Authenticated, handler.UpdateProfile)   // @Summary 更新个人资料
+		users.PATCH("/profile", middleware.Authenticated, handler.PatchProfile)  // @Summary 部分更新个人资料
+		users.PUT("/password", middleware.Authenticated, handler.UpdatePassword) // @Summary 修改密码
 	}
 }
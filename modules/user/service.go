@@ -0,0 +1,313 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/repository"
+	"erp_backend/pkg/settings"
+)
+
+// Service 用户业务逻辑：登录注册、用户管理与个人资料，供 HTTP 接口与命令行共用
+// 返回的错误均为 *errcode.Error（补丁、绑定等回调返回的非业务错误除外）
+type Service struct {
+	repo Repository
+}
+
+// NewService 创建用户服务
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Login 校验用户名与密码并签发令牌，用户不存在与密码错误返回相同的错误
+// 连续输错密码达到 user.login_lockout 设置的次数后锁定账号，锁定期间即使密码正确也拒绝登录
+func (s *Service) Login(ctx context.Context, username, password string) (*LoginResponse, error) {
+	user, err := s.repo.GetByName(ctx, username)
+	if err != nil {
+		return nil, errcode.LoginFailed.New()
+	}
+
+	now := time.Now()
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return nil, errcode.AccountLocked.New(user.LockedUntil.Format(time.RFC3339))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		if err := s.recordFailedLogin(ctx, user, now); err != nil {
+			return nil, err
+		}
+		return nil, errcode.LoginFailed.New()
+	}
+	if err := s.clearLockout(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// 生成JWT token，所属供应商随令牌下发，修改后重新登录生效
+	var supplierID uint
+	if user.SupplierID != nil {
+		supplierID = *user.SupplierID
+	}
+	token, err := middleware.GenerateToken(user.ID, user.UserType, supplierID, user.Language)
+	if err != nil {
+		return nil, errcode.TokenGenerateFailed.New()
+	}
+
+	return &LoginResponse{Token: token, User: user.ToResponse()}, nil
+}
+
+// recordFailedLogin 原子地累加连续登录失败次数，达到锁定策略的次数时锁定账号并清零计数
+func (s *Service) recordFailedLogin(ctx context.Context, user *User, now time.Time) error {
+	lockout := settings.Get[LoginLockout](ctx, SettingLoginLockout)
+	if lockout.MaxAttempts <= 0 {
+		return nil
+	}
+
+	until := now.Add(time.Duration(lockout.Minutes) * time.Minute)
+	failed, lockedUntil, err := s.repo.RecordFailedLogin(ctx, user.ID, lockout.MaxAttempts, until)
+	if err != nil {
+		return errcode.FromDB(err, errcode.User.UpdateFailed)
+	}
+	user.FailedLogins, user.LockedUntil = failed, lockedUntil
+	return nil
+}
+
+// clearLockout 清零连续登录失败次数并解除锁定，无需变更时不写入
+func (s *Service) clearLockout(ctx context.Context, user *User) error {
+	if user.FailedLogins == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+	columns := map[string]interface{}{"failed_logins": 0, "locked_until": nil}
+	if err := s.repo.Update(ctx, user, columns); err != nil {
+		return errcode.FromDB(err, errcode.User.UpdateFailed)
+	}
+	return nil
+}
+
+// Register 注册用户，未指定用户类型时使用默认类型
+func (s *Service) Register(ctx context.Context, user *User) error {
+	// 检查用户名是否已存在
+	if _, err := s.repo.GetByName(ctx, user.Name); err == nil {
+		return errcode.UserNameDuplicate.New()
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return errcode.FromDB(err, errcode.User.CreateFailed)
+	}
+
+	// 设置默认用户类型
+	if user.UserType == "" {
+		user.UserType = "user"
+	}
+	return s.Create(ctx, user)
+}
+
+// List 分页查询用户，query 与 REST 列表接口的查询参数一致
+func (s *Service) List(ctx context.Context, query url.Values) (*pagination.Page, error) {
+	params, err := pagination.ParseValues(query, listConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := filter.ParseValues(query, listFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.repo.List(ctx, params, conditions)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.User.ListFailed)
+	}
+	return page, nil
+}
+
+// Get 查询单个用户
+func (s *Service) Get(ctx context.Context, id uint) (*User, error) {
+	user, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.User.NotFound)
+	}
+	return user, nil
+}
+
+// GetByName 按用户名查询用户
+func (s *Service) GetByName(ctx context.Context, name string) (*User, error) {
+	user, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, errcode.FromDB(err, errcode.User.NotFound)
+	}
+	return user, nil
+}
+
+// Create 创建用户，user.Password 为明文密码，需符合密码策略，保存前加密
+func (s *Service) Create(ctx context.Context, user *User) error {
+	if err := checkPassword(ctx, user.Password); err != nil {
+		return err
+	}
+	hashed, err := hashPassword(user.Password)
+	if err != nil {
+		return err
+	}
+	user.Password = hashed
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		return errcode.FromDB(err, errcode.User.CreateFailed)
+	}
+	return nil
+}
+
+// Update 全量更新用户，bind 将新的值写入加载的用户
+func (s *Service) Update(ctx context.Context, id uint, bind func(*User) error) (*User, error) {
+	return s.save(ctx, id, errcode.User.UpdateFailed, bind)
+}
+
+// UpdateProfile 全量更新个人资料，bind 将新的值写入加载的用户
+func (s *Service) UpdateProfile(ctx context.Context, id uint, bind func(*User) error) (*User, error) {
+	return s.save(ctx, id, errcode.ProfileUpdateFailed, bind)
+}
+
+// save 加载用户，bind 写入新的值后保存全部字段
+func (s *Service) save(ctx context.Context, id uint, failed errcode.Code, bind func(*User) error) (*User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := bind(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, user); err != nil {
+		return nil, errcode.FromDB(err, failed)
+	}
+	return user, nil
+}
+
+// Patch 部分更新用户，apply 将补丁应用到加载的用户并返回变更的列
+func (s *Service) Patch(ctx context.Context, id uint, apply func(*User) (map[string]interface{}, error)) (*User, error) {
+	return s.patch(ctx, id, errcode.User.UpdateFailed, apply)
+}
+
+// PatchProfile 部分更新个人资料，apply 将补丁应用到加载的用户并返回变更的列
+func (s *Service) PatchProfile(ctx context.Context, id uint, apply func(*User) (map[string]interface{}, error)) (*User, error) {
+	return s.patch(ctx, id, errcode.ProfileUpdateFailed, apply)
+}
+
+// patch 加载用户并应用补丁，只写入变更的列
+func (s *Service) patch(ctx context.Context, id uint, failed errcode.Code, apply func(*User) (map[string]interface{}, error)) (*User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := apply(user)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) > 0 {
+		if err := s.repo.Update(ctx, user, columns); err != nil {
+			return nil, errcode.FromDB(err, failed)
+		}
+	}
+	return user, nil
+}
+
+// UpdatePassword 校验旧密码后修改密码，新密码需符合密码策略
+func (s *Service) UpdatePassword(ctx context.Context, id uint, oldPassword, newPassword string) error {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(oldPassword)); err != nil {
+		return errcode.OldPasswordIncorrect.New()
+	}
+	if err := checkPassword(ctx, newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	user.Password = hashed
+	if err := s.repo.Update(ctx, user, map[string]interface{}{"password": hashed}); err != nil {
+		return errcode.FromDB(err, errcode.PasswordUpdateFailed)
+	}
+	return nil
+}
+
+// ResetPassword 不校验旧密码直接设置新密码，供管理员重置遗忘的密码，新密码需符合密码策略
+func (s *Service) ResetPassword(ctx context.Context, id uint, password string) error {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkPassword(ctx, password); err != nil {
+		return err
+	}
+
+	hashed, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, user, map[string]interface{}{"password": hashed}); err != nil {
+		return errcode.FromDB(err, errcode.PasswordUpdateFailed)
+	}
+	return nil
+}
+
+// SetUserType 修改用户类型，新签发的令牌生效
+func (s *Service) SetUserType(ctx context.Context, id uint, userType string) (*User, error) {
+	return s.patch(ctx, id, errcode.User.UpdateFailed, func(user *User) (map[string]interface{}, error) {
+		if user.UserType == userType {
+			return nil, nil
+		}
+		user.UserType = userType
+		return map[string]interface{}{"user_type": userType}, nil
+	})
+}
+
+// SetAccess 修改用户类型与所属供应商，新签发的令牌生效
+func (s *Service) SetAccess(ctx context.Context, id uint, userType string, supplierID *uint) (*User, error) {
+	return s.patch(ctx, id, errcode.User.UpdateFailed, func(user *User) (map[string]interface{}, error) {
+		user.UserType = userType
+		user.SupplierID = supplierID
+		return map[string]interface{}{"user_type": userType, "supplier_id": supplierID}, nil
+	})
+}
+
+// Unlock 解除登录失败锁定并清零失败次数，供管理员在锁定到期前恢复账号
+func (s *Service) Unlock(ctx context.Context, id uint) (*User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.clearLockout(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Delete 删除用户
+func (s *Service) Delete(ctx context.Context, id uint) error {
+	if _, err := s.repo.Delete(ctx, id); err != nil {
+		return errcode.FromDB(err, errcode.User.DeleteFailed)
+	}
+	return nil
+}
+
+// hashPassword 加密密码
+func hashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errcode.PasswordHashFailed.New()
+	}
+	return string(hashed), nil
+}
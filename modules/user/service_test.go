@@ -0,0 +1,215 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/repository"
+	"erp_backend/pkg/settings"
+)
+
+// memoryRepository 基于内存的用户仓储
+type memoryRepository struct {
+	*repository.Memory[User]
+	mu *sync.Mutex // 保证累加登录失败次数的读取与写入不被并发请求打断
+}
+
+// newMemoryRepository 创建包含指定用户的内存仓储
+func newMemoryRepository(users ...User) memoryRepository {
+	return memoryRepository{Memory: repository.NewMemory(users...), mu: &sync.Mutex{}}
+}
+
+// RecordFailedLogin 实现 Repository 接口
+func (r memoryRepository) RecordFailedLogin(ctx context.Context, id uint, maxAttempts int, lockUntil time.Time) (int, *time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, err := r.Get(ctx, id)
+	if err != nil {
+		return 0, nil, err
+	}
+	user.FailedLogins++
+	if user.FailedLogins >= maxAttempts {
+		user.FailedLogins = 0
+		user.LockedUntil = &lockUntil
+	}
+	if err := r.Save(ctx, user); err != nil {
+		return 0, nil, err
+	}
+	return user.FailedLogins, user.LockedUntil, nil
+}
+
+// GetByName 实现 Repository 接口
+func (r memoryRepository) GetByName(_ context.Context, name string) (*User, error) {
+	return r.first(func(u *User) bool { return u.Name == name })
+}
+
+// GetByEmail 实现 Repository 接口
+func (r memoryRepository) GetByEmail(_ context.Context, email string) (*User, error) {
+	return r.first(func(u *User) bool { return u.Email == email })
+}
+
+// first 返回第一个满足条件的用户，不存在时返回 repository.ErrNotFound
+func (r memoryRepository) first(match func(*User) bool) (*User, error) {
+	users := r.Find(match)
+	if len(users) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &users[0], nil
+}
+
+// newTestService 创建使用内存仓储的用户服务
+func newTestService() *Service {
+	return NewService(newMemoryRepository())
+}
+
+// errorCode 返回业务错误码，非业务错误时返回空的错误码
+func errorCode(err error) errcode.Code {
+	var e *errcode.Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return errcode.Code{}
+}
+
+func TestServiceRegister(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService()
+
+	user := &User{Name: "alice", Email: "alice@example.com", Password: "secret1"}
+	if err := s.Register(ctx, user); err != nil {
+		t.Fatalf("Register() 错误 = %v", err)
+	}
+	if user.UserType != "user" {
+		t.Errorf("Register() 用户类型 = %q, 期望默认类型 user", user.UserType)
+	}
+	if user.Password == "secret1" {
+		t.Error("Register() 期望保存加密后的密码")
+	}
+
+	err := s.Register(ctx, &User{Name: "alice", Email: "other@example.com", Password: "secret2"})
+	if code := errorCode(err); code != errcode.UserNameDuplicate {
+		t.Errorf("重复用户名 Register() 错误 = %v, 期望 %s", err, errcode.UserNameDuplicate.Key)
+	}
+}
+
+func TestServiceUpdatePassword(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService()
+	user := &User{Name: "alice", Password: "secret1"}
+	if err := s.Register(ctx, user); err != nil {
+		t.Fatalf("Register() 错误 = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		want        errcode.Code
+	}{
+		{"原密码错误", "wrong", "secret2", errcode.OldPasswordIncorrect},
+		{"修改成功", "secret1", "secret2", errcode.Code{}},
+		{"修改后原密码失效", "secret1", "secret3", errcode.OldPasswordIncorrect},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.UpdatePassword(ctx, user.ID, tt.oldPassword, tt.newPassword)
+			if code := errorCode(err); code != tt.want || err != nil && tt.want == (errcode.Code{}) {
+				t.Errorf("UpdatePassword() 错误 = %v, 期望 %q", err, tt.want.Key)
+			}
+		})
+	}
+
+	if _, err := s.Login(ctx, "alice", "secret2"); errorCode(err) == errcode.LoginFailed {
+		t.Error("Login() 使用新密码期望登录成功")
+	}
+	if _, err := s.Login(ctx, "alice", "secret1"); errorCode(err) != errcode.LoginFailed {
+		t.Errorf("Login() 使用原密码错误 = %v, 期望 %s", err, errcode.LoginFailed.Key)
+	}
+}
+
+// useDefaultSettings 设置全局设置存储，数据库不可达，读取时回退为用户模块声明的默认值
+func useDefaultSettings(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1 connect_timeout=1"}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+	store := settings.NewStore(db, &config.SettingsConfig{CacheTTL: time.Hour})
+	store.Declare(Settings()...)
+	settings.SetStore(store)
+	t.Cleanup(func() { settings.SetStore(nil) })
+}
+
+func TestServiceLoginLockout(t *testing.T) {
+	useDefaultSettings(t)
+	ctx := context.Background()
+	s := newTestService()
+	user := &User{Name: "alice", Password: "secret1"}
+	if err := s.Register(ctx, user); err != nil {
+		t.Fatalf("Register() 错误 = %v", err)
+	}
+
+	// 默认策略连续输错 5 次后锁定
+	for i := 0; i < 5; i++ {
+		if _, err := s.Login(ctx, "alice", "wrong"); errorCode(err) != errcode.LoginFailed {
+			t.Fatalf("第 %d 次输错密码 Login() 错误 = %v, 期望 %s", i+1, err, errcode.LoginFailed.Key)
+		}
+	}
+	if _, err := s.Login(ctx, "alice", "secret1"); errorCode(err) != errcode.AccountLocked {
+		t.Fatalf("锁定期间 Login() 错误 = %v, 期望 %s", err, errcode.AccountLocked.Key)
+	}
+
+	unlocked, err := s.Unlock(ctx, user.ID)
+	if err != nil || unlocked.LockedUntil != nil || unlocked.FailedLogins != 0 {
+		t.Fatalf("Unlock() = (%+v, %v), 期望解除锁定并清零失败次数", unlocked, err)
+	}
+	if _, err := s.Login(ctx, "alice", "secret1"); errorCode(err) == errcode.LoginFailed || errorCode(err) == errcode.AccountLocked {
+		t.Errorf("解锁后 Login() 错误 = %v, 期望登录成功", err)
+	}
+
+	// 登录成功清零失败次数，之前的失败不计入下一次锁定
+	for i := 0; i < 4; i++ {
+		s.Login(ctx, "alice", "wrong")
+	}
+	s.Login(ctx, "alice", "secret1")
+	s.Login(ctx, "alice", "wrong")
+	if _, err := s.Login(ctx, "alice", "secret1"); errorCode(err) == errcode.AccountLocked {
+		t.Error("登录成功后失败次数期望清零")
+	}
+}
+
+func TestServiceLoginLockoutConcurrent(t *testing.T) {
+	useDefaultSettings(t)
+	ctx := context.Background()
+	s := newTestService()
+	user := &User{Name: "alice", Password: "secret1"}
+	if err := s.Register(ctx, user); err != nil {
+		t.Fatalf("Register() 错误 = %v", err)
+	}
+
+	// 同时输错 5 次，累加不能互相覆盖，最后一次失败后账号应被锁定
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Login(ctx, "alice", "wrong")
+		}()
+	}
+	wg.Wait()
+
+	if _, err := s.Login(ctx, "alice", "secret1"); errorCode(err) != errcode.AccountLocked {
+		t.Errorf("并发输错后 Login() 错误 = %v, 期望 %s", err, errcode.AccountLocked.Key)
+	}
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+	"unicode"
+	"unicode/utf8"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/settings"
+)
+
+// 用户模块的设置键
+const (
+	SettingPasswordPolicy = "user.password_policy" // 密码策略
+	SettingLoginLockout   = "user.login_lockout"   // 登录失败锁定策略
+)
+
+// PasswordPolicy 密码策略，创建用户、修改与重置密码时检查，已有密码不受影响
+type PasswordPolicy struct {
+	MinLength     int  `json:"min_length"`     // 最小长度
+	RequireLetter bool `json:"require_letter"` // 是否需包含字母
+	RequireDigit  bool `json:"require_digit"`  // 是否需包含数字
+	RequireSymbol bool `json:"require_symbol"` // 是否需包含字母和数字以外的符号
+}
+
+// LoginLockout 登录失败锁定策略，连续输错密码达到次数后锁定账号，到期或执行 user unlock 后解除
+type LoginLockout struct {
+	MaxAttempts int `json:"max_attempts"` // 锁定前允许连续输错密码的次数，0 表示不锁定
+	Minutes     int `json:"minutes"`      // 锁定时长（分钟）
+}
+
+// Settings 用户模块声明的设置项
+func Settings() []settings.Definition {
+	return []settings.Definition{
+		{
+			Key:         SettingPasswordPolicy,
+			Description: "密码策略，创建用户、修改与重置密码时检查",
+			Schema: &settings.Schema{
+				Type: "object",
+				Properties: map[string]*settings.Schema{
+					"min_length":     {Type: "integer", Minimum: settings.Float(1), Maximum: settings.Float(72)},
+					"require_letter": {Type: "boolean"},
+					"require_digit":  {Type: "boolean"},
+					"require_symbol": {Type: "boolean"},
+				},
+				Required:             []string{"min_length", "require_letter", "require_digit", "require_symbol"},
+				AdditionalProperties: settings.Bool(false),
+			},
+			Default: PasswordPolicy{MinLength: 6},
+		},
+		{
+			Key:         SettingLoginLockout,
+			Description: "登录失败锁定策略，连续输错密码达到次数后锁定账号",
+			Schema: &settings.Schema{
+				Type: "object",
+				Properties: map[string]*settings.Schema{
+					"max_attempts": {Type: "integer", Minimum: settings.Float(0)},
+					"minutes":      {Type: "integer", Minimum: settings.Float(1)},
+				},
+				Required:             []string{"max_attempts", "minutes"},
+				AdditionalProperties: settings.Bool(false),
+			},
+			Default: LoginLockout{MaxAttempts: 5, Minutes: 15},
+		},
+	}
+}
+
+// checkPassword 检查明文密码是否符合当前的密码策略
+func checkPassword(ctx context.Context, password string) error {
+	policy := settings.Get[PasswordPolicy](ctx, SettingPasswordPolicy)
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		return errcode.PasswordTooShort.New(policy.MinLength)
+	}
+
+	var letter, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	switch {
+	case policy.RequireLetter && !letter:
+		return errcode.PasswordLetterRequired.New()
+	case policy.RequireDigit && !digit:
+		return errcode.PasswordDigitRequired.New()
+	case policy.RequireSymbol && !symbol:
+		return errcode.PasswordSymbolRequired.New()
+	}
+	return nil
+}
@@ -0,0 +1,457 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/response"
+	hook "erp_backend/pkg/webhook"
+)
+
+// listConfig 订阅列表排序配置
+var listConfig = pagination.Config{
+	SortFields:  []string{"name", "created_at", "updated_at"},
+	DefaultSort: "-created_at",
+}
+
+// listFilters 订阅列表筛选字段
+var listFilters = filter.Fields{
+	"id":         filter.Number,
+	"name":       filter.String,
+	"url":        filter.String,
+	"is_enabled": filter.Bool,
+	"created_at": filter.Time,
+	"updated_at": filter.Time,
+}
+
+// deliveryListConfig 投递记录列表排序配置
+var deliveryListConfig = pagination.Config{
+	SortFields:  []string{"created_at", "updated_at", "next_attempt_at", "attempts"},
+	DefaultSort: "-created_at",
+}
+
+// deliveryListFilters 投递记录列表筛选字段
+var deliveryListFilters = filter.Fields{
+	"id":               filter.Number,
+	"subscription_id":  filter.Number,
+	"event_id":         filter.String,
+	"event":            filter.String,
+	"status":           filter.String,
+	"attempts":         filter.Number,
+	"last_status_code": filter.Number,
+	"next_attempt_at":  filter.Time,
+	"delivered_at":     filter.Time,
+	"created_at":       filter.Time,
+}
+
+type Handler struct {
+	db  *gorm.DB
+	cfg *config.WebhookConfig
+}
+
+func NewHandler(db *gorm.DB) *Handler {
+	return &Handler{db: db, cfg: config.GetWebhookConfig()}
+}
+
+// Create 创建订阅
+// @Summary 创建 Webhook 订阅
+// @Description 注册接收地址与事件类型。投递请求带有 X-Webhook-Signature 签名头，值为 sha256=HMAC-SHA256(密钥, X-Webhook-Timestamp + "." + 请求体) 的十六进制；密钥只在此处返回一次
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body SubscriptionRequest true "订阅信息"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=SecretResponse} "创建成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 401 {object} response.Response "未登录"
+// @Failure 403 {object} response.Response "权限不足"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req SubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+	if err := h.validate(c, &req); err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = hook.NewSecret(); err != nil {
+			response.FailWithError(c, err)
+			return
+		}
+	}
+
+	subscription := hook.Subscription{Secret: secret, IsEnabled: true}
+	apply(&subscription, &req)
+	if err := h.db.WithContext(c).Create(&subscription).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.CreateFailed)
+		return
+	}
+
+	response.Success(c, SecretResponse{Subscription: subscription, Secret: secret})
+}
+
+// List 获取订阅列表
+// @Summary 获取 Webhook 订阅列表
+// @Description 获取 Webhook 订阅列表，不含签名密钥
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[is_enabled]=true"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]hook.Subscription}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks [get]
+func (h *Handler) List(c *gin.Context) {
+	params, err := pagination.Parse(c, listConfig)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	conditions, err := filter.Parse(c, listFilters)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	var subscriptions []hook.Subscription
+	page, err := params.Find(conditions.Apply(h.db.WithContext(c).Model(&hook.Subscription{})), &subscriptions)
+	if err != nil {
+		response.DBError(c, err, errcode.Webhook.ListFailed)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// Get 获取单个订阅
+// @Summary 获取单个 Webhook 订阅
+// @Description 根据ID获取 Webhook 订阅，不含签名密钥
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "订阅ID"
+// @Success 200 {object} response.Response{data=hook.Subscription} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "订阅不存在"
+// @Router /webhooks/{id} [get]
+func (h *Handler) Get(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	var subscription hook.Subscription
+	if err := h.db.WithContext(c).First(&subscription, id).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.NotFound)
+		return
+	}
+
+	response.Success(c, subscription)
+}
+
+// Update 更新订阅
+// @Summary 更新 Webhook 订阅
+// @Description 更新 Webhook 订阅；传入 secret 时更换签名密钥并在响应中返回
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "订阅ID"
+// @Param data body SubscriptionRequest true "订阅信息"
+// @Success 200 {object} response.Response{data=SecretResponse} "更新成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "订阅不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	var subscription hook.Subscription
+	if err := h.db.WithContext(c).First(&subscription, id).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.NotFound)
+		return
+	}
+
+	var req SubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BindError(c, err)
+		return
+	}
+	if err := h.validate(c, &req); err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	apply(&subscription, &req)
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	if err := h.db.WithContext(c).Save(&subscription).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.UpdateFailed)
+		return
+	}
+
+	response.Success(c, SecretResponse{Subscription: subscription, Secret: req.Secret})
+}
+
+// Delete 删除订阅
+// @Summary 删除 Webhook 订阅
+// @Description 删除 Webhook 订阅，未投递的记录将进入死信
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "订阅ID"
+// @Success 200 {object} response.Response "删除成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	if err := h.db.WithContext(c).Delete(&hook.Subscription{}, id).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.DeleteFailed)
+		return
+	}
+
+	response.Success(c, gin.H{"message": i18n.T(c, "message.deleted")})
+}
+
+// ToggleStatus 切换订阅状态
+// @Summary 切换 Webhook 订阅状态
+// @Description 启用/停用 Webhook 订阅，停用期间不产生新的投递
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "订阅ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=hook.Subscription} "切换成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "订阅不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks/{id}/toggle [patch]
+func (h *Handler) ToggleStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	var subscription hook.Subscription
+	if err := h.db.WithContext(c).First(&subscription, id).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.NotFound)
+		return
+	}
+
+	subscription.IsEnabled = !subscription.IsEnabled
+	if err := h.db.WithContext(c).Model(&subscription).Update("is_enabled", subscription.IsEnabled).Error; err != nil {
+		response.DBError(c, err, errcode.Webhook.ToggleFailed)
+		return
+	}
+
+	response.Success(c, subscription)
+}
+
+// Events 获取事件类型
+// @Summary 获取可订阅的事件类型
+// @Description 获取全部事件类型，订阅时还可使用 资源.*（如 product.*）或 * 订阅多个事件
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} response.Response{data=[]string} "获取成功"
+// @Router /webhooks/events [get]
+func (h *Handler) Events(c *gin.Context) {
+	response.Success(c, hook.Events())
+}
+
+// ListDeliveries 获取投递记录
+// @Summary 获取 Webhook 投递记录
+// @Description 获取投递记录，可按订阅、事件、状态（pending、succeeded、dead）筛选
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[status]=pending"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]hook.Delivery}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks/deliveries [get]
+func (h *Handler) ListDeliveries(c *gin.Context) {
+	h.listDeliveries(c, h.db.WithContext(c).Model(&hook.Delivery{}))
+}
+
+// ListDeadLetters 获取死信
+// @Summary 获取 Webhook 死信
+// @Description 获取超过最大投递次数仍未成功的投递记录，可通过重新投递接口再次发送
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Param cursor query string false "游标，传入后忽略页码"
+// @Param sort query string false "排序字段，多个用逗号分隔，前缀-表示降序"
+// @Param filter query string false "筛选条件，格式 filter[字段][操作符]=值，如 filter[subscription_id]=1"
+// @Success 200 {object} response.Response{data=pagination.Page{items=[]hook.Delivery}} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks/dead-letters [get]
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	h.listDeliveries(c, h.db.WithContext(c).Model(&hook.Delivery{}).Where("status = ?", hook.StatusDead))
+}
+
+// listDeliveries 在 query 的基础上分页筛选投递记录
+func (h *Handler) listDeliveries(c *gin.Context, query *gorm.DB) {
+	params, err := pagination.Parse(c, deliveryListConfig)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	conditions, err := filter.Parse(c, deliveryListFilters)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	var deliveries []hook.Delivery
+	page, err := params.Find(conditions.Apply(query), &deliveries)
+	if err != nil {
+		response.DBError(c, err, errcode.WebhookDelivery.ListFailed)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// GetDelivery 获取投递详情
+// @Summary 获取 Webhook 投递详情
+// @Description 获取投递记录及每次投递的日志（响应状态码、错误、耗时、响应内容）
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "投递记录ID"
+// @Success 200 {object} response.Response{data=hook.Delivery} "获取成功"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "投递记录不存在"
+// @Router /webhooks/deliveries/{id} [get]
+func (h *Handler) GetDelivery(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	var delivery hook.Delivery
+	err = h.db.WithContext(c).
+		Preload("Logs", func(db *gorm.DB) *gorm.DB { return db.Order("attempt") }).
+		First(&delivery, id).Error
+	if err != nil {
+		response.DBError(c, err, errcode.WebhookDelivery.NotFound)
+		return
+	}
+
+	response.Success(c, delivery)
+}
+
+// Redeliver 重新投递
+// @Summary 重新投递 Webhook
+// @Description 将投递记录（通常是死信）重置为待投递并清零投递次数，由后台立即重新发送
+// @Tags Webhook 管理
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "投递记录ID"
+// @Param Idempotency-Key header string false "幂等键，重试时携带相同的值，避免重复执行"
+// @Success 200 {object} response.Response{data=hook.Delivery} "已重新排队"
+// @Failure 400 {object} response.Response "请求参数错误"
+// @Failure 404 {object} response.Response "投递记录不存在"
+// @Failure 500 {object} response.Response "服务器内部错误"
+// @Router /webhooks/deliveries/{id}/redeliver [post]
+func (h *Handler) Redeliver(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return
+	}
+
+	delivery, err := hook.Redeliver(c, h.db, uint(id))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		response.Fail(c, errcode.WebhookDelivery.NotFound)
+		return
+	}
+	if err != nil {
+		response.DBError(c, err, errcode.WebhookRedeliverFailed)
+		return
+	}
+
+	response.Success(c, delivery)
+}
+
+// validate 校验接收地址与事件类型，接收地址不能指向本机、内网或云平台元数据地址
+func (h *Handler) validate(ctx context.Context, req *SubscriptionRequest) error {
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errcode.ValidationFailed.WithDetails(errcode.FieldError{Field: "url", Rule: "url"})
+	}
+	if err := hook.CheckURL(ctx, req.URL, h.cfg.AllowPrivateNetworks); err != nil {
+		return errcode.WebhookURLForbidden.New(err.Error())
+	}
+	for _, event := range req.Events {
+		if !hook.ValidPattern(event) {
+			return errcode.WebhookEventInvalid.New(event)
+		}
+	}
+	return nil
+}
+
+// apply 将请求参数写入订阅，签名密钥单独处理
+func apply(subscription *hook.Subscription, req *SubscriptionRequest) {
+	subscription.Name = req.Name
+	subscription.URL = req.URL
+	subscription.Events = req.Events
+	subscription.Remark = req.Remark
+	if req.IsEnabled != nil {
+		subscription.IsEnabled = *req.IsEnabled
+	}
+}
@@ -0,0 +1,21 @@
+package webhook
+
+import hook "erp_backend/pkg/webhook"
+
+// SubscriptionRequest 创建、更新订阅的请求参数
+// @Description Webhook 订阅的请求参数
+type SubscriptionRequest struct {
+	Name      string   `json:"name" binding:"required,max=100" example:"商城同步"`                                  // 订阅名称
+	URL       string   `json:"url" binding:"required,url,max=500" example:"https://shop.example.com/hooks/erp"` // 接收地址，需为 http 或 https
+	Events    []string `json:"events" binding:"required,min=1,dive,required" example:"product.*,link.toggled"`  // 订阅的事件类型，支持 资源.* 与 *
+	Secret    string   `json:"secret,omitempty" binding:"omitempty,min=16,max=100"`                             // 签名密钥，创建时为空则自动生成，更新时为空表示不更换
+	Remark    string   `json:"remark" example:"商品与链接变更推送到商城"`                                                   // 备注
+	IsEnabled *bool    `json:"is_enabled" example:"true"`                                                       // 是否启用，默认启用
+}
+
+// SecretResponse 带签名密钥的订阅信息，密钥只在创建或更换时返回
+// @Description 订阅信息与签名密钥
+type SecretResponse struct {
+	hook.Subscription
+	Secret string `json:"secret" example:"whsec_3f1c..."` // 签名密钥，请妥善保存
+}
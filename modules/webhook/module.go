@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+	hook "erp_backend/pkg/webhook"
+)
+
+func init() {
+	module.Register(Module{})
+}
+
+// Module Webhook 模块
+type Module struct {
+	module.Base
+}
+
+// Name 实现 module.Module 接口
+func (Module) Name() string { return "webhook" }
+
+// Models 实现 module.Module 接口
+func (Module) Models() []interface{} {
+	return []interface{}{&hook.Subscription{}, &hook.Delivery{}, &hook.Attempt{}}
+}
+
+// RegisterRoutes 实现 module.Module 接口，可订阅的事件类型取自已启用模块声明的领域事件
+func (Module) RegisterRoutes(r *middleware.Router, app *module.App) {
+	hook.SetEvents(module.EventNames())
+	RegisterRoutes(r, app.DB)
+}
+
+// Jobs 实现 module.Module 接口，订阅领域事件并启动后台投递与过期记录清理
+// 未开启 WEBHOOK_ENABLED 时业务事件不产生投递记录
+func (Module) Jobs(app *module.App) []module.Job {
+	cfg := config.GetWebhookConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	hook.SetEvents(module.EventNames())
+	dispatcher := hook.NewDispatcher(app.DB, cfg)
+	hook.SetDispatcher(dispatcher)
+	app.Relay.SubscribeAll("webhook", dispatcher.Handle)
+	return []module.Job{
+		{Name: "webhook-dispatcher", Run: dispatcher.Run},
+		{Name: "webhook-cleanup", Run: dispatcher.Cleanup},
+	}
+}
+
+// Permissions 实现 module.Module 接口
+func (Module) Permissions() []module.Permission {
+	return []module.Permission{
+		{Code: "webhook.manage", Description: "管理 Webhook 订阅并查看、重新投递投递记录", UserTypes: module.Admins},
+	}
+}
@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/module"
+)
+
+// RegisterRoutes 注册 Webhook 相关路由，仅管理员可访问
+func RegisterRoutes(r *middleware.Router, db *gorm.DB) {
+	handler := NewHandler(db)
+
+	manage := module.RequirePermission("webhook.manage")
+	webhooks := r.Group("/webhooks")
+	{
+		webhooks.GET("/events", manage, handler.Events)
+		webhooks.GET("/deliveries", manage, handler.ListDeliveries)
+		webhooks.GET("/deliveries/:id", manage, handler.GetDelivery)
+		webhooks.POST("/deliveries/:id/redeliver", manage, handler.Redeliver)
+		webhooks.GET("/dead-letters", manage, handler.ListDeadLetters)
+
+		webhooks.POST("", manage, handler.Create)
+		webhooks.GET("", manage, handler.List)
+		webhooks.GET("/:id", manage, handler.Get)
+		webhooks.PUT("/:id", manage, handler.Update)
+		webhooks.DELETE("/:id", manage, handler.Delete)
+		webhooks.PATCH("/:id/toggle", manage, handler.ToggleStatus)
+	}
+}
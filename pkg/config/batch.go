@@ -0,0 +1,15 @@
+package config
+
+// BatchConfig 批量接口配置
+type BatchConfig struct {
+	MaxOperations int // 单个批量请求最多包含的操作数
+	MaxBodySize   int // 请求体上限（字节）
+}
+
+// GetBatchConfig 获取批量接口配置
+func GetBatchConfig() *BatchConfig {
+	return &BatchConfig{
+		MaxOperations: getEnvInt("BATCH_MAX_OPERATIONS", 100),
+		MaxBodySize:   getEnvInt("BATCH_MAX_BODY_SIZE", 10<<20),
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"sort"
+	"sync"
+)
+
+// invalid 格式错误而回退为默认值的环境变量，供 config check 命令报告
+var invalid = struct {
+	mu     sync.Mutex
+	values map[string]string
+}{values: make(map[string]string)}
+
+// recordInvalid 记录格式错误的环境变量，未设置（空值）时忽略
+func recordInvalid(key, value string) {
+	if value == "" {
+		return
+	}
+	invalid.mu.Lock()
+	defer invalid.mu.Unlock()
+	invalid.values[key] = value
+}
+
+// InvalidValues 返回已读取过的配置中格式错误而回退为默认值的环境变量，格式为 KEY=value，按变量名排序
+// 只包含调用过对应 Get*Config 的配置项
+func InvalidValues() []string {
+	invalid.mu.Lock()
+	defer invalid.mu.Unlock()
+
+	values := make([]string, 0, len(invalid.values))
+	for key, value := range invalid.values {
+		values = append(values, key+"="+value)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// CORSConfig 跨域配置
+type CORSConfig struct {
+	AllowedOrigins   []string      // 允许的来源，支持 * 与 https://*.example.com 形式的子域名通配
+	AllowedMethods   []string      // 允许的请求方法
+	AllowedHeaders   []string      // 允许的请求头
+	ExposedHeaders   []string      // 浏览器可读取的响应头
+	AllowCredentials bool          // 是否允许携带 Cookie 等凭据
+	MaxAge           time.Duration // 预检结果缓存时间
+}
+
+// CORSGroup 使用独立跨域配置的路由组
+type CORSGroup struct {
+	Name     string      // 配置名，对应环境变量前缀 CORS_{NAME}_
+	Prefixes []string    // 路由路径前缀
+	Config   *CORSConfig // 跨域配置
+}
+
+// GetCORSConfig 获取全局跨域配置
+func GetCORSConfig() *CORSConfig {
+	return &CORSConfig{
+		AllowedOrigins: getEnvList("CORS_ALLOWED_ORIGINS", "*"),
+		AllowedMethods: getEnvList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+		AllowedHeaders: getEnvList("CORS_ALLOWED_HEADERS",
+			"Origin,Content-Type,Content-Length,Accept,Accept-Encoding,Accept-Language,Authorization,X-CSRF-Token,X-Request-ID,X-API-Key,Idempotency-Key,traceparent,tracestate"),
+		ExposedHeaders: getEnvList("CORS_EXPOSED_HEADERS",
+			"X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy,Retry-After,Idempotent-Replayed"),
+		AllowCredentials: getEnv("CORS_ALLOW_CREDENTIALS", "false") == "true",
+		MaxAge:           getEnvDuration("CORS_MAX_AGE", 12*time.Hour),
+	}
+}
+
+// GetCORSGroups 获取路由组的跨域配置，未单独设置的项沿用全局配置
+// 目前有 admin 组（管理接口），如 CORS_ADMIN_ALLOWED_ORIGINS=https://admin.example.com
+func GetCORSGroups() []CORSGroup {
+	base := GetCORSConfig()
+	return []CORSGroup{
+		{
+			Name:     "admin",
+			Prefixes: getEnvList("CORS_ADMIN_PATHS", "/api/v1/users"),
+			Config:   groupCORSConfig("CORS_ADMIN_", base),
+		},
+	}
+}
+
+// groupCORSConfig 读取带前缀的跨域配置，未设置的项使用 base 中的值
+func groupCORSConfig(prefix string, base *CORSConfig) *CORSConfig {
+	return &CORSConfig{
+		AllowedOrigins:   getEnvList(prefix+"ALLOWED_ORIGINS", strings.Join(base.AllowedOrigins, ",")),
+		AllowedMethods:   getEnvList(prefix+"ALLOWED_METHODS", strings.Join(base.AllowedMethods, ",")),
+		AllowedHeaders:   getEnvList(prefix+"ALLOWED_HEADERS", strings.Join(base.AllowedHeaders, ",")),
+		ExposedHeaders:   getEnvList(prefix+"EXPOSED_HEADERS", strings.Join(base.ExposedHeaders, ",")),
+		AllowCredentials: getEnv(prefix+"ALLOW_CREDENTIALS", boolString(base.AllowCredentials)) == "true",
+		MaxAge:           getEnvDuration(prefix+"MAX_AGE", base.MaxAge),
+	}
+}
+
+// getEnvList 获取逗号分隔的环境变量列表，忽略空项
+func getEnvList(key, defaultValue string) []string {
+	var list []string
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// boolString 将布尔值转为 true/false 字符串
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
@@ -0,0 +1,19 @@
+package config
+
+// GraphQLConfig GraphQL 接口配置
+type GraphQLConfig struct {
+	MaxDepth      int  // 最大查询深度
+	MaxComplexity int  // 最大查询复杂度，列表字段按每页条数成倍计算
+	Introspection bool // 是否允许内省查询
+	MaxBodySize   int  // POST 请求体上限（字节）
+}
+
+// GetGraphQLConfig 获取 GraphQL 接口配置
+func GetGraphQLConfig() *GraphQLConfig {
+	return &GraphQLConfig{
+		MaxDepth:      getEnvInt("GRAPHQL_MAX_DEPTH", 10),
+		MaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 1000),
+		Introspection: getEnv("GRAPHQL_INTROSPECTION", "true") == "true",
+		MaxBodySize:   getEnvInt("GRAPHQL_MAX_BODY_SIZE", 1<<20),
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "time"
+
+// GRPCConfig gRPC 服务配置
+type GRPCConfig struct {
+	Enabled         bool          // 是否启动 gRPC 服务
+	Addr            string        // 监听地址，gRPC 与 JSON 网关共用
+	APIKeys         []string      // 服务间调用的 API 密钥，gRPC 通过 x-api-key 元数据携带，HTTP 接口通过 X-API-Key 请求头携带（仅用于限流识别调用方）
+	Gateway         bool          // 是否在同一端口提供 JSON 网关，供不便使用 gRPC 的客户端调试与调用
+	Reflection      bool          // 是否开启服务反射，供 grpcurl 等工具发现接口
+	ExportBatchSize int           // 流式导出每批读取的条数
+	MaxRecvMsgSize  int           // 单条请求消息的最大字节数
+	ShutdownTimeout time.Duration // 关闭时等待进行中的调用（含流式导出）完成的时间
+}
+
+// GetGRPCConfig 获取 gRPC 服务配置
+func GetGRPCConfig() *GRPCConfig {
+	return &GRPCConfig{
+		Enabled:         getEnv("GRPC_ENABLED", "false") == "true",
+		Addr:            getEnv("GRPC_ADDR", ":50051"),
+		APIKeys:         getEnvList("GRPC_API_KEYS", ""),
+		Gateway:         getEnv("GRPC_GATEWAY_ENABLED", "true") == "true",
+		Reflection:      getEnv("GRPC_REFLECTION", "false") == "true",
+		ExportBatchSize: getEnvInt("GRPC_EXPORT_BATCH_SIZE", 500),
+		MaxRecvMsgSize:  getEnvInt("GRPC_MAX_RECV_MSG_SIZE", 4<<20),
+		ShutdownTimeout: getEnvDuration("GRPC_SHUTDOWN_TIMEOUT", 30*time.Second),
+	}
+}
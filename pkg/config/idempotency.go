@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+// IdempotencyConfig 幂等键配置
+type IdempotencyConfig struct {
+	Enabled     bool          // 是否开启幂等键
+	TTL         time.Duration // 幂等键及其响应的保留时间
+	LockTimeout time.Duration // 处理中状态的最长持有时间，超过后视为处理实例已退出，允许重新处理
+	WaitTimeout time.Duration // 相同幂等键的并发请求等待前一个请求完成的最长时间
+	MaxBodySize int           // 携带幂等键的请求体上限（字节），请求体需整体读入内存计算摘要
+}
+
+// GetIdempotencyConfig 获取幂等键配置
+func GetIdempotencyConfig() *IdempotencyConfig {
+	return &IdempotencyConfig{
+		Enabled:     getEnv("IDEMPOTENCY_ENABLED", "true") == "true",
+		TTL:         getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		LockTimeout: getEnvDuration("IDEMPOTENCY_LOCK_TIMEOUT", time.Minute),
+		WaitTimeout: getEnvDuration("IDEMPOTENCY_WAIT_TIMEOUT", 10*time.Second),
+		MaxBodySize: getEnvInt("IDEMPOTENCY_MAX_BODY_SIZE", 10<<20),
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import "time"
+
+// JobsConfig 后台任务队列配置
+type JobsConfig struct {
+	Enabled        bool          // 是否在本实例执行后台任务与定时计划，关闭后仍可入队，由其他实例执行
+	Workers        int           // 并发执行的任务数
+	PollInterval   time.Duration // 没有到期任务时的轮询间隔
+	Timeout        time.Duration // 单个任务的默认超时时间，也是执行租约，实例在租约内退出时任务到期后会被重新领取
+	MaxAttempts    int           // 默认最大执行次数，超过后标记为失败
+	BackoffBase    time.Duration // 首次重试的等待时间，之后按 2 的指数增长
+	BackoffMax     time.Duration // 重试等待时间上限
+	Retention      time.Duration // 已结束任务的保留时间
+	TrashRetention time.Duration // 回收站数据的保留时间，超过后由清理任务永久删除
+}
+
+// GetJobsConfig 获取后台任务队列配置
+func GetJobsConfig() *JobsConfig {
+	return &JobsConfig{
+		Enabled:        getEnv("JOBS_ENABLED", "true") == "true",
+		Workers:        getEnvInt("JOBS_WORKERS", 4),
+		PollInterval:   getEnvDuration("JOBS_POLL_INTERVAL", 2*time.Second),
+		Timeout:        getEnvDuration("JOBS_TIMEOUT", 5*time.Minute),
+		MaxAttempts:    getEnvInt("JOBS_MAX_ATTEMPTS", 5),
+		BackoffBase:    getEnvDuration("JOBS_BACKOFF_BASE", 30*time.Second),
+		BackoffMax:     getEnvDuration("JOBS_BACKOFF_MAX", time.Hour),
+		Retention:      getEnvDuration("JOBS_RETENTION", 7*24*time.Hour),
+		TrashRetention: getEnvDuration("JOBS_TRASH_RETENTION", 30*24*time.Hour),
+	}
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// LogConfig 日志配置
+type LogConfig struct {
+	Level          string        // 日志级别：debug、info、warn、error
+	Format         string        // 输出格式：json、text
+	File           string        // 日志文件路径，为空时只输出到标准输出
+	MaxSizeMB      int           // 单个日志文件最大体积（MB），超过后切割
+	MaxBackups     int           // 保留的历史日志文件数量
+	MaxAgeDays     int           // 历史日志文件保留天数
+	RotateInterval time.Duration // 按时间切割的间隔，0 表示不按时间切割
+	Compress       bool          // 是否压缩历史日志
+	DBLevel        string        // SQL 日志级别：silent、error、warn、info
+	SlowThreshold  time.Duration // 慢查询阈值
+	Stderr         bool          // 控制台日志输出到标准错误而非标准输出，运维命令使用，使标准输出只包含命令结果
+}
+
+// GetLogConfig 获取日志配置
+func GetLogConfig() *LogConfig {
+	return &LogConfig{
+		Level:          getEnv("LOG_LEVEL", "info"),
+		Format:         getEnv("LOG_FORMAT", "json"),
+		File:           getEnv("LOG_FILE", ""),
+		MaxSizeMB:      getEnvInt("LOG_MAX_SIZE_MB", 100),
+		MaxBackups:     getEnvInt("LOG_MAX_BACKUPS", 10),
+		MaxAgeDays:     getEnvInt("LOG_MAX_AGE_DAYS", 30),
+		RotateInterval: getEnvDuration("LOG_ROTATE_INTERVAL", 24*time.Hour),
+		Compress:       getEnv("LOG_COMPRESS", "true") == "true",
+		DBLevel:        getEnv("DB_LOG_LEVEL", "warn"),
+		SlowThreshold:  getEnvDuration("DB_SLOW_THRESHOLD", 200*time.Millisecond),
+	}
+}
+
+// getEnvInt 获取整数类型的环境变量，解析失败时返回默认值
+func getEnvInt(key string, defaultValue int) int {
+	raw := getEnv(key, "")
+	if value, err := strconv.Atoi(raw); err == nil {
+		return value
+	}
+	recordInvalid(key, raw)
+	return defaultValue
+}
+
+// getEnvDuration 获取时间间隔类型的环境变量，如 200ms、24h
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	raw := getEnv(key, "")
+	if value, err := time.ParseDuration(raw); err == nil {
+		return value
+	}
+	recordInvalid(key, raw)
+	return defaultValue
+}
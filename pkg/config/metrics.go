@@ -0,0 +1,21 @@
+package config
+
+// MetricsConfig 监控指标配置
+type MetricsConfig struct {
+	Enabled           bool   // 是否开启 Prometheus 指标
+	Path              string // 指标路径
+	Addr              string // 独立监听地址，如 :9090，为空时挂载在业务端口上
+	Token             string // 访问令牌，设置后需携带 Authorization: Bearer {token}
+	LowStockThreshold int    // 低库存阈值的默认值，运行时以设置 product.low_stock_threshold 为准
+}
+
+// GetMetricsConfig 获取监控指标配置
+func GetMetricsConfig() *MetricsConfig {
+	return &MetricsConfig{
+		Enabled:           getEnv("METRICS_ENABLED", "true") == "true",
+		Path:              getEnv("METRICS_PATH", "/metrics"),
+		Addr:              getEnv("METRICS_ADDR", ""),
+		Token:             getEnv("METRICS_TOKEN", ""),
+		LowStockThreshold: getEnvInt("METRICS_LOW_STOCK_THRESHOLD", 10),
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+// ModuleConfig 业务模块启用配置
+type ModuleConfig struct {
+	Enabled  []string // 只启用这些模块，为空表示启用全部已注册模块
+	Disabled []string // 禁用的模块，优先于 Enabled
+}
+
+// GetModuleConfig 获取业务模块启用配置
+func GetModuleConfig() *ModuleConfig {
+	return &ModuleConfig{
+		Enabled:  getEnvList("MODULES_ENABLED", ""),
+		Disabled: getEnvList("MODULES_DISABLED", ""),
+	}
+}
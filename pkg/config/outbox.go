@@ -0,0 +1,27 @@
+package config
+
+import "time"
+
+// OutboxConfig 事件发件箱转发配置
+type OutboxConfig struct {
+	Enabled      bool          // 是否在本实例运行转发，多实例部署时可只在部分实例开启
+	BatchSize    int           // 每次领取的事件数
+	PollInterval time.Duration // 没有待转发事件时的轮询间隔
+	MaxAttempts  int           // 最大处理次数，超过后标记为失败并不再阻塞同一聚合的后续事件
+	BackoffBase  time.Duration // 首次重试的等待时间，之后按 2 的指数增长
+	BackoffMax   time.Duration // 重试等待时间上限
+	Retention    time.Duration // 已处理事件的保留时间
+}
+
+// GetOutboxConfig 获取事件发件箱转发配置
+func GetOutboxConfig() *OutboxConfig {
+	return &OutboxConfig{
+		Enabled:      getEnv("OUTBOX_RELAY_ENABLED", "true") == "true",
+		BatchSize:    getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		PollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		MaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
+		BackoffBase:  getEnvDuration("OUTBOX_BACKOFF_BASE", 5*time.Second),
+		BackoffMax:   getEnvDuration("OUTBOX_BACKOFF_MAX", 10*time.Minute),
+		Retention:    getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+// PatchConfig PATCH 部分更新配置
+type PatchConfig struct {
+	MaxBodySize int // 补丁请求体上限（字节）
+}
+
+// GetPatchConfig 获取 PATCH 部分更新配置
+func GetPatchConfig() *PatchConfig {
+	return &PatchConfig{
+		MaxBodySize: getEnvInt("PATCH_MAX_BODY_SIZE", 1<<20),
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+// RateLimitConfig 限流配置
+// 额度格式为 次数/时间窗口，如 300/1m 表示每分钟 300 次，允许在窗口内一次性用完
+type RateLimitConfig struct {
+	Enabled bool   // 是否开启限流
+	Store   string // 令牌桶存储：memory（单实例）、postgres（多实例共享）
+	Default string // 全部接口的默认额度
+	Login   string // 登录、注册接口的额度
+	Bulk    string // 批量与同步类接口（如库存、价格更新）的额度
+}
+
+// GetRateLimitConfig 获取限流配置
+func GetRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		Enabled: getEnv("RATE_LIMIT_ENABLED", "true") == "true",
+		Store:   getEnv("RATE_LIMIT_STORE", "memory"),
+		Default: getEnv("RATE_LIMIT_DEFAULT", "300/1m"),
+		Login:   getEnv("RATE_LIMIT_LOGIN", "10/1m"),
+		Bulk:    getEnv("RATE_LIMIT_BULK", "60/1m"),
+	}
+}
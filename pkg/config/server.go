@@ -0,0 +1,42 @@
+package config
+
+import "time"
+
+// ServerConfig HTTP 服务配置
+type ServerConfig struct {
+	Port                  string        // 监听端口
+	ReadTimeout           time.Duration // 读取整个请求（含请求体）的超时
+	ReadHeaderTimeout     time.Duration // 读取请求头的超时
+	WriteTimeout          time.Duration // 写出响应的超时
+	IdleTimeout           time.Duration // keep-alive 连接空闲超时
+	MaxHeaderBytes        int           // 请求头最大字节数
+	ShutdownTimeout       time.Duration // 关闭时等待进行中请求完成的最长时间
+	WorkerShutdownTimeout time.Duration // 请求处理完后等待后台任务退出的最长时间，与 ShutdownTimeout 分别计时
+	ReadinessDelay        time.Duration // 就绪检查失败后到停止接收新连接的等待时间，留给负载均衡摘除实例
+	TLSCertFile           string        // TLS 证书文件，与私钥同时设置时启用 HTTPS
+	TLSKeyFile            string        // TLS 私钥文件
+	TLSReloadInterval     time.Duration // 检查证书文件变更的间隔，变更后自动重新加载
+}
+
+// GetServerConfig 获取 HTTP 服务配置
+func GetServerConfig() *ServerConfig {
+	return &ServerConfig{
+		Port:                  getEnv("PORT", "8080"),
+		ReadTimeout:           getEnvDuration("SERVER_READ_TIMEOUT", 15*time.Second),
+		ReadHeaderTimeout:     getEnvDuration("SERVER_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:          getEnvDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:           getEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+		MaxHeaderBytes:        getEnvInt("SERVER_MAX_HEADER_BYTES", 1<<20),
+		ShutdownTimeout:       getEnvDuration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+		WorkerShutdownTimeout: getEnvDuration("SERVER_WORKER_SHUTDOWN_TIMEOUT", 35*time.Second),
+		ReadinessDelay:        getEnvDuration("SERVER_READINESS_DELAY", 5*time.Second),
+		TLSCertFile:           getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:            getEnv("TLS_KEY_FILE", ""),
+		TLSReloadInterval:     getEnvDuration("TLS_RELOAD_INTERVAL", time.Minute),
+	}
+}
+
+// TLSEnabled 是否启用 HTTPS
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// SettingsConfig 运行时设置配置
+type SettingsConfig struct {
+	CacheTTL time.Duration // 设置缓存的最长有效期，其他实例修改设置的通知丢失时最迟在此之后生效
+}
+
+// GetSettingsConfig 获取运行时设置配置
+func GetSettingsConfig() *SettingsConfig {
+	return &SettingsConfig{
+		CacheTTL: getEnvDuration("SETTINGS_CACHE_TTL", time.Minute),
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+// StreamConfig 实时推送配置
+type StreamConfig struct {
+	Enabled      bool          // 是否提供 /stream 接口
+	BufferSize   int           // 用于 Last-Event-ID 断线续传的最近事件数
+	ClientBuffer int           // 每个连接待发送事件的队列长度，写满说明客户端接收过慢，连接将被断开
+	MaxTopics    int           // 每个连接最多订阅的主题数
+	Heartbeat    time.Duration // 心跳间隔，用于保持连接并及时发现断开的客户端
+	WriteTimeout time.Duration // 单条消息的写超时
+}
+
+// GetStreamConfig 获取实时推送配置
+func GetStreamConfig() *StreamConfig {
+	return &StreamConfig{
+		Enabled:      getEnv("STREAM_ENABLED", "true") == "true",
+		BufferSize:   getEnvInt("STREAM_BUFFER_SIZE", 1000),
+		ClientBuffer: getEnvInt("STREAM_CLIENT_BUFFER", 256),
+		MaxTopics:    getEnvInt("STREAM_MAX_TOPICS", 50),
+		Heartbeat:    getEnvDuration("STREAM_HEARTBEAT", 15*time.Second),
+		WriteTimeout: getEnvDuration("STREAM_WRITE_TIMEOUT", 10*time.Second),
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import "strconv"
+
+// TracingConfig 链路追踪配置
+// OTLP 导出地址、请求头等沿用 OpenTelemetry 标准环境变量，如 OTEL_EXPORTER_OTLP_ENDPOINT
+type TracingConfig struct {
+	Exporter    string  // 导出方式：otlp、stdout、none
+	ServiceName string  // 服务名
+	SampleRatio float64 // 采样比例，0-1，上游已采样的请求始终采样
+}
+
+// GetTracingConfig 获取链路追踪配置
+func GetTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Exporter:    getEnv("TRACING_EXPORTER", "none"),
+		ServiceName: getEnv("OTEL_SERVICE_NAME", "erp_backend"),
+		SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+	}
+}
+
+// getEnvFloat 获取浮点数类型的环境变量，解析失败时返回默认值
+func getEnvFloat(key string, defaultValue float64) float64 {
+	raw := getEnv(key, "")
+	if value, err := strconv.ParseFloat(raw, 64); err == nil {
+		return value
+	}
+	recordInvalid(key, raw)
+	return defaultValue
+}
@@ -0,0 +1,35 @@
+package config
+
+import "time"
+
+// WebhookConfig Webhook 投递配置
+type WebhookConfig struct {
+	Enabled      bool          // 是否投递 Webhook，关闭后不再产生投递记录
+	Workers      int           // 并发投递数
+	BatchSize    int           // 每次领取的待投递记录数
+	PollInterval time.Duration // 没有待投递记录时的轮询间隔
+	Timeout      time.Duration // 单次投递的超时时间
+	MaxAttempts  int           // 最大投递次数，超过后进入死信
+	BackoffBase  time.Duration // 首次重试的等待时间，之后按 2 的指数增长
+	BackoffMax   time.Duration // 重试等待时间上限
+	Retention    time.Duration // 投递成功记录及其日志的保留时间
+
+	AllowPrivateNetworks bool // 是否允许投递到本机、内网与元数据地址，仅用于本地开发与测试
+}
+
+// GetWebhookConfig 获取 Webhook 投递配置
+func GetWebhookConfig() *WebhookConfig {
+	return &WebhookConfig{
+		Enabled:      getEnv("WEBHOOK_ENABLED", "true") == "true",
+		Workers:      getEnvInt("WEBHOOK_WORKERS", 4),
+		BatchSize:    getEnvInt("WEBHOOK_BATCH_SIZE", 20),
+		PollInterval: getEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		Timeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		MaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		BackoffBase:  getEnvDuration("WEBHOOK_BACKOFF_BASE", 30*time.Second),
+		BackoffMax:   getEnvDuration("WEBHOOK_BACKOFF_MAX", 6*time.Hour),
+		Retention:    getEnvDuration("WEBHOOK_RETENTION", 30*24*time.Hour),
+
+		AllowPrivateNetworks: getEnv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false") == "true",
+	}
+}
@@ -6,10 +6,10 @@ import (
 	"os"
 
 	"erp_backend/pkg/config"
+	"erp_backend/pkg/logger"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
@@ -21,8 +21,9 @@ func InitDatabase() error {
 	dsn := dbConfig.GetDSN()
 	log.Printf("连接数据库: %s:%s/%s", dbConfig.Host, dbConfig.Port, dbConfig.DBName)
 
+	logConfig := config.GetLogConfig()
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: logger.NewGormLogger(logConfig.DBLevel, logConfig.SlowThreshold),
 	})
 
 	if err != nil {
@@ -52,9 +53,9 @@ func CloseDatabase() error {
 	return nil
 }
 
-// Connect 连接数据库
-func Connect() (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+// dsn 由环境变量拼接数据库连接串
+func dsn() string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		os.Getenv("DB_HOST"),
 		os.Getenv("DB_PORT"),
 		os.Getenv("DB_USER"),
@@ -62,11 +63,27 @@ func Connect() (*gorm.DB, error) {
 		os.Getenv("DB_NAME"),
 		os.Getenv("DB_SSLMODE"),
 	)
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// Connect 连接数据库，连接同时保存为全局实例以便 CloseDatabase 关闭
+func Connect() (*gorm.DB, error) {
+	logConfig := config.GetLogConfig()
+	db, err := gorm.Open(postgres.Open(dsn()), &gorm.Config{
+		Logger: logger.NewGormLogger(logConfig.DBLevel, logConfig.SlowThreshold),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("连接数据库失败: %w", err)
 	}
 
+	DB = db
+	return db, nil
+}
+
+// Handle 返回不连接数据库的句柄，只能用于注册路由等不执行查询的操作，执行查询时才建立连接
+func Handle() (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn()), &gorm.Config{DisableAutomaticPing: true})
+	if err != nil {
+		return nil, fmt.Errorf("创建数据库句柄失败: %w", err)
+	}
 	return db, nil
 }
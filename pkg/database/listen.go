@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// listenRetryDelay 监听中断后重连前的等待时间
+const listenRetryDelay = 5 * time.Second
+
+// Listener PostgreSQL 频道监听器，占用一个数据库连接执行 LISTEN，连接断开后自动重连
+type Listener struct {
+	DB      *gorm.DB
+	Channel string
+	// OnListen 每次开始监听（含重连）后调用，可据此补偿断线期间丢失的通知，可为 nil
+	OnListen func(ctx context.Context)
+	// OnNotify 收到通知时调用，payload 为 NOTIFY 携带的内容
+	OnNotify func(ctx context.Context, payload string)
+}
+
+// Run 监听直到 ctx 取消
+func (l *Listener) Run(ctx context.Context) {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.ErrorContext(ctx, "数据库通知监听中断，稍后重连", slog.String("channel", l.Channel), slog.Any("error", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// listen 监听直到出错
+func (l *Listener) listen(ctx context.Context) error {
+	sqlDB, err := l.DB.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var listenErr error
+	err = conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = errors.New("数据库驱动不支持 LISTEN")
+			return nil
+		}
+		pgConn := c.Conn()
+		if _, listenErr = pgConn.Exec(ctx, "LISTEN "+l.Channel); listenErr != nil {
+			return driver.ErrBadConn
+		}
+		if l.OnListen != nil {
+			l.OnListen(ctx)
+		}
+		for {
+			notification, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				listenErr = err
+				// 连接仍处于 LISTEN 状态，不归还连接池
+				return driver.ErrBadConn
+			}
+			l.OnNotify(ctx, notification.Payload)
+		}
+	})
+	if listenErr != nil {
+		return listenErr
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Transactor 事务执行接口，服务层借此把多次仓储调用放进同一事务而无需依赖 *gorm.DB
+type Transactor interface {
+	// Transaction 在事务中执行 fn，fn 内的仓储调用需使用传入的 ctx；ctx 中已有事务时加入该事务
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// gormTransactor 基于 Transaction 的实现，仓储通过 db.WithContext(ctx) 自动使用事务连接
+type gormTransactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor 创建事务执行器，db 需已调用 RegisterTx
+func NewTransactor(db *gorm.DB) Transactor {
+	return gormTransactor{db: db}
+}
+
+// Transaction 实现 Transactor 接口
+func (t gormTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return Transaction(ctx, t.db, func(tx *gorm.DB) error {
+		return fn(tx.Statement.Context)
+	})
+}
+
+// NopTransactor 不开启事务、直接执行 fn 的事务执行器，供使用内存仓储的单元测试
+type NopTransactor struct{}
+
+// Transaction 实现 Transactor 接口
+func (NopTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
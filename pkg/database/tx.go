@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// txState context 中的事务连接及提交后回调
+type txState struct {
+	pool        gorm.ConnPool
+	mu          sync.Mutex
+	afterCommit []func()
+}
+
+// WithTx 将事务写入 context，之后通过 db.WithContext(ctx) 执行的 SQL 都在该事务中执行
+// 处理函数无需感知事务，批量接口借此把多个子请求放进同一个事务
+// 自行提交事务的调用方需在提交成功后调用 Committed
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, &txState{pool: tx.Statement.ConnPool})
+}
+
+// WithoutTx 返回不带事务的 context，之后的 SQL 使用独立连接并立即生效，
+// 用于限流计数等不应随所在事务回滚、也不应长时间持有行锁的写入
+func WithoutTx(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(txKey{}).(*txState); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, txKey{}, nil)
+}
+
+// Transaction 在事务中执行 fn，fn 返回错误时回滚
+// ctx 中已有事务时直接加入该事务，由外层决定提交或回滚
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(db.WithContext(ctx))
+	}
+
+	var txCtx context.Context
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx = WithTx(ctx, tx)
+		return fn(tx.WithContext(txCtx))
+	})
+	if err != nil {
+		return err
+	}
+	Committed(txCtx)
+	return nil
+}
+
+// AfterCommit 注册事务提交后执行的函数，如唤醒后台任务处理刚写入的记录
+// ctx 不在事务中时立即执行；事务回滚时不执行
+func AfterCommit(ctx context.Context, fn func()) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// Committed 执行通过 AfterCommit 注册的函数，由 WithTx 的调用方在事务提交成功后调用
+func Committed(ctx context.Context) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return
+	}
+	state.mu.Lock()
+	fns := state.afterCommit
+	state.afterCommit = nil
+	state.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// RegisterTx 注册 context 事务插件，需在处理请求前调用
+func RegisterTx(db *gorm.DB) error {
+	return db.Use(&txPlugin{})
+}
+
+// txPlugin 将 SQL 切换到 context 中事务连接的 GORM 插件
+type txPlugin struct{}
+
+// Name 实现 gorm.Plugin
+func (p *txPlugin) Name() string {
+	return "context_tx"
+}
+
+// Initialize 实现 gorm.Plugin，回调排在最前，早于 GORM 为写操作开启默认事务
+// 连接已是事务时 GORM 不会再开启嵌套事务
+func (p *txPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("context_tx:create", useTx),
+		cb.Query().Before("*").Register("context_tx:query", useTx),
+		cb.Update().Before("*").Register("context_tx:update", useTx),
+		cb.Delete().Before("*").Register("context_tx:delete", useTx),
+		cb.Row().Before("*").Register("context_tx:row", useTx),
+		cb.Raw().Before("*").Register("context_tx:raw", useTx),
+	)
+}
+
+// useTx context 中带有事务时改用事务连接
+func useTx(db *gorm.DB) {
+	if db.Statement.Context == nil {
+		return
+	}
+	if state, ok := db.Statement.Context.Value(txKey{}).(*txState); ok {
+		db.Statement.ConnPool = state.pool
+	}
+}
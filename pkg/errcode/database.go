@@ -0,0 +1,54 @@
+package errcode
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// PostgreSQL 错误状态码
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// FromDB 将数据库错误转换为业务错误
+// fallback 为未识别错误时使用的错误码；若 fallback 为 404 类错误码，则仅在记录不存在时使用，
+// 其他数据库错误返回 DATABASE_ERROR，避免把连接故障误报为资源不存在
+func FromDB(err error, fallback Code) *Error {
+	var codeErr *Error
+	if errors.As(err, &codeErr) {
+		return codeErr
+	}
+	var code Code
+	if errors.As(err, &code) {
+		return code.New()
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if fallback.Status == http.StatusNotFound {
+			return fallback.New()
+		}
+		return NotFound.New()
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			if code, ok := constraints[pgErr.ConstraintName]; ok {
+				return code.New()
+			}
+			return DuplicateEntry.New()
+		case pgForeignKeyViolation:
+			return ReferenceNotFound.New()
+		}
+	}
+
+	if fallback.Status == http.StatusNotFound {
+		return DatabaseError.New()
+	}
+	return fallback.New()
+}
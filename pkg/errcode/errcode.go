@@ -0,0 +1,111 @@
+package errcode
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// Code 业务错误码
+type Code struct {
+	Key     string // 稳定的错误码标识，如 PRODUCT_SKU_DUPLICATE
+	Status  int    // 对应的 HTTP 状态码
+	Message string // 默认提示信息，可包含 fmt 占位符
+}
+
+// Error 携带业务错误码的错误
+type Error struct {
+	Code    Code
+	Args    []interface{} // 提示信息中的占位参数
+	Details []FieldError  // 字段级错误详情
+}
+
+// FieldError 字段级错误详情
+// @Description 字段校验失败详情
+type FieldError struct {
+	Field   string `json:"field" example:"email"`        // 字段名（JSON 字段名）
+	Rule    string `json:"rule" example:"email"`         // 校验规则
+	Param   string `json:"-"`                            // 校验规则参数
+	Message string `json:"message" example:"必须是有效的邮箱地址"` // 错误信息
+}
+
+// catalog 已注册的错误码
+var catalog = map[string]Code{}
+
+// New 注册一个错误码，Key 重复时 panic
+func New(key string, status int, message string) Code {
+	if _, exists := catalog[key]; exists {
+		panic("errcode: 重复的错误码 " + key)
+	}
+	code := Code{Key: key, Status: status, Message: message}
+	catalog[key] = code
+	return code
+}
+
+// All 返回按 Key 排序的全部错误码
+func All() []Code {
+	codes := make([]Code, 0, len(catalog))
+	for _, code := range catalog {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Key < codes[j].Key })
+	return codes
+}
+
+// New 使用占位参数创建错误
+func (c Code) New(args ...interface{}) *Error {
+	return &Error{Code: c, Args: args}
+}
+
+// WithDetails 创建携带字段详情的错误
+func (c Code) WithDetails(details ...FieldError) *Error {
+	return &Error{Code: c, Details: details}
+}
+
+// Error 实现 error 接口
+func (c Code) Error() string {
+	return c.Message
+}
+
+// Error 实现 error 接口
+func (e *Error) Error() string {
+	if len(e.Args) == 0 {
+		return e.Code.Message
+	}
+	return fmt.Sprintf(e.Code.Message, e.Args...)
+}
+
+// 通用错误码
+var (
+	InvalidParams         = New("INVALID_PARAMS", http.StatusBadRequest, "无效的请求参数")
+	RequestTooLarge       = New("REQUEST_TOO_LARGE", http.StatusRequestEntityTooLarge, "请求体过大")
+	ValidationFailed      = New("VALIDATION_FAILED", http.StatusBadRequest, "请求参数校验失败")
+	InvalidID             = New("INVALID_ID", http.StatusBadRequest, "无效的ID")
+	InvalidPagination     = New("INVALID_PAGINATION", http.StatusBadRequest, "无效的分页参数: %s")
+	InvalidCursor         = New("INVALID_CURSOR", http.StatusBadRequest, "无效的游标")
+	InvalidSort           = New("INVALID_SORT", http.StatusBadRequest, "不支持的排序字段: %s")
+	InvalidFilter         = New("INVALID_FILTER", http.StatusBadRequest, "无效的筛选条件: %s")
+	InvalidPatch          = New("INVALID_PATCH", http.StatusBadRequest, "无效的补丁: %s")
+	PatchTestFailed       = New("PATCH_TEST_FAILED", http.StatusConflict, "补丁 test 操作未通过，数据已被修改")
+	FieldNotPatchable     = New("FIELD_NOT_PATCHABLE", http.StatusBadRequest, "字段不允许修改: %s")
+	Unauthorized          = New("UNAUTHORIZED", http.StatusUnauthorized, "未认证")
+	TokenMissing          = New("TOKEN_MISSING", http.StatusUnauthorized, "未提供认证信息")
+	TokenMalformed        = New("TOKEN_MALFORMED", http.StatusUnauthorized, "认证格式错误")
+	TokenInvalid          = New("TOKEN_INVALID", http.StatusUnauthorized, "无效的令牌")
+	TokenExpired          = New("TOKEN_EXPIRED", http.StatusUnauthorized, "令牌已过期")
+	Forbidden             = New("FORBIDDEN", http.StatusForbidden, "权限不足")
+	NotFound              = New("NOT_FOUND", http.StatusNotFound, "资源不存在")
+	DuplicateEntry        = New("DUPLICATE_ENTRY", http.StatusConflict, "数据已存在")
+	ReferenceNotFound     = New("REFERENCE_NOT_FOUND", http.StatusBadRequest, "关联数据不存在")
+	DatabaseError         = New("DATABASE_ERROR", http.StatusInternalServerError, "数据库操作失败")
+	InternalError         = New("INTERNAL_ERROR", http.StatusInternalServerError, "服务器内部错误")
+	IdempotencyKeyInvalid = New("IDEMPOTENCY_KEY_INVALID", http.StatusBadRequest, "幂等键长度不能超过255个字符")
+	IdempotencyKeyReused  = New("IDEMPOTENCY_KEY_REUSED", http.StatusConflict, "幂等键已用于内容不同的请求")
+	IdempotencyInProgress = New("IDEMPOTENCY_IN_PROGRESS", http.StatusConflict, "相同幂等键的请求正在处理中，请稍后重试")
+	RateLimited           = New("RATE_LIMITED", http.StatusTooManyRequests, "请求过于频繁，请稍后重试")
+	BatchNested           = New("BATCH_NESTED", http.StatusBadRequest, "批量请求中不能再调用批量接口")
+	BatchReferenceInvalid = New("BATCH_REFERENCE_INVALID", http.StatusBadRequest, "无效的引用: %s")
+	BatchDependencyFailed = New("BATCH_DEPENDENCY_FAILED", http.StatusFailedDependency, "引用的操作 %s 未成功执行")
+	BatchAborted          = New("BATCH_ABORTED", http.StatusFailedDependency, "前序操作失败，事务已回滚，此操作未执行")
+	ServiceUnavailable    = New("SERVICE_UNAVAILABLE", http.StatusServiceUnavailable, "服务暂不可用")
+)
@@ -0,0 +1,146 @@
+package errcode
+
+import (
+	"net/http"
+)
+
+// Resource 资源的通用错误码
+type Resource struct {
+	Name          string // 资源名称
+	NotFound      Code   // 资源不存在
+	CreateFailed  Code   // 创建失败
+	UpdateFailed  Code   // 更新失败
+	DeleteFailed  Code   // 删除失败
+	ListFailed    Code   // 获取列表失败
+	ToggleFailed  Code   // 切换状态失败
+	RestoreFailed Code   // 从回收站恢复失败
+}
+
+// NewResource 注册资源的通用错误码，key 为大写资源标识，如 PRODUCT
+func NewResource(key, name string) Resource {
+	return Resource{
+		Name:          name,
+		NotFound:      New(key+"_NOT_FOUND", http.StatusNotFound, name+"不存在"),
+		CreateFailed:  New(key+"_CREATE_FAILED", http.StatusInternalServerError, "创建"+name+"失败"),
+		UpdateFailed:  New(key+"_UPDATE_FAILED", http.StatusInternalServerError, "更新"+name+"失败"),
+		DeleteFailed:  New(key+"_DELETE_FAILED", http.StatusInternalServerError, "删除"+name+"失败"),
+		ListFailed:    New(key+"_LIST_FAILED", http.StatusInternalServerError, "获取"+name+"列表失败"),
+		ToggleFailed:  New(key+"_TOGGLE_FAILED", http.StatusInternalServerError, "更新"+name+"状态失败"),
+		RestoreFailed: New(key+"_RESTORE_FAILED", http.StatusInternalServerError, "恢复"+name+"失败"),
+	}
+}
+
+// 各业务资源
+var (
+	User             = NewResource("USER", "用户")
+	Supplier         = NewResource("SUPPLIER", "供应商")
+	Shop             = NewResource("SHOP", "店铺")
+	Product          = NewResource("PRODUCT", "商品")
+	Category         = NewResource("CATEGORY", "分类")
+	Link             = NewResource("LINK", "链接")
+	Attribute        = NewResource("ATTRIBUTE", "属性")
+	ProductAttribute = NewResource("PRODUCT_ATTRIBUTE", "商品属性值")
+	Webhook          = NewResource("WEBHOOK", "Webhook 订阅")
+	WebhookDelivery  = NewResource("WEBHOOK_DELIVERY", "Webhook 投递记录")
+	Job              = NewResource("JOB", "后台任务")
+	JobSchedule      = NewResource("JOB_SCHEDULE", "定时计划")
+	Setting          = NewResource("SETTING", "系统设置")
+)
+
+// 用户与认证
+var (
+	LoginFailed            = New("LOGIN_FAILED", http.StatusUnauthorized, "用户名或密码错误")
+	AccountLocked          = New("ACCOUNT_LOCKED", http.StatusLocked, "登录失败次数过多，账号已锁定至 %s")
+	UserNameDuplicate      = New("USER_NAME_DUPLICATE", http.StatusConflict, "用户名已存在")
+	UserEmailDuplicate     = New("USER_EMAIL_DUPLICATE", http.StatusConflict, "邮箱已存在")
+	PasswordHashFailed     = New("PASSWORD_HASH_FAILED", http.StatusInternalServerError, "密码加密失败")
+	OldPasswordIncorrect   = New("OLD_PASSWORD_INCORRECT", http.StatusBadRequest, "原密码错误")
+	PasswordUpdateFailed   = New("PASSWORD_UPDATE_FAILED", http.StatusInternalServerError, "更新密码失败")
+	ProfileUpdateFailed    = New("PROFILE_UPDATE_FAILED", http.StatusInternalServerError, "更新个人资料失败")
+	TokenGenerateFailed    = New("TOKEN_GENERATE_FAILED", http.StatusInternalServerError, "生成token失败")
+	PasswordTooShort       = New("PASSWORD_TOO_SHORT", http.StatusBadRequest, "密码至少 %d 位")
+	PasswordLetterRequired = New("PASSWORD_LETTER_REQUIRED", http.StatusBadRequest, "密码需包含字母")
+	PasswordDigitRequired  = New("PASSWORD_DIGIT_REQUIRED", http.StatusBadRequest, "密码需包含数字")
+	PasswordSymbolRequired = New("PASSWORD_SYMBOL_REQUIRED", http.StatusBadRequest, "密码需包含字母和数字以外的符号")
+)
+
+// 商品
+var (
+	ProductSKUDuplicate      = New("PRODUCT_SKU_DUPLICATE", http.StatusConflict, "商品SKU已存在")
+	ProductSKUInvalid        = New("PRODUCT_SKU_INVALID", http.StatusBadRequest, "商品SKU不符合格式要求: %s")
+	ProductStockUpdateFailed = New("PRODUCT_STOCK_UPDATE_FAILED", http.StatusInternalServerError, "更新库存失败")
+	ProductPriceUpdateFailed = New("PRODUCT_PRICE_UPDATE_FAILED", http.StatusInternalServerError, "更新价格失败")
+	SearchKeywordRequired    = New("SEARCH_KEYWORD_REQUIRED", http.StatusBadRequest, "搜索关键词不能为空")
+	ProductSearchFailed      = New("PRODUCT_SEARCH_FAILED", http.StatusInternalServerError, "搜索商品失败")
+)
+
+// 分类
+var (
+	CategoryHasChildren        = New("CATEGORY_HAS_CHILDREN", http.StatusConflict, "该分类下存在子分类，无法删除")
+	CategoryChildrenListFailed = New("CATEGORY_CHILDREN_LIST_FAILED", http.StatusInternalServerError, "获取子分类失败")
+)
+
+// Webhook
+var (
+	WebhookEventInvalid    = New("WEBHOOK_EVENT_INVALID", http.StatusBadRequest, "不支持的事件类型: %s")
+	WebhookRedeliverFailed = New("WEBHOOK_REDELIVER_FAILED", http.StatusInternalServerError, "重新投递失败")
+	WebhookURLForbidden    = New("WEBHOOK_URL_FORBIDDEN", http.StatusBadRequest, "接收地址不可用: %s")
+)
+
+// 后台任务
+var (
+	JobNotRetryable   = New("JOB_NOT_RETRYABLE", http.StatusConflict, "只有失败或已取消的任务可以重试")
+	JobNotCancellable = New("JOB_NOT_CANCELLABLE", http.StatusConflict, "任务已结束，不能取消")
+	JobDuplicate      = New("JOB_DUPLICATE", http.StatusConflict, "已有相同唯一键的任务尚未结束")
+	JobRetryFailed    = New("JOB_RETRY_FAILED", http.StatusInternalServerError, "重试任务失败")
+	JobCancelFailed   = New("JOB_CANCEL_FAILED", http.StatusInternalServerError, "取消任务失败")
+	JobEnqueueFailed  = New("JOB_ENQUEUE_FAILED", http.StatusInternalServerError, "任务入队失败")
+)
+
+// 系统设置
+var (
+	MaintenanceMode = New("MAINTENANCE_MODE", http.StatusServiceUnavailable, "系统维护中，暂时只能查询数据")
+)
+
+// 实时推送
+var (
+	StreamUnavailable   = New("STREAM_UNAVAILABLE", http.StatusServiceUnavailable, "实时推送未启用或服务正在关闭")
+	StreamTopicInvalid  = New("STREAM_TOPIC_INVALID", http.StatusBadRequest, "无效的订阅主题: %s")
+	StreamTooManyTopics = New("STREAM_TOO_MANY_TOPICS", http.StatusBadRequest, "订阅主题数量超过上限 %d")
+	StreamLagging       = New("STREAM_LAGGING", http.StatusServiceUnavailable, "客户端接收过慢，连接已断开，请携带 Last-Event-ID 重新连接")
+	StreamReset         = New("STREAM_RESET", http.StatusGone, "断线期间的事件已超出回放范围，请重新加载数据")
+	StreamShutdown      = New("STREAM_SHUTDOWN", http.StatusServiceUnavailable, "服务正在关闭，请携带 Last-Event-ID 重新连接")
+)
+
+// GraphQL
+var (
+	GraphQLQueryMissing          = New("GRAPHQL_QUERY_MISSING", http.StatusBadRequest, "缺少查询语句")
+	GraphQLInvalid               = New("GRAPHQL_INVALID", http.StatusBadRequest, "查询无效: %s")
+	GraphQLOperationNotFound     = New("GRAPHQL_OPERATION_NOT_FOUND", http.StatusBadRequest, "未找到操作 %s")
+	GraphQLOperationNameRequired = New("GRAPHQL_OPERATION_NAME_REQUIRED", http.StatusBadRequest, "文档包含多个操作，需指定 operationName")
+	GraphQLMutationNotAllowed    = New("GRAPHQL_MUTATION_NOT_ALLOWED", http.StatusMethodNotAllowed, "GET 请求只能执行查询，变更请使用 POST")
+	GraphQLTooDeep               = New("GRAPHQL_TOO_DEEP", http.StatusBadRequest, "查询深度 %d 超过上限 %d")
+	GraphQLTooComplex            = New("GRAPHQL_TOO_COMPLEX", http.StatusBadRequest, "查询复杂度 %d 超过上限 %d")
+	GraphQLIntrospectionDisabled = New("GRAPHQL_INTROSPECTION_DISABLED", http.StatusForbidden, "内省查询已禁用")
+)
+
+// gRPC
+var (
+	APIKeyInvalid         = New("API_KEY_INVALID", http.StatusUnauthorized, "无效的 API 密钥")
+	GRPCMethodNotFound    = New("GRPC_METHOD_NOT_FOUND", http.StatusNotFound, "gRPC 方法 %s 不存在")
+	GRPCGatewayPostOnly   = New("GRPC_GATEWAY_POST_ONLY", http.StatusMethodNotAllowed, "网关只接受 POST 请求")
+	GRPCStockTooManyItems = New("GRPC_STOCK_TOO_MANY_ITEMS", http.StatusBadRequest, "一次最多查询 %d 个商品")
+)
+
+// 唯一约束与错误码的对应关系，键为 GORM 生成的索引名
+var constraints = map[string]Code{
+	"idx_products_sku_active": ProductSKUDuplicate,
+	"idx_users_name":          UserNameDuplicate,
+	"idx_users_email":         UserEmailDuplicate,
+	"idx_jobs_unique_key":     JobDuplicate,
+}
+
+// RegisterConstraint 注册唯一约束对应的错误码
+func RegisterConstraint(name string, code Code) {
+	constraints[name] = code
+}
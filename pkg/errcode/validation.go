@@ -0,0 +1,80 @@
+package errcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"erp_backend/pkg/i18n"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// 校验错误中使用 JSON 字段名，便于前端定位字段
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
+
+// FromBinding 将请求绑定错误转换为业务错误，校验失败时给出字段级详情
+func FromBinding(err error) *Error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		details := make([]FieldError, 0, len(validationErrors))
+		for _, fieldErr := range validationErrors {
+			details = append(details, FieldError{
+				Field:   fieldPath(fieldErr),
+				Rule:    fieldErr.Tag(),
+				Param:   fieldErr.Param(),
+				Message: RuleMessage(i18n.Default, fieldErr.Tag(), fieldErr.Param()),
+			})
+		}
+		return ValidationFailed.WithDetails(details...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return ValidationFailed.WithDetails(FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Message: RuleMessage(i18n.Default, "type", typeErr.Type.String()),
+		})
+	}
+
+	return InvalidParams.New()
+}
+
+// RuleMessage 按语言生成校验规则的提示信息，未知规则给出通用提示
+func RuleMessage(locale, rule, param string) string {
+	message := i18n.Translate(locale, "validation."+rule, "")
+	if message == "" {
+		return i18n.Translate(locale, "validation.unknown", "", rule)
+	}
+	if strings.Contains(message, "%s") {
+		return fmt.Sprintf(message, param)
+	}
+	return message
+}
+
+// fieldPath 去掉顶层结构体名，得到 JSON 字段路径
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldErr.Field()
+}
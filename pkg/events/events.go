@@ -0,0 +1,240 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"erp_backend/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// Event 领域事件
+type Event interface {
+	EventName() string     // 事件名称，格式为 聚合类型.动作，如 product.created
+	AggregateType() string // 聚合类型，如 product
+	AggregateID() uint     // 聚合ID，同一聚合的事件按写入顺序处理
+}
+
+// Aggregate 可产生领域事件的实体
+type Aggregate interface {
+	AggregateType() string
+	AggregateID() uint
+}
+
+// Created 实体创建事件，序列化为实体本身
+type Created[T Aggregate] struct {
+	Entity T
+}
+
+func (e Created[T]) EventName() string     { return e.Entity.AggregateType() + ".created" }
+func (e Created[T]) AggregateType() string { return e.Entity.AggregateType() }
+func (e Created[T]) AggregateID() uint     { return e.Entity.AggregateID() }
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (e Created[T]) MarshalJSON() ([]byte, error) { return json.Marshal(e.Entity) }
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (e *Created[T]) UnmarshalJSON(b []byte) error { return json.Unmarshal(b, &e.Entity) }
+
+// Updated 实体更新事件，序列化为更新后的实体
+type Updated[T Aggregate] struct {
+	Entity T
+}
+
+func (e Updated[T]) EventName() string     { return e.Entity.AggregateType() + ".updated" }
+func (e Updated[T]) AggregateType() string { return e.Entity.AggregateType() }
+func (e Updated[T]) AggregateID() uint     { return e.Entity.AggregateID() }
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (e Updated[T]) MarshalJSON() ([]byte, error) { return json.Marshal(e.Entity) }
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (e *Updated[T]) UnmarshalJSON(b []byte) error { return json.Unmarshal(b, &e.Entity) }
+
+// Toggled 实体启用状态切换事件，序列化为切换后的实体
+type Toggled[T Aggregate] struct {
+	Entity T
+}
+
+func (e Toggled[T]) EventName() string     { return e.Entity.AggregateType() + ".toggled" }
+func (e Toggled[T]) AggregateType() string { return e.Entity.AggregateType() }
+func (e Toggled[T]) AggregateID() uint     { return e.Entity.AggregateID() }
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (e Toggled[T]) MarshalJSON() ([]byte, error) { return json.Marshal(e.Entity) }
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (e *Toggled[T]) UnmarshalJSON(b []byte) error { return json.Unmarshal(b, &e.Entity) }
+
+// Owned 归属于其他实体的聚合，删除事件据此携带所属实体的ID，
+// 订阅方（如按供应商推送的实时事件）无需再查询已删除的记录
+type Owned interface {
+	Owners() map[string]uint // 所属实体的引用字段及ID，如 {"supplier_id": 1}
+}
+
+// Deleted 实体删除事件，含被删除的ID，实体实现 Owned 时还含所属实体的ID
+type Deleted[T Aggregate] struct {
+	ID     uint
+	Owners map[string]uint
+}
+
+// DeletedOf 根据删除前的实体创建删除事件
+func DeletedOf[T Aggregate](entity T) Deleted[T] {
+	e := Deleted[T]{ID: entity.AggregateID()}
+	if owned, ok := any(entity).(Owned); ok {
+		e.Owners = owned.Owners()
+	}
+	return e
+}
+
+func (e Deleted[T]) EventName() string { return e.AggregateType() + ".deleted" }
+func (e Deleted[T]) AggregateID() uint { return e.ID }
+
+// AggregateType 聚合类型取自实体类型的零值
+func (e Deleted[T]) AggregateType() string {
+	var entity T
+	return entity.AggregateType()
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，所属实体的ID与 id 平铺在同一层
+func (e Deleted[T]) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]uint, len(e.Owners)+1)
+	for key, id := range e.Owners {
+		fields[key] = id
+	}
+	fields["id"] = e.ID
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (e *Deleted[T]) UnmarshalJSON(b []byte) error {
+	var fields map[string]uint
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	e.ID = fields["id"]
+	delete(fields, "id")
+	e.Owners = nil
+	if len(fields) > 0 {
+		e.Owners = fields
+	}
+	return nil
+}
+
+// Restored 实体从回收站恢复事件，序列化为恢复后的实体
+type Restored[T Aggregate] struct {
+	Entity T
+}
+
+func (e Restored[T]) EventName() string     { return e.Entity.AggregateType() + ".restored" }
+func (e Restored[T]) AggregateType() string { return e.Entity.AggregateType() }
+func (e Restored[T]) AggregateID() uint     { return e.Entity.AggregateID() }
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (e Restored[T]) MarshalJSON() ([]byte, error) { return json.Marshal(e.Entity) }
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (e *Restored[T]) UnmarshalJSON(b []byte) error { return json.Unmarshal(b, &e.Entity) }
+
+// 发件箱事件状态
+const (
+	StatusPending   = "pending"   // 等待处理或重试
+	StatusProcessed = "processed" // 已被全部订阅方处理
+	StatusFailed    = "failed"    // 超过最大处理次数
+)
+
+// Message 发件箱中的事件，与业务数据在同一事务中写入
+type Message struct {
+	ID            uint64          `gorm:"primarykey;index:idx_outbox_aggregate,priority:4"` // 自增ID，即写入顺序
+	CreatedAt     time.Time       // 写入时间
+	EventID       string          `gorm:"type:varchar(40);not null;uniqueIndex;comment:事件ID"`                                              // 事件ID
+	Event         string          `gorm:"type:varchar(100);not null;index;comment:事件名称"`                                                   // 事件名称
+	AggregateType string          `gorm:"type:varchar(50);not null;index:idx_outbox_aggregate,priority:2;comment:聚合类型"`                    // 聚合类型
+	AggregateID   uint            `gorm:"not null;index:idx_outbox_aggregate,priority:3;comment:聚合ID"`                                     // 聚合ID
+	Payload       json.RawMessage `gorm:"type:jsonb;not null;comment:事件内容"`                                                                // 事件内容
+	Status        string          `gorm:"type:varchar(20);not null;index:idx_outbox_aggregate,priority:1;index:idx_outbox_due,priority:1"` // 状态：pending、processed、failed
+	Attempts      int             `gorm:"not null;default:0;comment:已处理次数"`                                                                // 已处理次数
+	NextAttemptAt time.Time       `gorm:"not null;index:idx_outbox_due,priority:2;comment:下次处理时间"`                                         // 下次处理时间
+	LastError     string          `gorm:"type:text;comment:最近一次错误"`                                                                        // 最近一次错误
+	ProcessedAt   *time.Time      `gorm:"comment:处理完成时间"`                                                                                  // 处理完成时间
+}
+
+// TableName 表名
+func (Message) TableName() string {
+	return "outbox"
+}
+
+// Envelope 投递给订阅方的事件
+type Envelope struct {
+	ID            string          // 事件ID，重试时不变，订阅方可据此去重
+	Event         string          // 事件名称
+	AggregateType string          // 聚合类型
+	AggregateID   uint            // 聚合ID
+	Payload       json.RawMessage // 事件内容
+	CreatedAt     time.Time       // 事件发生时间
+}
+
+// relay 全局转发器，用于事务提交后唤醒
+var relay *Relay
+
+// SetRelay 设置全局转发器
+func SetRelay(r *Relay) {
+	relay = r
+}
+
+// Publish 将事件写入发件箱，tx 需与业务写入处于同一事务（见 database.Transaction）
+// 事务提交后唤醒本实例的转发器；未提交的事件不会被任何订阅方看到
+func Publish(tx *gorm.DB, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	messages := make([]Message, len(events))
+	for i, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		messages[i] = Message{
+			CreatedAt:     now,
+			EventID:       newEventID(),
+			Event:         event.EventName(),
+			AggregateType: event.AggregateType(),
+			AggregateID:   event.AggregateID(),
+			Payload:       payload,
+			Status:        StatusPending,
+			NextAttemptAt: now,
+		}
+	}
+	if err := tx.Create(&messages).Error; err != nil {
+		return err
+	}
+
+	ctx := tx.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	database.AfterCommit(ctx, Notify)
+	return nil
+}
+
+// Notify 唤醒本实例的转发器
+func Notify() {
+	if r := relay; r != nil {
+		r.Notify()
+	}
+}
+
+// newEventID 生成随机事件ID
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "evt_" + time.Now().Format("20060102150405.000000000")
+	}
+	return "evt_" + hex.EncodeToString(b)
+}
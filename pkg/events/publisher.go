@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Publisher 领域事件发布接口
+type Publisher interface {
+	// Publish 发布事件，ctx 在事务中时事件随业务写入一同提交
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// outbox 写入发件箱的发布器
+type outbox struct {
+	db *gorm.DB
+}
+
+// NewOutbox 创建写入发件箱的发布器，通过 db.WithContext(ctx) 加入 ctx 中的事务
+func NewOutbox(db *gorm.DB) Publisher {
+	return outbox{db: db}
+}
+
+// Publish 实现 Publisher 接口
+func (o outbox) Publish(ctx context.Context, events ...Event) error {
+	return Publish(o.db.WithContext(ctx), events...)
+}
+
+// Recorder 在内存中记录事件的发布器，供单元测试断言发布的事件
+type Recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Publish 实现 Publisher 接口
+func (r *Recorder) Publish(_ context.Context, events ...Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, events...)
+	return nil
+}
+
+// Events 返回已发布的事件
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
@@ -0,0 +1,256 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/metrics"
+	"erp_backend/pkg/retry"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// messagesTotal 事件处理结果计数
+var messagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "outbox",
+	Name:      "messages_total",
+	Help:      "发件箱事件处理次数，result 为 processed、retry、failed",
+}, []string{"event", "result"})
+
+func init() {
+	metrics.MustRegister(messagesTotal)
+}
+
+// Handler 事件处理函数，返回错误时事件稍后重新投递
+// 处理函数中通过 db.WithContext(ctx) 执行的 SQL 与标记事件已处理在同一事务中提交
+type Handler func(ctx context.Context, e Envelope) error
+
+// subscriber 具名的事件处理函数
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// Relay 从发件箱领取事件并依次交给订阅方处理，保证至少一次投递与同一聚合内的顺序
+// 多实例部署时通过 SKIP LOCKED 分摊，同一聚合的事件同一时刻只会被一个实例处理
+type Relay struct {
+	db          *gorm.DB
+	cfg         *config.OutboxConfig
+	subscribers map[string][]subscriber
+	all         []subscriber
+	wake        chan struct{}
+}
+
+// NewRelay 创建转发器，订阅需在 Run 之前完成
+func NewRelay(db *gorm.DB, cfg *config.OutboxConfig) *Relay {
+	return &Relay{
+		db:          db,
+		cfg:         cfg,
+		subscribers: make(map[string][]subscriber),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Subscribe 订阅指定名称的事件，name 用于日志
+func (r *Relay) Subscribe(event, name string, handler Handler) {
+	r.subscribers[event] = append(r.subscribers[event], subscriber{name: name, handler: handler})
+}
+
+// SubscribeAll 订阅全部事件，name 用于日志
+func (r *Relay) SubscribeAll(name string, handler Handler) {
+	r.all = append(r.all, subscriber{name: name, handler: handler})
+}
+
+// On 以具体事件类型订阅，事件内容解码为 E 后交给 handler
+func On[E Event](r *Relay, name string, handler func(ctx context.Context, e E) error) {
+	var zero E
+	r.Subscribe(zero.EventName(), name, func(ctx context.Context, envelope Envelope) error {
+		var e E
+		if err := json.Unmarshal(envelope.Payload, &e); err != nil {
+			return err
+		}
+		return handler(ctx, e)
+	})
+}
+
+// Notify 唤醒转发循环，有新事件时无需等到下一次轮询
+func (r *Relay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 循环领取并处理事件，直到 ctx 取消
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		n, err := r.process(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "发件箱事件处理失败", slog.Any("error", err))
+		}
+
+		// 有进展说明可能还有积压（同一聚合每轮只领取一条），立即继续
+		if n > 0 && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// claimSQL 领取到期的事件并加锁，每个聚合只领取最早一条未处理的事件
+// 前一条事件处于重试等待时，同一聚合的后续事件也不会被领取，以保证顺序
+const claimSQL = `
+SELECT * FROM outbox o
+WHERE o.status = @status AND o.next_attempt_at <= now()
+AND NOT EXISTS (
+	SELECT 1 FROM outbox p
+	WHERE p.status = @status AND p.aggregate_type = o.aggregate_type AND p.aggregate_id = o.aggregate_id AND p.id < o.id
+)
+ORDER BY o.id
+LIMIT @limit
+FOR UPDATE SKIP LOCKED`
+
+// process 在一个事务中领取一批事件、交给订阅方并记录结果，返回领取的事件数
+func (r *Relay) process(ctx context.Context) (int, error) {
+	var messages []Message
+	var txCtx context.Context
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Raw(claimSQL, map[string]interface{}{
+			"status": StatusPending,
+			"limit":  r.cfg.BatchSize,
+		}).Scan(&messages).Error
+		if err != nil {
+			return err
+		}
+
+		txCtx = database.WithTx(ctx, tx)
+		for i := range messages {
+			if err := r.dispatch(txCtx, tx.WithContext(txCtx), &messages[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if txCtx != nil {
+		database.Committed(txCtx)
+	}
+	return len(messages), nil
+}
+
+// dispatch 将事件交给全部订阅方，任一订阅方失败时回滚该事件的全部写入并安排重试
+// 返回的错误表示事务已不可用，需放弃整批
+func (r *Relay) dispatch(ctx context.Context, tx *gorm.DB, message *Message) error {
+	envelope := Envelope{
+		ID:            message.EventID,
+		Event:         message.Event,
+		AggregateType: message.AggregateType,
+		AggregateID:   message.AggregateID,
+		Payload:       message.Payload,
+		CreatedAt:     message.CreatedAt,
+	}
+
+	savepoint := fmt.Sprintf("outbox_%d", message.ID)
+	if err := tx.SavePoint(savepoint).Error; err != nil {
+		return err
+	}
+
+	handleErr := r.handle(ctx, envelope)
+	if ctx.Err() != nil {
+		// 服务关闭时放弃整批，事件保持未处理
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	attempts := message.Attempts + 1
+	updates := map[string]interface{}{"attempts": attempts}
+	result := StatusProcessed
+	if handleErr == nil {
+		updates["status"] = StatusProcessed
+		updates["processed_at"] = now
+		updates["last_error"] = ""
+	} else {
+		if err := tx.RollbackTo(savepoint).Error; err != nil {
+			return err
+		}
+		updates["last_error"] = handleErr.Error()
+		if attempts >= r.cfg.MaxAttempts {
+			result = StatusFailed
+			updates["status"] = StatusFailed
+		} else {
+			result = "retry"
+			updates["next_attempt_at"] = now.Add(retry.Backoff(attempts, r.cfg.BackoffBase, r.cfg.BackoffMax))
+		}
+	}
+
+	if err := tx.Model(&Message{}).Where("id = ?", message.ID).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	messagesTotal.WithLabelValues(message.Event, result).Inc()
+	switch result {
+	case "retry":
+		slog.WarnContext(ctx, "发件箱事件处理失败，稍后重试",
+			slog.String("event_id", message.EventID),
+			slog.String("event", message.Event),
+			slog.Int("attempts", attempts),
+			slog.Any("error", handleErr),
+		)
+	case StatusFailed:
+		slog.ErrorContext(ctx, "发件箱事件超过最大处理次数",
+			slog.String("event_id", message.EventID),
+			slog.String("event", message.Event),
+			slog.Int("attempts", attempts),
+			slog.Any("error", handleErr),
+		)
+	}
+	return nil
+}
+
+// handle 依次调用订阅了该事件的处理函数，遇到错误即停止
+func (r *Relay) handle(ctx context.Context, envelope Envelope) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("处理函数 panic: %v", p)
+		}
+	}()
+
+	for _, subscribers := range [][]subscriber{r.subscribers[envelope.Event], r.all} {
+		for _, s := range subscribers {
+			if err := s.handler(ctx, envelope); err != nil {
+				return fmt.Errorf("%s: %w", s.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Cleanup 定期删除超过保留时间的已处理事件，直到 ctx 取消
+func (r *Relay) Cleanup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.db.WithContext(ctx).
+				Where("status = ? AND processed_at < ?", StatusProcessed, time.Now().Add(-r.cfg.Retention)).
+				Delete(&Message{})
+		}
+	}
+}
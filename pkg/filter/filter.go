@@ -0,0 +1,255 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"erp_backend/pkg/errcode"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Type 字段值类型
+type Type int
+
+const (
+	String Type = iota // 字符串
+	Number             // 数值（整数或小数）
+	Bool               // 布尔
+	Time               // 时间，支持 RFC3339 与 2006-01-02
+)
+
+// Fields 允许筛选的字段及其类型，键为数据库列名
+type Fields map[string]Type
+
+// Condition 单个筛选条件
+type Condition struct {
+	Column   string
+	Operator string
+	Values   []interface{}
+}
+
+// Conditions 筛选条件集合
+type Conditions []Condition
+
+// 各类型支持的操作符
+var operators = map[Type][]string{
+	String: {"eq", "ne", "in", "nin", "contains", "ncontains", "startswith", "endswith", "null"},
+	Number: {"eq", "ne", "gt", "gte", "lt", "lte", "in", "nin", "between", "null"},
+	Bool:   {"eq", "ne", "null"},
+	Time:   {"eq", "ne", "gt", "gte", "lt", "lte", "between", "null"},
+}
+
+// Parse 从查询参数中解析 filter[字段][操作符]=值 形式的筛选条件
+// 省略操作符时视为 eq；in/nin 以逗号分隔多个值，between 以逗号分隔上下限
+func Parse(c *gin.Context, fields Fields) (Conditions, error) {
+	return ParseValues(c.Request.URL.Query(), fields)
+}
+
+// ParseValues 从 url.Values 中解析筛选条件
+func ParseValues(query url.Values, fields Fields) (Conditions, error) {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	// 保证生成的 SQL 顺序稳定
+	sort.Strings(keys)
+
+	var conditions Conditions
+	for _, key := range keys {
+		column, operator, ok := parseKey(key)
+		if !ok {
+			return nil, errcode.InvalidFilter.New(key)
+		}
+
+		// 字段不在白名单或类型不支持该操作符
+		fieldType, ok := fields[column]
+		if !ok || !supports(fieldType, operator) {
+			return nil, errcode.InvalidFilter.New(key)
+		}
+
+		for _, raw := range query[key] {
+			values, err := parseValues(fieldType, operator, raw)
+			if err != nil {
+				return nil, errcode.InvalidFilter.New(key)
+			}
+			conditions = append(conditions, Condition{Column: column, Operator: operator, Values: values})
+		}
+	}
+
+	return conditions, nil
+}
+
+// Apply 将筛选条件以参数化方式追加到查询
+// 列名带当前查询的表名，查询关联其他表时不会产生歧义
+func (cs Conditions) Apply(query *gorm.DB) *gorm.DB {
+	for _, cond := range cs {
+		column := clause.Column{Table: clause.CurrentTable, Name: cond.Column}
+		switch cond.Operator {
+		case "eq":
+			query = query.Where("? = ?", column, cond.Values[0])
+		case "ne":
+			query = query.Where("? <> ?", column, cond.Values[0])
+		case "gt":
+			query = query.Where("? > ?", column, cond.Values[0])
+		case "gte":
+			query = query.Where("? >= ?", column, cond.Values[0])
+		case "lt":
+			query = query.Where("? < ?", column, cond.Values[0])
+		case "lte":
+			query = query.Where("? <= ?", column, cond.Values[0])
+		case "in":
+			query = query.Where("? IN ?", column, cond.Values)
+		case "nin":
+			query = query.Where("? NOT IN ?", column, cond.Values)
+		case "between":
+			query = query.Where("? BETWEEN ? AND ?", column, cond.Values[0], cond.Values[1])
+		case "contains":
+			query = query.Where("? ILIKE ?", column, "%"+escapeLike(cond.Values[0].(string))+"%")
+		case "ncontains":
+			query = query.Where("? NOT ILIKE ?", column, "%"+escapeLike(cond.Values[0].(string))+"%")
+		case "startswith":
+			query = query.Where("? ILIKE ?", column, escapeLike(cond.Values[0].(string))+"%")
+		case "endswith":
+			query = query.Where("? ILIKE ?", column, "%"+escapeLike(cond.Values[0].(string)))
+		case "null":
+			if cond.Values[0].(bool) {
+				query = query.Where("? IS NULL", column)
+			} else {
+				query = query.Where("? IS NOT NULL", column)
+			}
+		}
+	}
+	return query
+}
+
+// parseKey 解析 filter[字段] 或 filter[字段][操作符]
+func parseKey(key string) (string, string, bool) {
+	rest := strings.TrimPrefix(key, "filter")
+	var parts []string
+	for rest != "" {
+		if !strings.HasPrefix(rest, "[") {
+			return "", "", false
+		}
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return "", "", false
+		}
+		parts = append(parts, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	switch len(parts) {
+	case 1:
+		return parts[0], "eq", true
+	case 2:
+		return parts[0], strings.ToLower(parts[1]), true
+	default:
+		return "", "", false
+	}
+}
+
+// parseValues 按字段类型和操作符转换参数值
+func parseValues(fieldType Type, operator, raw string) ([]interface{}, error) {
+	switch operator {
+	case "null":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{b}, nil
+	case "in", "nin":
+		var values []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			value, err := convert(fieldType, strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case "between":
+		items := strings.Split(raw, ",")
+		if len(items) != 2 {
+			return nil, fmt.Errorf("between 需要两个值")
+		}
+		lower, err := convert(fieldType, strings.TrimSpace(items[0]))
+		if err != nil {
+			return nil, err
+		}
+		upper, err := convert(fieldType, strings.TrimSpace(items[1]))
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{lower, upper}, nil
+	default:
+		value, err := convert(fieldType, raw)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{value}, nil
+	}
+}
+
+// convert 将字符串转换为字段类型对应的值
+func convert(fieldType Type, raw string) (interface{}, error) {
+	switch fieldType {
+	case Number:
+		if i, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		// ParseFloat 接受 NaN 与 Inf，数据库列中没有对应的值
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("无效的数值: %s", raw)
+		}
+		return f, nil
+	case Bool:
+		return strconv.ParseBool(raw)
+	case Time:
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		return time.ParseInLocation("2006-01-02", raw, time.Local)
+	default:
+		return raw, nil
+	}
+}
+
+// supports 判断类型是否支持该操作符
+func supports(fieldType Type, operator string) bool {
+	for _, op := range operators[fieldType] {
+		if op == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeLike 转义 LIKE 通配符
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// Legacy 将旧版 字段=值 形式的查询参数转换为等值条件，值原样交给数据库比较
+func Legacy(query url.Values, columns ...string) Conditions {
+	var conditions Conditions
+	for _, column := range columns {
+		if value := query.Get(column); value != "" {
+			conditions = append(conditions, Condition{Column: column, Operator: "eq", Values: []interface{}{value}})
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,142 @@
+package filter
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"erp_backend/pkg/errcode"
+)
+
+// item 测试模型
+type item struct {
+	ID    uint
+	Name  string
+	Price float64
+}
+
+// fields 测试使用的字段白名单
+var fields = Fields{
+	"name":       String,
+	"price":      Number,
+	"is_enabled": Bool,
+	"created_at": Time,
+}
+
+func TestParseValues(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name  string
+		query string
+		want  Conditions
+	}{
+		{"省略操作符", "filter[name]=a", Conditions{{Column: "name", Operator: "eq", Values: []interface{}{"a"}}}},
+		{"操作符不区分大小写", "filter[price][GTE]=5", Conditions{{Column: "price", Operator: "gte", Values: []interface{}{int64(5)}}}},
+		{"小数", "filter[price][lt]=9.5", Conditions{{Column: "price", Operator: "lt", Values: []interface{}{9.5}}}},
+		{"in", "filter[price][in]=1, 2,3", Conditions{{Column: "price", Operator: "in", Values: []interface{}{int64(1), int64(2), int64(3)}}}},
+		{"between", "filter[price][between]=1,2.5", Conditions{{Column: "price", Operator: "between", Values: []interface{}{int64(1), 2.5}}}},
+		{"布尔", "filter[is_enabled]=true", Conditions{{Column: "is_enabled", Operator: "eq", Values: []interface{}{true}}}},
+		{"日期", "filter[created_at][gte]=2024-01-02", Conditions{{Column: "created_at", Operator: "gte", Values: []interface{}{day}}}},
+		{"null", "filter[name][null]=false", Conditions{{Column: "name", Operator: "null", Values: []interface{}{false}}}},
+		{"contains", "filter[name][contains]=50%25", Conditions{{Column: "name", Operator: "contains", Values: []interface{}{"50%"}}}},
+		{"按键排序", "filter[price][gt]=1&filter[name]=a", Conditions{
+			{Column: "name", Operator: "eq", Values: []interface{}{"a"}},
+			{Column: "price", Operator: "gt", Values: []interface{}{int64(1)}},
+		}},
+		{"忽略其他参数", "page=2&sort=-price", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("url.ParseQuery() 错误 = %v", err)
+			}
+			got, err := ParseValues(query, fields)
+			if err != nil {
+				t.Fatalf("ParseValues(%q) 错误 = %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseValues(%q) = %#v, 期望 %#v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValuesRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"字段不在白名单", "filter[password]=a"},
+		{"类型不支持操作符", "filter[is_enabled][gt]=true"},
+		{"字符串不支持比较", "filter[name][gte]=a"},
+		{"未知操作符", "filter[price][like]=1"},
+		{"键格式错误", "filter[price]x=1"},
+		{"键层级过多", "filter[price][gt][x]=1"},
+		{"括号未闭合", "filter[price=1"},
+		{"非数值", "filter[price]=abc"},
+		{"NaN", "filter[price]=NaN"},
+		{"Inf", "filter[price][gt]=Inf"},
+		{"负 Inf", "filter[price][in]=1,-Infinity"},
+		{"between 缺少上限", "filter[price][between]=1"},
+		{"非布尔", "filter[is_enabled]=yes"},
+		{"null 非布尔", "filter[name][null]=maybe"},
+		{"时间格式错误", "filter[created_at]=2024/01/02"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("url.ParseQuery() 错误 = %v", err)
+			}
+			_, err = ParseValues(query, fields)
+			if e, ok := err.(*errcode.Error); !ok || e.Code != errcode.InvalidFilter {
+				t.Errorf("ParseValues(%q) 错误 = %v, 期望 %s", tt.query, err, errcode.InvalidFilter.Key)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1"}), &gorm.Config{
+		DisableAutomaticPing: true,
+		DryRun:               true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"filter[price][gte]=5", `SELECT * FROM "items" WHERE "items"."price" >= 5`},
+		{"filter[price][nin]=1,2", `SELECT * FROM "items" WHERE "items"."price" NOT IN (1,2)`},
+		{"filter[price][between]=1,2", `SELECT * FROM "items" WHERE "items"."price" BETWEEN 1 AND 2`},
+		{"filter[name][startswith]=a_b", `SELECT * FROM "items" WHERE "items"."name" ILIKE 'a\_b%'`},
+		{"filter[name][null]=true", `SELECT * FROM "items" WHERE "items"."name" IS NULL`},
+		{"filter[name][ne]=a&filter[price][lt]=3", `SELECT * FROM "items" WHERE "items"."name" <> 'a' AND "items"."price" < 3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			conditions, err := ParseValues(query, fields)
+			if err != nil {
+				t.Fatalf("ParseValues(%q) 错误 = %v", tt.query, err)
+			}
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				var items []item
+				return conditions.Apply(tx.Model(&item{})).Find(&items)
+			})
+			if sql != tt.want {
+				t.Errorf("Apply(%q) = %s, 期望 %s", tt.query, sql, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+const (
+	ZhCN = "zh-CN" // 简体中文
+	EnUS = "en-US" // 美式英语
+
+	// Default 默认语言，也是所有语言的最终回退
+	Default = ZhCN
+
+	// ContextKey 上下文中保存用户语言偏好的键
+	ContextKey = "language"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// bundles 各语言的消息表，键为错误码或消息标识
+var bundles = map[string]map[string]string{}
+
+// supported 支持的语言，第一个为默认语言
+var supported = []language.Tag{language.MustParse(ZhCN), language.MustParse(EnUS)}
+
+var matcher = language.NewMatcher(supported)
+
+func init() {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: 解析 %s 失败: %v", entry.Name(), err))
+		}
+		bundles[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+}
+
+// Supported 判断是否为支持的语言
+func Supported(locale string) bool {
+	_, ok := bundles[locale]
+	return ok
+}
+
+// Match 将任意语言标签（如 en、en-GB、zh-Hans）匹配为支持的语言
+func Match(tags ...string) string {
+	var parsed []language.Tag
+	for _, tag := range tags {
+		if t, err := language.Parse(tag); err == nil {
+			parsed = append(parsed, t)
+		}
+	}
+	if len(parsed) == 0 {
+		return Default
+	}
+	_, index, confidence := matcher.Match(parsed...)
+	if confidence == language.No {
+		return Default
+	}
+	return supported[index].String()
+}
+
+// Locale 解析请求使用的语言
+// 优先级：lang 查询参数 > 用户偏好 > Accept-Language 请求头 > 默认语言
+func Locale(c *gin.Context) string {
+	if lang := c.Query("lang"); lang != "" {
+		return Match(lang)
+	}
+	if preference := c.GetString(ContextKey); preference != "" {
+		return Match(preference)
+	}
+	if header := c.GetHeader("Accept-Language"); header != "" {
+		tags, _, err := language.ParseAcceptLanguage(header)
+		if err == nil && len(tags) > 0 {
+			_, index, confidence := matcher.Match(tags...)
+			if confidence != language.No {
+				return supported[index].String()
+			}
+		}
+	}
+	return Default
+}
+
+// localeKey 语言在 context 中的键
+type localeKey struct{}
+
+// WithLocale 将请求使用的语言写入 context，供 gRPC 等非 gin 入口使用
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext 解析 ctx 所属请求使用的语言：HTTP 请求同 Locale，其他入口取 WithLocale 写入的语言，
+// 均没有时为默认语言；供服务层等只持有 context 的代码生成本地化的提示信息
+func FromContext(ctx context.Context) string {
+	if c, ok := ctx.Value(gin.ContextKey).(*gin.Context); ok {
+		return Locale(c)
+	}
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale
+	}
+	return Default
+}
+
+// Translate 查找消息，当前语言缺失时回退到默认语言，均缺失时使用 fallback
+func Translate(locale, key, fallback string, args ...interface{}) string {
+	message, ok := bundles[locale][key]
+	if !ok {
+		message, ok = bundles[Default][key]
+	}
+	if !ok {
+		message = fallback
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// T 按请求语言翻译消息，找不到时返回 key 本身
+func T(c *gin.Context, key string, args ...interface{}) string {
+	return Translate(Locale(c), key, key, args...)
+}
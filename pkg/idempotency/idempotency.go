@@ -0,0 +1,143 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"erp_backend/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// 幂等键状态
+const (
+	StatusProcessing = "processing" // 首个请求处理中
+	StatusCompleted  = "completed"  // 已完成，保存了响应
+)
+
+// Record 幂等键记录
+type Record struct {
+	Key            string    `gorm:"primaryKey;type:varchar(400)"`         // 调用方标识与幂等键
+	RequestHash    string    `gorm:"type:char(64);not null"`               // 请求方法、路径与请求体的摘要
+	Status         string    `gorm:"type:varchar(20);not null"`            // 状态
+	Owner          string    `gorm:"type:varchar(32);not null;default:''"` // 占用者标识，处理中超时被接管后原请求不能再保存或释放
+	ResponseStatus int       `gorm:"not null;default:0"`                   // 响应状态码
+	ContentType    string    `gorm:"type:varchar(100)"`                    // 响应类型
+	ResponseBody   []byte    `gorm:"type:bytea"`                           // 响应体
+	LockedUntil    time.Time `gorm:"not null"`                             // 处理中状态的过期时间
+	ExpiresAt      time.Time `gorm:"not null;index"`                       // 记录过期时间
+	CreatedAt      time.Time `gorm:"not null"`                             // 创建时间
+}
+
+// TableName 表名
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// Hash 计算请求摘要，同一幂等键的重试必须与首个请求的摘要一致
+func Hash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + "\n" + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Store 基于 PostgreSQL 的幂等键存储，多实例共享
+type Store struct {
+	db  *gorm.DB
+	cfg *config.IdempotencyConfig
+}
+
+// NewStore 创建幂等键存储
+func NewStore(db *gorm.DB, cfg *config.IdempotencyConfig) *Store {
+	return &Store{db: db, cfg: cfg}
+}
+
+// WaitTimeout 并发请求等待前一个请求完成的最长时间
+func (s *Store) WaitTimeout() time.Duration {
+	return s.cfg.WaitTimeout
+}
+
+// MaxBodySize 携带幂等键的请求体上限（字节）
+func (s *Store) MaxBodySize() int64 {
+	return int64(s.cfg.MaxBodySize)
+}
+
+// acquireSQL 插入处理中的记录；已有记录过期或处理中状态超时时接管该记录
+const acquireSQL = `
+INSERT INTO idempotency_keys AS k (key, request_hash, status, owner, response_status, locked_until, expires_at, created_at)
+VALUES (@key, @hash, @status, @owner, 0, now() + make_interval(secs => @lock), now() + make_interval(secs => @ttl), now())
+ON CONFLICT (key) DO UPDATE SET
+	request_hash = EXCLUDED.request_hash,
+	status = EXCLUDED.status,
+	owner = EXCLUDED.owner,
+	response_status = 0,
+	content_type = NULL,
+	response_body = NULL,
+	locked_until = EXCLUDED.locked_until,
+	expires_at = EXCLUDED.expires_at,
+	created_at = EXCLUDED.created_at
+WHERE k.expires_at < now() OR (k.status = @status AND k.locked_until < now())`
+
+// Acquire 尝试占用幂等键，占用成功时返回本次占用的标识 owner，之后以它调用 Complete 或 Release；
+// 并发的相同请求中只有一个能占用成功，由数据库主键保证
+func (s *Store) Acquire(ctx context.Context, key, hash string) (owner string, acquired bool, err error) {
+	b := make([]byte, 16)
+	rand.Read(b)
+	owner = hex.EncodeToString(b)
+
+	result := s.db.WithContext(ctx).Exec(acquireSQL, map[string]interface{}{
+		"key":    key,
+		"hash":   hash,
+		"status": StatusProcessing,
+		"owner":  owner,
+		"lock":   s.cfg.LockTimeout.Seconds(),
+		"ttl":    s.cfg.TTL.Seconds(),
+	})
+	return owner, result.RowsAffected > 0, result.Error
+}
+
+// Get 获取幂等键记录
+func (s *Store) Get(ctx context.Context, key string) (*Record, error) {
+	var record Record
+	if err := s.db.WithContext(ctx).Where("key = ?", key).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Complete 保存响应，之后的重试直接返回该响应
+// 只更新 owner 仍持有的记录：处理超过 LockTimeout 被其他请求接管后，原请求的结果不再保存
+func (s *Store) Complete(ctx context.Context, key, owner string, status int, contentType string, body []byte) error {
+	return s.db.WithContext(ctx).Model(&Record{}).
+		Where("key = ? AND status = ? AND owner = ?", key, StatusProcessing, owner).
+		Updates(map[string]interface{}{
+			"status":          StatusCompleted,
+			"response_status": status,
+			"content_type":    contentType,
+			"response_body":   body,
+		}).Error
+}
+
+// Release 释放幂等键，用于处理失败（服务端错误）的请求，使重试可以重新处理
+// 与 Complete 相同，只删除 owner 仍持有的记录，不会删除接管者的记录
+func (s *Store) Release(ctx context.Context, key, owner string) error {
+	return s.db.WithContext(ctx).Where("key = ? AND status = ? AND owner = ?", key, StatusProcessing, owner).Delete(&Record{}).Error
+}
+
+// Cleanup 定期删除过期的幂等键，直到 ctx 取消
+func (s *Store) Cleanup(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.db.WithContext(ctx).Where("expires_at < now()").Delete(&Record{})
+		}
+	}
+}
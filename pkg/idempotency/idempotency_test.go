@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"erp_backend/pkg/config"
+)
+
+func TestHash(t *testing.T) {
+	base := Hash("POST", "/orders", []byte(`{"qty":1}`))
+	if len(base) != 64 || base != Hash("POST", "/orders", []byte(`{"qty":1}`)) {
+		t.Fatalf("Hash() = %q, 期望稳定的 64 位十六进制摘要", base)
+	}
+	for _, other := range []string{
+		Hash("PATCH", "/orders", []byte(`{"qty":1}`)),
+		Hash("POST", "/orders?x=1", []byte(`{"qty":1}`)),
+		Hash("POST", "/orders", []byte(`{"qty":2}`)),
+		// 方法、路径与请求体之间有分隔，拼接结果相同的请求摘要不同
+		Hash("POST", "/orders\n{", []byte(`"qty":1}`)),
+	} {
+		if other == base {
+			t.Errorf("Hash() 不同请求的摘要相同: %s", other)
+		}
+	}
+}
+
+// TestStore 需设置 ERP_TEST_DATABASE_DSN 指向可写的 PostgreSQL 数据库
+func TestStore(t *testing.T) {
+	dsn := os.Getenv("ERP_TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("未设置 ERP_TEST_DATABASE_DSN")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+	if err := db.AutoMigrate(&Record{}); err != nil {
+		t.Fatalf("AutoMigrate() 错误 = %v", err)
+	}
+	ctx := context.Background()
+	store := NewStore(db, &config.IdempotencyConfig{TTL: time.Hour, LockTimeout: time.Second, WaitTimeout: time.Second})
+	prefix := fmt.Sprintf("test-%d:", time.Now().UnixNano())
+	t.Cleanup(func() { db.Where("key LIKE ?", prefix+"%").Delete(&Record{}) })
+
+	acquire := func(key, hash string) (string, bool) {
+		t.Helper()
+		owner, acquired, err := store.Acquire(ctx, key, hash)
+		if err != nil {
+			t.Fatalf("Acquire() 错误 = %v", err)
+		}
+		return owner, acquired
+	}
+
+	// 同一幂等键只有一个请求能占用，完成后保存响应
+	key := prefix + "a"
+	owner, acquired := acquire(key, "h1")
+	if !acquired {
+		t.Fatal("首次 Acquire() 未占用")
+	}
+	if _, acquired := acquire(key, "h1"); acquired {
+		t.Fatal("处理中的幂等键被重复占用")
+	}
+	if err := store.Complete(ctx, key, "other", 201, "application/json", []byte(`{}`)); err != nil {
+		t.Fatalf("Complete() 错误 = %v", err)
+	}
+	if record, _ := store.Get(ctx, key); record.Status != StatusProcessing {
+		t.Fatalf("非占用者 Complete() 后状态 = %s, 期望仍为 %s", record.Status, StatusProcessing)
+	}
+	if err := store.Complete(ctx, key, owner, 201, "application/json", []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Complete() 错误 = %v", err)
+	}
+	record, err := store.Get(ctx, key)
+	if err != nil || record.Status != StatusCompleted || record.ResponseStatus != 201 || string(record.ResponseBody) != `{"id":1}` || record.RequestHash != "h1" {
+		t.Fatalf("Get() = (%+v, %v), 期望保存的响应", record, err)
+	}
+	if _, acquired := acquire(key, "h1"); acquired {
+		t.Fatal("已完成的幂等键被重新占用")
+	}
+
+	// 释放后可重新占用；已完成的记录不会被释放
+	key = prefix + "b"
+	owner, _ = acquire(key, "h1")
+	if err := store.Release(ctx, key, "other"); err != nil {
+		t.Fatalf("Release() 错误 = %v", err)
+	}
+	if _, err := store.Get(ctx, key); err != nil {
+		t.Fatalf("非占用者 Release() 后 Get() 错误 = %v, 期望记录仍在", err)
+	}
+	if err := store.Release(ctx, key, owner); err != nil {
+		t.Fatalf("Release() 错误 = %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("Release() 后 Get() 错误 = %v, 期望记录不存在", err)
+	}
+	if _, acquired := acquire(key, "h2"); !acquired {
+		t.Fatal("释放后 Acquire() 未占用")
+	}
+
+	// 处理中超过 LockTimeout 后被接管，原占用者不能再保存结果
+	key = prefix + "c"
+	stale, _ := acquire(key, "h1")
+	time.Sleep(1100 * time.Millisecond)
+	current, acquired := acquire(key, "h2")
+	if !acquired || current == stale {
+		t.Fatal("超时的幂等键未被接管")
+	}
+	store.Complete(ctx, key, stale, 201, "application/json", []byte(`{}`))
+	if record, _ := store.Get(ctx, key); record.Status != StatusProcessing || record.RequestHash != "h2" {
+		t.Errorf("原占用者 Complete() 后记录 = %+v, 期望仍由接管者持有", record)
+	}
+}
@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron 定时计划的执行规则
+type Cron interface {
+	// Next 返回 t 之后的下一次执行时间
+	Next(t time.Time) time.Time
+}
+
+// cronAliases 预定义的规则
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField 字段取值范围
+type cronField struct {
+	name     string
+	min, max int
+}
+
+// cronFields 分、时、日、月、星期，星期的 7 等同于 0（周日）
+var cronFields = []cronField{
+	{"分", 0, 59},
+	{"时", 0, 23},
+	{"日", 1, 31},
+	{"月", 1, 12},
+	{"星期", 0, 7},
+}
+
+// ParseCron 解析执行规则，支持标准的 5 段格式（分 时 日 月 星期，可用 *、列表、范围与步长，如 */15 9-18 * * 1-5）、
+// @hourly、@daily 等预定义规则以及 @every <间隔>（如 @every 10m）；时间按服务所在时区计算
+// 夏令时开始时跳过的时间不会执行；夏令时结束时重复的时段，小时为 * 的规则按实际经过的时间两次执行，其余规则只执行一次
+func ParseCron(spec string) (Cron, error) {
+	spec = strings.TrimSpace(spec)
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || d < time.Second {
+			return nil, fmt.Errorf("无效的间隔 %q，至少为 1s", interval)
+		}
+		return every(d), nil
+	}
+	if alias, ok := cronAliases[spec]; ok {
+		spec = alias
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("无效的执行规则 %q，需为 5 段：分 时 日 月 星期", spec)
+	}
+	var s schedule
+	bits := []*uint64{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, part := range parts {
+		b, err := parseField(part, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		*bits[i] = b
+	}
+	// 星期 7 与 0 都表示周日
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.anyHour = parts[1] == "*"
+	s.anyDom = parts[2] == "*"
+	s.anyDow = parts[4] == "*"
+	return &s, nil
+}
+
+// parseField 解析单个字段，返回取值的位图
+func parseField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s字段的步长 %q 无效", field.name, stepExpr)
+			}
+			step = n
+		}
+
+		low, high := field.min, field.max
+		if rangeExpr != "*" {
+			lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = strconv.Atoi(lowExpr); err != nil {
+				return 0, fmt.Errorf("%s字段的取值 %q 无效", field.name, item)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highExpr); err != nil {
+					return 0, fmt.Errorf("%s字段的取值 %q 无效", field.name, item)
+				}
+			} else if hasStep {
+				high = field.max
+			}
+		}
+		if low < field.min || high > field.max || low > high {
+			return 0, fmt.Errorf("%s字段的取值 %q 超出范围 %d-%d", field.name, item, field.min, field.max)
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// schedule 5 段格式的执行规则
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	anyHour, anyDom, anyDow       bool
+}
+
+// Next 实现 Cron 接口，逐级跳过不匹配的月、日、时、分；5 年内没有匹配的时间时返回零值
+func (s *schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = later(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location()))
+		case !s.matchDay(t):
+			t = later(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()))
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			// 按经过的分钟数前进到下一个整点，time.Date 构造夏令时跳过的整点时可能回到当前小时
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case s.minute&(1<<uint(t.Minute())) == 0, !s.anyHour && repeated(t):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// later 返回 next，本地时间因夏令时不存在、time.Date 换算后没有晚于 t 时改为 t 之后一小时
+func later(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Hour)
+}
+
+// repeated 判断 t 是否处于夏令时结束后重复的时段，且同一时刻在切换前已出现过
+func repeated(t time.Time) bool {
+	_, offset := t.Zone()
+	_, before := t.Add(-time.Hour).Zone()
+	if before <= offset {
+		return false
+	}
+	first := t.Add(-time.Duration(before-offset) * time.Second)
+	return first.Day() == t.Day() && first.Hour() == t.Hour() && first.Minute() == t.Minute()
+}
+
+// matchDay 日与星期都有限制时满足其一即可，与标准 cron 一致
+func (s *schedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.anyDom || s.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// every 固定间隔的执行规则
+type every time.Duration
+
+// Next 实现 Cron 接口
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
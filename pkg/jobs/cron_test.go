@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// at 构造指定时区的时间
+func at(loc *time.Location, year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, loc)
+}
+
+func TestCronNext(t *testing.T) {
+	utc := time.UTC
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", at(utc, 2024, 1, 1, 10, 7).Add(30 * time.Second), at(utc, 2024, 1, 1, 10, 8)},
+		{"5,10 * * * *", at(utc, 2024, 1, 1, 10, 7), at(utc, 2024, 1, 1, 10, 10)},
+		{"5,10 * * * *", at(utc, 2024, 1, 1, 10, 10), at(utc, 2024, 1, 1, 11, 5)},
+		{"10-20/5 * * * *", at(utc, 2024, 1, 1, 0, 0), at(utc, 2024, 1, 1, 0, 10)},
+		{"10-20/5 * * * *", at(utc, 2024, 1, 1, 0, 16), at(utc, 2024, 1, 1, 0, 20)},
+		{"10-20/5 * * * *", at(utc, 2024, 1, 1, 0, 20), at(utc, 2024, 1, 1, 1, 10)},
+		{"7/20 * * * *", at(utc, 2024, 1, 1, 0, 30), at(utc, 2024, 1, 1, 0, 47)},
+		{"*/15 9-18 * * 1-5", at(utc, 2024, 1, 5, 18, 50), at(utc, 2024, 1, 8, 9, 0)},
+		{"0 0 * * 7", at(utc, 2024, 1, 1, 0, 0), at(utc, 2024, 1, 7, 0, 0)},
+		{"0 12 13 * 5", at(utc, 2024, 1, 1, 0, 0), at(utc, 2024, 1, 5, 12, 0)},
+		{"0 0 29 2 *", at(utc, 2024, 3, 1, 0, 0), at(utc, 2028, 2, 29, 0, 0)},
+		{"0 0 31 * *", at(utc, 2024, 4, 1, 0, 0), at(utc, 2024, 5, 31, 0, 0)},
+		{"0 0 30 2 *", at(utc, 2024, 1, 1, 0, 0), time.Time{}},
+		{"@yearly", at(utc, 2024, 6, 1, 0, 0), at(utc, 2025, 1, 1, 0, 0)},
+		{"@hourly", at(utc, 2024, 12, 31, 23, 30), at(utc, 2025, 1, 1, 0, 0)},
+		{"@every 10m", at(utc, 2024, 1, 1, 0, 3), at(utc, 2024, 1, 1, 0, 13)},
+	}
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) 错误 = %v", tt.spec, err)
+		}
+		if got := cron.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next(%v) = %v, 期望 %v", tt.spec, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestCronNextDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() 错误 = %v", err)
+	}
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("LoadLocation() 错误 = %v", err)
+	}
+	// 2024-03-10 02:00 EST 跳到 03:00 EDT；2024-11-03 02:00 EDT 回到 01:00 EST，01:00-02:00 出现两次
+	firstOneThirty := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(ny)
+	secondOne := time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC).In(ny)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"跳过的时间不执行", "30 2 * * *", at(ny, 2024, 3, 9, 3, 0), at(ny, 2024, 3, 11, 2, 30)},
+		{"每小时跨过跳过的时段", "0 * * * *", at(ny, 2024, 3, 10, 1, 30), at(ny, 2024, 3, 10, 3, 0)},
+		{"切换前后按本地时间", "0 3 * * *", at(ny, 2024, 3, 10, 1, 0), at(ny, 2024, 3, 10, 3, 0)},
+		{"重复时段首次执行", "30 1 * * *", at(ny, 2024, 11, 3, 0, 0), firstOneThirty},
+		{"重复时段只执行一次", "30 1 * * *", firstOneThirty, at(ny, 2024, 11, 4, 1, 30)},
+		{"每小时按实际时间执行两次", "0 * * * *", firstOneThirty, secondOne},
+		{"每半小时按实际时间执行", "*/30 * * * *", firstOneThirty, secondOne},
+		{"重复时段之后", "0 2 * * *", firstOneThirty, at(ny, 2024, 11, 3, 2, 0)},
+		// 2018-11-04 00:00 跳到 01:00，当天没有零点
+		{"跨过没有零点的日期", "0 12 * * *", at(saoPaulo, 2018, 11, 3, 13, 0), at(saoPaulo, 2018, 11, 4, 12, 0)},
+		{"不存在的零点不执行", "0 0 * * *", at(saoPaulo, 2018, 11, 3, 1, 0), at(saoPaulo, 2018, 11, 5, 0, 0)},
+		{"跨过没有零点的月初", "0 12 * 12 *", at(saoPaulo, 2018, 11, 3, 13, 0), at(saoPaulo, 2018, 12, 1, 12, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) 错误 = %v", tt.spec, err)
+			}
+			if got := cron.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("ParseCron(%q).Next(%v) = %v, 期望 %v", tt.spec, tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-2-3 * * * *",
+		"-1 * * * *",
+		"1,,2 * * * *",
+		"@weekdays",
+		"@every 500ms",
+		"@every x",
+	} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) 期望返回错误", spec)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+)
+
+// 任务状态
+const (
+	StatusPending   = "pending"   // 等待执行或重试
+	StatusRunning   = "running"   // 执行中
+	StatusSucceeded = "succeeded" // 执行成功
+	StatusFailed    = "failed"    // 超过最大执行次数仍失败
+	StatusCancelled = "cancelled" // 已取消
+)
+
+var (
+	// ErrNotRetryable 只有失败或已取消的任务可以重试
+	ErrNotRetryable = errors.New("任务未结束或已成功，不能重试")
+	// ErrNotCancellable 只有等待中或执行中的任务可以取消
+	ErrNotCancellable = errors.New("任务已结束，不能取消")
+)
+
+// Job 后台任务
+// @Description 后台任务
+type Job struct {
+	ID          uint            `gorm:"primarykey" json:"id"`                                                                          // 主键ID
+	CreatedAt   time.Time       `json:"created_at"`                                                                                    // 入队时间
+	UpdatedAt   time.Time       `json:"updated_at"`                                                                                    // 更新时间
+	Kind        string          `gorm:"type:varchar(100);not null;index;comment:任务类型" json:"kind"`                                     // 任务类型，如 product.low_stock_scan
+	Payload     json.RawMessage `gorm:"type:jsonb;not null;comment:任务参数" json:"payload" swaggertype:"object"`                          // 任务参数
+	Status      string          `gorm:"type:varchar(20);not null;index:idx_jobs_due,priority:1" json:"status"`                         // 状态：pending、running、succeeded、failed、cancelled
+	RunAt       time.Time       `gorm:"not null;index:idx_jobs_due,priority:2;comment:最早执行时间" json:"run_at"`                           // 最早执行时间，重试时为下次执行时间
+	Attempts    int             `gorm:"not null;default:0;comment:已执行次数" json:"attempts"`                                              // 已执行次数
+	MaxAttempts int             `gorm:"not null;comment:最大执行次数" json:"max_attempts"`                                                   // 最大执行次数
+	UniqueKey   *string         `gorm:"type:varchar(255);uniqueIndex:idx_jobs_unique_key,where:finished_at IS NULL" json:"unique_key"` // 唯一键，同一唯一键同时只有一个未结束的任务
+	Schedule    string          `gorm:"type:varchar(100);index;comment:产生任务的定时计划" json:"schedule"`                                     // 产生任务的定时计划，手动入队时为空
+	LockedBy    string          `gorm:"type:varchar(100);comment:执行实例" json:"locked_by"`                                               // 执行实例
+	LockedUntil *time.Time      `gorm:"comment:执行租约到期时间" json:"locked_until"`                                                          // 执行租约到期时间，实例在租约内退出时任务到期后会被重新领取
+	LastError   string          `gorm:"type:text;comment:最近一次错误" json:"last_error"`                                                    // 最近一次错误
+	StartedAt   *time.Time      `gorm:"comment:最近一次开始执行时间" json:"started_at"`                                                          // 最近一次开始执行时间
+	FinishedAt  *time.Time      `gorm:"index;comment:结束时间" json:"finished_at"`                                                         // 结束时间（成功、失败或取消）
+}
+
+// TableName 表名
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// Decode 将任务参数解析到 v
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Schedule 定时计划，由模块在代码中声明，服务启动时同步到数据库；启用状态由管理员维护
+// @Description 后台任务的定时计划
+type Schedule struct {
+	Name      string          `gorm:"type:varchar(100);primarykey" json:"name"`                             // 计划名称
+	CreatedAt time.Time       `json:"created_at"`                                                           // 创建时间
+	UpdatedAt time.Time       `json:"updated_at"`                                                           // 更新时间
+	Cron      string          `gorm:"type:varchar(100);not null;comment:执行规则" json:"cron"`                  // 执行规则，如 0 * * * *、@daily、@every 10m
+	Kind      string          `gorm:"type:varchar(100);not null;comment:任务类型" json:"kind"`                  // 任务类型
+	Payload   json.RawMessage `gorm:"type:jsonb;not null;comment:任务参数" json:"payload" swaggertype:"object"` // 任务参数
+	IsEnabled bool            `gorm:"not null;default:true;comment:是否启用" json:"is_enabled"`                 // 是否启用
+	NextRunAt time.Time       `gorm:"not null;comment:下次执行时间" json:"next_run_at"`                           // 下次执行时间
+	LastRunAt *time.Time      `gorm:"comment:上次执行时间" json:"last_run_at"`                                    // 上次入队时间
+	LastJobID *uint           `gorm:"comment:上次产生的任务ID" json:"last_job_id"`                                 // 上次产生的任务ID
+}
+
+// TableName 表名
+func (Schedule) TableName() string {
+	return "job_schedules"
+}
+
+// Options 入队选项
+type Options struct {
+	RunAt       time.Time     // 最早执行时间，为零值时按 Delay 计算
+	Delay       time.Duration // 延迟执行的时间，RunAt 与 Delay 均为零值时立即执行
+	UniqueKey   string        // 唯一键，已有相同唯一键的未结束任务时不再入队，返回已有任务
+	MaxAttempts int           // 最大执行次数，为 0 时使用任务类型的设置或 JOBS_MAX_ATTEMPTS
+}
+
+// queue 全局队列，用于入队后唤醒与取消本实例执行中的任务
+var queue *Queue
+
+// SetQueue 设置全局队列
+func SetQueue(q *Queue) {
+	queue = q
+}
+
+// Enqueue 将任务加入队列，payload 序列化为 JSON 作为任务参数
+// db 可以是事务（或 ctx 中带有 database.Transaction 开启的事务），任务与业务数据一同提交，提交后唤醒本实例的队列
+func Enqueue(ctx context.Context, db *gorm.DB, kind string, payload interface{}, opts Options) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{Kind: kind, Payload: data, Status: StatusPending, RunAt: opts.RunAt, MaxAttempts: opts.MaxAttempts}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now().Add(opts.Delay)
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = defaultMaxAttempts(kind)
+	}
+	if opts.UniqueKey != "" {
+		job.UniqueKey = &opts.UniqueKey
+	}
+
+	if job, err = insert(db.WithContext(ctx), job); err != nil {
+		return nil, err
+	}
+	database.AfterCommit(ctx, Notify)
+	return job, nil
+}
+
+// insert 写入任务；唯一键冲突时返回已有的未结束任务
+func insert(db *gorm.DB, job *Job) (*Job, error) {
+	// 已有任务恰好在两次查询之间结束时重新写入
+	for i := 0; i < 2; i++ {
+		result := db.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "unique_key"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "finished_at IS NULL"}}},
+			DoNothing:   true,
+		}).Create(job)
+		if result.Error != nil || result.RowsAffected > 0 || job.UniqueKey == nil {
+			return job, result.Error
+		}
+
+		var existing Job
+		err := db.Where("unique_key = ? AND finished_at IS NULL", *job.UniqueKey).First(&existing).Error
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return &existing, err
+		}
+	}
+	return nil, errors.New("任务入队失败：唯一键冲突")
+}
+
+// defaultMaxAttempts 任务类型在本实例注册时设置的最大执行次数，未设置时使用全局配置
+func defaultMaxAttempts(kind string) int {
+	if q := queue; q != nil {
+		return q.maxAttempts(kind)
+	}
+	return config.GetJobsConfig().MaxAttempts
+}
+
+// Notify 唤醒本实例的队列
+func Notify() {
+	if q := queue; q != nil {
+		q.Notify()
+	}
+}
+
+// Retry 将失败或已取消的任务重置为等待执行，执行次数清零
+func Retry(ctx context.Context, db *gorm.DB, id uint) (*Job, error) {
+	var job Job
+	if err := db.WithContext(ctx).First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	if job.Status != StatusFailed && job.Status != StatusCancelled {
+		return nil, ErrNotRetryable
+	}
+
+	result := db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND status IN ?", id, []string{StatusFailed, StatusCancelled}).
+		Updates(map[string]interface{}{
+			"status":       StatusPending,
+			"attempts":     0,
+			"run_at":       time.Now(),
+			"locked_by":    "",
+			"locked_until": nil,
+			"finished_at":  nil,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrNotRetryable
+	}
+	Notify()
+	return &job, db.WithContext(ctx).First(&job, id).Error
+}
+
+// Cancel 取消等待中或执行中的任务
+// 执行中的任务若在本实例运行则立即中止；在其他实例运行的任务会继续执行到结束，但结果不再写入
+func Cancel(ctx context.Context, db *gorm.DB, id uint) (*Job, error) {
+	var job Job
+	if err := db.WithContext(ctx).First(&job, id).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND status IN ?", id, []string{StatusPending, StatusRunning}).
+		Updates(map[string]interface{}{
+			"status":       StatusCancelled,
+			"locked_until": nil,
+			"finished_at":  now,
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrNotCancellable
+	}
+	if q := queue; q != nil {
+		q.abort(id)
+	}
+	return &job, db.WithContext(ctx).First(&job, id).Error
+}
+
+// RunSchedule 立即按定时计划入队一次，不影响下次执行时间；计划上次产生的任务未结束时返回该任务
+func RunSchedule(ctx context.Context, db *gorm.DB, name string) (*Job, error) {
+	var schedule Schedule
+	if err := db.WithContext(ctx).First(&schedule, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return enqueueSchedule(db.WithContext(ctx), &schedule, time.Now())
+}
+
+// enqueueSchedule 按定时计划入队并记录到计划上，以计划名作为唯一键，上次的任务未结束时不重复入队
+func enqueueSchedule(db *gorm.DB, schedule *Schedule, now time.Time) (*Job, error) {
+	key := "schedule:" + schedule.Name
+	job, err := insert(db, &Job{
+		Kind:        schedule.Kind,
+		Payload:     schedule.Payload,
+		Status:      StatusPending,
+		RunAt:       now,
+		MaxAttempts: defaultMaxAttempts(schedule.Kind),
+		UniqueKey:   &key,
+		Schedule:    schedule.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Model(&Schedule{}).Where("name = ?", schedule.Name).Updates(map[string]interface{}{
+		"last_run_at": now,
+		"last_job_id": job.ID,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	database.AfterCommit(db.Statement.Context, Notify)
+	return job, nil
+}
@@ -0,0 +1,503 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/metrics"
+	"erp_backend/pkg/retry"
+)
+
+// jobsTotal 任务执行结果计数
+var jobsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "jobs",
+	Name:      "executions_total",
+	Help:      "后台任务执行次数，result 为 succeeded、retry、failed",
+}, []string{"kind", "result"})
+
+func init() {
+	metrics.MustRegister(jobsTotal)
+}
+
+// Handler 任务处理函数，返回错误时按指数退避重试；ctx 在超时、取消或服务关闭时取消
+// 任务可能因实例退出而重复执行，处理函数需可重复执行
+type Handler func(ctx context.Context, job *Job) error
+
+// Definition 任务类型
+type Definition struct {
+	Kind        string        // 任务类型，全局唯一，格式为 模块.动作，如 product.low_stock_scan
+	Handler     Handler       // 处理函数
+	MaxAttempts int           // 最大执行次数，为 0 时使用 JOBS_MAX_ATTEMPTS
+	Timeout     time.Duration // 单次执行的超时时间，为 0 时使用 JOBS_TIMEOUT
+}
+
+// scheduled 模块声明的定时计划
+type scheduled struct {
+	Schedule
+	cron Cron
+}
+
+// Queue 基于数据库的任务队列：工作池领取到期任务执行，多实例部署时通过 SKIP LOCKED 分摊；
+// 调度器按定时计划入队，每个计划同时只有一个未结束的任务
+type Queue struct {
+	db        *gorm.DB
+	cfg       *config.JobsConfig
+	worker    string
+	defs      map[string]Definition
+	schedules map[string]*scheduled
+	wake      chan struct{}
+
+	mu      sync.Mutex
+	running map[uint]context.CancelFunc
+}
+
+// NewQueue 创建队列，任务类型与定时计划需在 Run 之前注册
+func NewQueue(db *gorm.DB, cfg *config.JobsConfig) *Queue {
+	return &Queue{
+		db:        db,
+		cfg:       cfg,
+		worker:    workerID(),
+		defs:      make(map[string]Definition),
+		schedules: make(map[string]*scheduled),
+		wake:      make(chan struct{}, 1),
+		running:   make(map[uint]context.CancelFunc),
+	}
+}
+
+// workerID 实例标识，记录在执行中的任务上
+func workerID() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	rand.Read(b)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(b))
+}
+
+// Register 注册任务类型，只有注册了该类型的实例会领取此类任务；类型重复时 panic
+func (q *Queue) Register(def Definition) {
+	if _, ok := q.defs[def.Kind]; ok {
+		panic("jobs: 任务类型重复注册: " + def.Kind)
+	}
+	q.defs[def.Kind] = def
+}
+
+// Schedule 声明定时计划，按 spec（见 ParseCron）定期入队 kind 类型的任务；规则无效或名称重复时 panic
+// 计划在调度器启动时写入数据库，管理员停用的计划保持停用
+func (q *Queue) Schedule(name, spec, kind string, payload interface{}) {
+	cron, err := ParseCron(spec)
+	if err != nil {
+		panic(fmt.Sprintf("jobs: 定时计划 %s 的执行规则无效: %v", name, err))
+	}
+	if cron.Next(time.Now()).IsZero() {
+		panic(fmt.Sprintf("jobs: 定时计划 %s 的执行规则 %q 没有可执行的时间", name, spec))
+	}
+	if _, ok := q.schedules[name]; ok {
+		panic("jobs: 定时计划重复声明: " + name)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic(fmt.Sprintf("jobs: 定时计划 %s 的任务参数无法序列化: %v", name, err))
+	}
+	q.schedules[name] = &scheduled{
+		Schedule: Schedule{Name: name, Cron: spec, Kind: kind, Payload: data, IsEnabled: true},
+		cron:     cron,
+	}
+}
+
+// purgeBatchSize 清理回收站时每批读取的记录数
+const purgeBatchSize = 500
+
+// PurgeTrash 注册 <resource>.purge_trash 任务并每天执行，永久删除回收站中超过 JOBS_TRASH_RETENTION 的数据
+// model 需包含 gorm.DeletedAt 字段；逐条删除，仍被其他数据引用（外键约束）的记录跳过并保留在回收站中，不影响其他记录
+func (q *Queue) PurgeTrash(resource string, model interface{}) {
+	kind := resource + ".purge_trash"
+	q.Register(Definition{
+		Kind: kind,
+		Handler: func(ctx context.Context, job *Job) error {
+			before := time.Now().Add(-q.cfg.TrashRetention)
+			deleted, skipped, err := q.purge(ctx, model, before)
+			if deleted > 0 || skipped > 0 {
+				slog.InfoContext(ctx, "已永久删除回收站中过期的数据", slog.String("resource", resource), slog.Int("count", deleted), slog.Int("skipped", skipped))
+			}
+			return err
+		},
+	})
+	q.Schedule(kind, "0 3 * * *", kind, nil)
+}
+
+// purge 按主键顺序逐条永久删除 before 之前进入回收站的记录，返回删除与因仍被引用而跳过的记录数
+func (q *Queue) purge(ctx context.Context, model interface{}, before time.Time) (deleted, skipped int, err error) {
+	var lastID uint
+	for {
+		var ids []uint
+		err := q.db.WithContext(ctx).Unscoped().Model(model).
+			Where("deleted_at < ? AND id > ?", before, lastID).
+			Order("id").Limit(purgeBatchSize).Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return deleted, skipped, err
+		}
+
+		for _, id := range ids {
+			result := q.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at < ?", id, before).Delete(model)
+			switch {
+			case isForeignKeyViolation(result.Error):
+				skipped++
+			case result.Error != nil:
+				return deleted, skipped, result.Error
+			default:
+				deleted += int(result.RowsAffected)
+			}
+		}
+		lastID = ids[len(ids)-1]
+	}
+}
+
+// isForeignKeyViolation 判断是否为外键约束错误
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+// Kinds 返回本实例注册的任务类型
+func (q *Queue) Kinds() []string {
+	kinds := make([]string, 0, len(q.defs))
+	for kind := range q.defs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// maxAttempts 任务类型的最大执行次数
+func (q *Queue) maxAttempts(kind string) int {
+	if def, ok := q.defs[kind]; ok && def.MaxAttempts > 0 {
+		return def.MaxAttempts
+	}
+	return q.cfg.MaxAttempts
+}
+
+// timeout 任务类型的超时时间
+func (q *Queue) timeout(kind string) time.Duration {
+	if def, ok := q.defs[kind]; ok && def.Timeout > 0 {
+		return def.Timeout
+	}
+	return q.cfg.Timeout
+}
+
+// lease 执行租约，为最长超时时间的两倍
+func (q *Queue) lease() time.Duration {
+	longest := q.cfg.Timeout
+	for _, def := range q.defs {
+		longest = max(longest, def.Timeout)
+	}
+	return 2 * longest
+}
+
+// Notify 唤醒工作池，有新任务时无需等到下一次轮询
+func (q *Queue) Notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 工作池：在空闲名额内领取到期任务并发执行，直到 ctx 取消；关闭时中断的任务放回队列
+func (q *Queue) Run(ctx context.Context) {
+	if len(q.defs) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	sem := make(chan struct{}, max(q.cfg.Workers, 1))
+	for {
+		if free := cap(sem) - len(sem); free > 0 {
+			jobs, err := q.claim(ctx, free)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "领取后台任务失败", slog.Any("error", err))
+			}
+			for _, job := range jobs {
+				sem <- struct{}{}
+				wg.Add(1)
+				go func(job Job) {
+					defer func() { <-sem; wg.Done(); q.Notify() }()
+					q.execute(ctx, &job)
+				}(job)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-time.After(q.cfg.PollInterval):
+		}
+	}
+}
+
+// claimSQL 领取到期的等待任务及租约已过期的执行中任务（执行实例已退出），并设置新的租约
+const claimSQL = `
+UPDATE jobs SET status = @running, attempts = attempts + 1, locked_by = @worker,
+	locked_until = now() + make_interval(secs => @lease), started_at = now(), updated_at = now()
+WHERE id IN (
+	SELECT id FROM jobs
+	WHERE kind IN @kinds AND (
+		(status = @pending AND run_at <= now()) OR
+		(status = @running AND locked_until < now())
+	)
+	ORDER BY run_at, id
+	LIMIT @limit
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// claim 领取最多 limit 个任务
+func (q *Queue) claim(ctx context.Context, limit int) ([]Job, error) {
+	var jobs []Job
+	err := q.db.WithContext(ctx).Raw(claimSQL, map[string]interface{}{
+		"running": StatusRunning,
+		"pending": StatusPending,
+		"worker":  q.worker,
+		"lease":   q.lease().Seconds(),
+		"kinds":   q.Kinds(),
+		"limit":   limit,
+	}).Scan(&jobs).Error
+	return jobs, err
+}
+
+// execute 执行任务并记录结果
+func (q *Queue) execute(ctx context.Context, job *Job) {
+	if job.Attempts > job.MaxAttempts {
+		// 租约过期后被重新领取，说明此前的执行实例在执行中退出
+		q.finish(ctx, job, fmt.Errorf("执行中断次数超过最大执行次数 %d", job.MaxAttempts))
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, q.timeout(job.Kind))
+	defer cancel()
+	q.mu.Lock()
+	q.running[job.ID] = cancel
+	q.mu.Unlock()
+	defer func() {
+		q.mu.Lock()
+		delete(q.running, job.ID)
+		q.mu.Unlock()
+	}()
+
+	err := q.call(jobCtx, job)
+	if ctx.Err() != nil {
+		q.release(ctx, job)
+		return
+	}
+	q.finish(ctx, job, err)
+}
+
+// call 调用处理函数，panic 视为执行失败
+func (q *Queue) call(ctx context.Context, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "后台任务 panic", slog.String("kind", job.Kind), slog.Uint64("job_id", uint64(job.ID)),
+				slog.Any("panic", r), slog.String("stack", string(debug.Stack())))
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return q.defs[job.Kind].Handler(ctx, job)
+}
+
+// abort 中止本实例执行中的任务
+func (q *Queue) abort(id uint) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if cancel, ok := q.running[id]; ok {
+		cancel()
+	}
+}
+
+// finish 保存执行结果；失败且未超过最大次数时按指数退避安排重试
+// 只更新仍由本实例执行的任务，已被取消的任务保持取消状态
+func (q *Queue) finish(ctx context.Context, job *Job, err error) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":       StatusSucceeded,
+		"last_error":   "",
+		"locked_until": nil,
+		"finished_at":  now,
+	}
+	result := StatusSucceeded
+	switch {
+	case err == nil:
+	case job.Attempts < job.MaxAttempts:
+		result = "retry"
+		updates["status"] = StatusPending
+		updates["run_at"] = now.Add(retry.Backoff(job.Attempts, q.cfg.BackoffBase, q.cfg.BackoffMax))
+		updates["finished_at"] = nil
+		updates["last_error"] = err.Error()
+	default:
+		result = StatusFailed
+		updates["status"] = StatusFailed
+		updates["last_error"] = err.Error()
+	}
+
+	res := q.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, StatusRunning, q.worker).
+		Updates(updates)
+	if res.Error != nil {
+		slog.ErrorContext(ctx, "保存后台任务结果失败", slog.Uint64("job_id", uint64(job.ID)), slog.Any("error", res.Error))
+		return
+	}
+	if res.RowsAffected == 0 {
+		return
+	}
+
+	jobsTotal.WithLabelValues(job.Kind, result).Inc()
+	switch result {
+	case StatusFailed:
+		slog.ErrorContext(ctx, "后台任务执行失败",
+			slog.Uint64("job_id", uint64(job.ID)),
+			slog.String("kind", job.Kind),
+			slog.Int("attempts", job.Attempts),
+			slog.String("error", err.Error()),
+		)
+	case "retry":
+		slog.WarnContext(ctx, "后台任务执行失败，稍后重试",
+			slog.Uint64("job_id", uint64(job.ID)),
+			slog.String("kind", job.Kind),
+			slog.Int("attempts", job.Attempts),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// release 服务关闭中断的任务放回队列，不计执行次数
+func (q *Queue) release(ctx context.Context, job *Job) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	err := q.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, StatusRunning, q.worker).
+		Updates(map[string]interface{}{
+			"status":       StatusPending,
+			"attempts":     gorm.Expr("attempts - 1"),
+			"run_at":       time.Now(),
+			"locked_until": nil,
+		}).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "放回后台任务失败", slog.Uint64("job_id", uint64(job.ID)), slog.Any("error", err))
+	}
+}
+
+// RunScheduler 同步定时计划后按计划入队，直到 ctx 取消；多实例同时运行时每次只有一个实例入队
+func (q *Queue) RunScheduler(ctx context.Context) {
+	if len(q.schedules) == 0 {
+		return
+	}
+	if err := q.syncSchedules(ctx); err != nil {
+		slog.ErrorContext(ctx, "同步定时计划失败", slog.Any("error", err))
+	}
+
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := q.enqueueDue(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "定时计划入队失败", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncScheduleSQL 写入或更新定时计划，执行规则变化时重新计算下次执行时间，保留管理员设置的启用状态
+const syncScheduleSQL = `
+INSERT INTO job_schedules (name, created_at, updated_at, cron, kind, payload, is_enabled, next_run_at)
+VALUES (@name, now(), now(), @cron, @kind, @payload, true, @next_run_at)
+ON CONFLICT (name) DO UPDATE SET
+	updated_at = now(),
+	kind = excluded.kind,
+	payload = excluded.payload,
+	next_run_at = CASE WHEN job_schedules.cron = excluded.cron THEN job_schedules.next_run_at ELSE excluded.next_run_at END,
+	cron = excluded.cron`
+
+// syncSchedules 将代码中声明的定时计划写入数据库
+func (q *Queue) syncSchedules(ctx context.Context) error {
+	now := time.Now()
+	for _, s := range q.schedules {
+		err := q.db.WithContext(ctx).Exec(syncScheduleSQL, map[string]interface{}{
+			"name":        s.Name,
+			"cron":        s.Cron,
+			"kind":        s.Kind,
+			"payload":     string(s.Payload),
+			"next_run_at": s.cron.Next(now),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueDue 在事务中锁定到期的定时计划，入队并推进下次执行时间
+func (q *Queue) enqueueDue(ctx context.Context) error {
+	names := make([]string, 0, len(q.schedules))
+	for name := range q.schedules {
+		names = append(names, name)
+	}
+
+	return database.Transaction(ctx, q.db, func(tx *gorm.DB) error {
+		var due []Schedule
+		err := tx.Raw(`SELECT * FROM job_schedules WHERE name IN ? AND is_enabled AND next_run_at <= now() FOR UPDATE SKIP LOCKED`, names).
+			Scan(&due).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range due {
+			schedule := &due[i]
+			if _, err := enqueueSchedule(tx, schedule, now); err != nil {
+				return err
+			}
+			cron, err := ParseCron(schedule.Cron)
+			if err != nil {
+				return fmt.Errorf("定时计划 %s 的执行规则无效: %w", schedule.Name, err)
+			}
+			if err := tx.Model(&Schedule{}).Where("name = ?", schedule.Name).Update("next_run_at", cron.Next(now)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Cleanup 定期删除超过保留时间的已结束任务，直到 ctx 取消
+func (q *Queue) Cleanup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.db.WithContext(ctx).
+				Where("status IN ? AND finished_at < ?", []string{StatusSucceeded, StatusFailed, StatusCancelled}, time.Now().Add(-q.cfg.Retention)).
+				Delete(&Job{})
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger 基于 slog 的 GORM 日志，SQL 日志带有请求ID
+// 查询需通过 db.WithContext(c) 传入请求上下文才能关联请求ID
+type GormLogger struct {
+	Level         gormlogger.LogLevel
+	SlowThreshold time.Duration
+}
+
+// NewGormLogger 创建 GORM 日志，level 取值 silent、error、warn、info
+func NewGormLogger(level string, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{Level: ParseGormLevel(level), SlowThreshold: slowThreshold}
+}
+
+// ParseGormLevel 解析 SQL 日志级别，无法识别时为 warn
+func ParseGormLevel(level string) gormlogger.LogLevel {
+	switch strings.ToLower(level) {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
+
+// LogMode 实现 gormlogger.Interface
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.Level = level
+	return &clone
+}
+
+// Info 实现 gormlogger.Interface
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.Level >= gormlogger.Info {
+		FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn 实现 gormlogger.Interface
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.Level >= gormlogger.Warn {
+		FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error 实现 gormlogger.Interface
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.Level >= gormlogger.Error {
+		FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace 实现 gormlogger.Interface，记录 SQL、耗时与影响行数
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.Level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	attrs := func() []any {
+		sql, rows := fc()
+		return []any{
+			slog.String("sql", sql),
+			slog.Int64("rows", rows),
+			slog.Float64("latency_ms", float64(elapsed.Microseconds())/1000),
+		}
+	}
+	log := FromContext(ctx)
+
+	switch {
+	case err != nil && l.Level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		log.ErrorContext(ctx, "SQL 执行失败", append(attrs(), slog.Any("error", err))...)
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.Level >= gormlogger.Warn:
+		log.WarnContext(ctx, "慢查询", append(attrs(), slog.Duration("threshold", l.SlowThreshold))...)
+	case l.Level >= gormlogger.Info:
+		log.InfoContext(ctx, "SQL", attrs()...)
+	}
+}
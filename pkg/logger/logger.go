@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"erp_backend/pkg/config"
+
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RequestIDKey 请求ID在 gin 上下文中的键
+const RequestIDKey = "request_id"
+
+type requestIDKey struct{}
+
+// WithRequestID 将请求ID写入 context
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID 从 context 中读取请求ID，兼容 gin.Context
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	if id, ok := ctx.Value(RequestIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// FromContext 返回带请求ID字段的 logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With(slog.String(RequestIDKey, id))
+	}
+	return slog.Default()
+}
+
+// Init 初始化全局日志，返回的 closer 用于关闭日志文件
+// 设置后标准库 log 包的输出也会经由 slog 处理
+func Init(cfg *config.LogConfig) (io.Closer, error) {
+	var console io.Writer = os.Stdout
+	if cfg.Stderr {
+		console = os.Stderr
+	}
+	writer := console
+	var closer io.Closer = nopCloser{}
+
+	if cfg.File != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.File), 0o755); err != nil {
+			return nil, err
+		}
+		rotator := &lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSizeMB,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAgeDays,
+			Compress:   cfg.Compress,
+			LocalTime:  true,
+		}
+		writer = io.MultiWriter(console, rotator)
+		closer = startRotation(rotator, cfg.RotateInterval)
+	}
+
+	options := &slog.HandlerOptions{Level: ParseLevel(cfg.Level)}
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(writer, options)
+	} else {
+		handler = slog.NewJSONHandler(writer, options)
+	}
+	slog.SetDefault(slog.New(traceHandler{handler}))
+
+	return closer, nil
+}
+
+// traceHandler 为带有追踪上下文的日志附加 trace_id、span_id 字段
+type traceHandler struct {
+	slog.Handler
+}
+
+// Handle 实现 slog.Handler
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs 实现 slog.Handler
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup 实现 slog.Handler
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
+
+// ParseLevel 解析日志级别，无法识别时为 info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// rotation 按时间间隔切割日志文件
+type rotation struct {
+	rotator *lumberjack.Logger
+	stop    chan struct{}
+}
+
+// startRotation 启动按时间切割，interval 为 0 时仅按大小切割
+func startRotation(rotator *lumberjack.Logger, interval time.Duration) io.Closer {
+	r := &rotation{rotator: rotator, stop: make(chan struct{})}
+	if interval <= 0 {
+		return r
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := rotator.Rotate(); err != nil {
+					slog.Error("日志切割失败", slog.Any("error", err))
+				}
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// Close 停止定时切割并关闭日志文件
+func (r *rotation) Close() error {
+	close(r.stop)
+	return r.rotator.Close()
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// startTimeKey 查询开始时间在 gorm 实例中的键
+const startTimeKey = "metrics:start_time"
+
+var (
+	// DBQueryDuration SQL 执行耗时，按操作类型与表名区分
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "SQL 执行耗时（秒）",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	// DBQueryErrors SQL 执行失败次数，不含记录不存在
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "SQL 执行失败次数",
+	}, []string{"operation", "table"})
+)
+
+func init() {
+	Registry.MustRegister(DBQueryDuration, DBQueryErrors)
+}
+
+// RegisterDB 为数据库注册查询耗时插件与连接池指标
+func RegisterDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := db.Use(&gormPlugin{}); err != nil {
+		return err
+	}
+	return Registry.Register(collectors.NewDBStatsCollector(sqlDB, "erp"))
+}
+
+// gormPlugin 记录 SQL 执行耗时的 GORM 插件
+type gormPlugin struct{}
+
+// Name 实现 gorm.Plugin
+func (p *gormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize 实现 gorm.Plugin，在各类操作的首尾注册回调
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("metrics:before_create", start),
+		cb.Create().After("*").Register("metrics:after_create", observe("create")),
+		cb.Query().Before("*").Register("metrics:before_query", start),
+		cb.Query().After("*").Register("metrics:after_query", observe("query")),
+		cb.Update().Before("*").Register("metrics:before_update", start),
+		cb.Update().After("*").Register("metrics:after_update", observe("update")),
+		cb.Delete().Before("*").Register("metrics:before_delete", start),
+		cb.Delete().After("*").Register("metrics:after_delete", observe("delete")),
+		cb.Row().Before("*").Register("metrics:before_row", start),
+		cb.Row().After("*").Register("metrics:after_row", observe("row")),
+		cb.Raw().Before("*").Register("metrics:before_raw", start),
+		cb.Raw().After("*").Register("metrics:after_raw", observe("raw")),
+	)
+}
+
+// start 记录开始时间
+func start(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+// observe 返回记录耗时与错误的回调
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		begin, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(begin).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			DBQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace 指标名前缀
+const Namespace = "erp"
+
+// Registry 指标注册表，包含 Go 运行时与进程指标
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestDuration HTTP 请求耗时，按路由模板、方法与状态码区分
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP 请求耗时（秒）",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestsInFlight 正在处理的 HTTP 请求数
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "正在处理的 HTTP 请求数",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+	)
+}
+
+// MustRegister 注册自定义指标，重复注册时 panic
+func MustRegister(cs ...prometheus.Collector) {
+	Registry.MustRegister(cs...)
+}
+
+// Handler 返回 Prometheus 文本格式的指标处理器
+// 个别采集器失败时仍输出其余指标；token 非空时要求请求携带 Authorization: Bearer {token}
+func Handler(token string) http.Handler {
+	handler := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		Registry:      Registry,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+	if token == "" {
+		return handler
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
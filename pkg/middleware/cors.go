@@ -1,23 +1,161 @@
 package middleware
 
 import (
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"erp_backend/pkg/config"
 
 	"github.com/gin-gonic/gin"
 )
 
-// CORSMiddleware 处理跨域请求
-func CORSMiddleware() gin.HandlerFunc {
+// corsPolicy 预处理后的跨域配置
+type corsPolicy struct {
+	allowAll         bool
+	origins          map[string]bool
+	wildcards        [][2]string // 子域名通配的前缀（协议）与后缀（父域名）
+	methods          map[string]bool
+	allowedMethods   string
+	allowAllHeaders  bool
+	allowedHeaders   string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           string
+}
+
+// newCORSPolicy 解析跨域配置
+func newCORSPolicy(cfg *config.CORSConfig) *corsPolicy {
+	p := &corsPolicy{
+		origins:          map[string]bool{},
+		methods:          map[string]bool{},
+		allowedMethods:   strings.ToUpper(strings.Join(cfg.AllowedMethods, ", ")),
+		allowedHeaders:   strings.Join(cfg.AllowedHeaders, ", "),
+		exposedHeaders:   strings.Join(cfg.ExposedHeaders, ", "),
+		allowCredentials: cfg.AllowCredentials,
+		maxAge:           strconv.Itoa(int(cfg.MaxAge.Seconds())),
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		switch {
+		case origin == "*":
+			p.allowAll = true
+		case strings.Contains(origin, "://*."):
+			scheme, domain, _ := strings.Cut(origin, "*.")
+			p.wildcards = append(p.wildcards, [2]string{scheme, "." + domain})
+		default:
+			p.origins[origin] = true
+		}
+	}
+	for _, method := range cfg.AllowedMethods {
+		p.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range cfg.AllowedHeaders {
+		if header == "*" {
+			p.allowAllHeaders = true
+		}
+	}
+
+	if p.allowAll && p.allowCredentials {
+		log.Println("警告: CORS 允许全部来源且允许携带凭据，任意网站都可以用户身份调用接口，请配置来源白名单")
+	}
+	return p
+}
+
+// allowOrigin 来源是否在白名单中
+func (p *corsPolicy) allowOrigin(origin string) bool {
+	if p.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if p.origins[origin] {
+		return true
+	}
+	for _, w := range p.wildcards {
+		if strings.HasPrefix(origin, w[0]) && strings.HasSuffix(origin, w[1]) && len(origin) > len(w[0])+len(w[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS 按配置处理跨域请求，groups 中的路由组按路径前缀使用各自的配置
+// 预检请求在此直接响应，不进入后续中间件
+func CORS(cfg *config.CORSConfig, groups ...config.CORSGroup) gin.HandlerFunc {
+	type groupPolicy struct {
+		prefix string
+		policy *corsPolicy
+	}
+
+	defaultPolicy := newCORSPolicy(cfg)
+	var groupPolicies []groupPolicy
+	for _, group := range groups {
+		policy := newCORSPolicy(group.Config)
+		for _, prefix := range group.Prefixes {
+			groupPolicies = append(groupPolicies, groupPolicy{prefix: prefix, policy: policy})
+		}
+	}
+
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		policy := defaultPolicy
+		longest := 0
+		for _, g := range groupPolicies {
+			if len(g.prefix) > longest && strings.HasPrefix(c.Request.URL.Path, g.prefix) {
+				policy, longest = g.policy, len(g.prefix)
+			}
+		}
+
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !policy.allowAll || policy.allowCredentials {
+			c.Writer.Header().Add("Vary", "Origin")
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
+		if origin == "" {
+			c.Next()
+			return
+		}
+		if !policy.allowOrigin(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
 			return
 		}
 
-		c.Next()
+		if policy.allowAll && !policy.allowCredentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if policy.allowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if policy.exposedHeaders != "" {
+				c.Header("Access-Control-Expose-Headers", policy.exposedHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		if !policy.methods[strings.ToUpper(c.GetHeader("Access-Control-Request-Method"))] {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		c.Header("Access-Control-Allow-Methods", policy.allowedMethods)
+		if policy.allowAllHeaders {
+			c.Header("Access-Control-Allow-Headers", c.GetHeader("Access-Control-Request-Headers"))
+		} else {
+			c.Header("Access-Control-Allow-Headers", policy.allowedHeaders)
+		}
+		c.Header("Access-Control-Max-Age", policy.maxAge)
+		c.AbortWithStatus(http.StatusNoContent)
 	}
 }
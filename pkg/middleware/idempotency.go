@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/idempotency"
+	"erp_backend/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKeyHeader 幂等键请求头
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyBackend 幂等键中间件依赖的存储操作，由 *idempotency.Store 实现
+type idempotencyBackend interface {
+	Acquire(ctx context.Context, key, hash string) (owner string, acquired bool, err error)
+	Get(ctx context.Context, key string) (*idempotency.Record, error)
+	Complete(ctx context.Context, key, owner string, status int, contentType string, body []byte) error
+	Release(ctx context.Context, key, owner string) error
+	WaitTimeout() time.Duration
+	MaxBodySize() int64
+}
+
+// idempotencyStore 全局幂等键存储，未设置时幂等键中间件直接放行
+var idempotencyStore idempotencyBackend
+
+// SetIdempotencyStore 设置全局幂等键存储
+func SetIdempotencyStore(s *idempotency.Store) {
+	if s == nil {
+		idempotencyStore = nil
+		return
+	}
+	idempotencyStore = s
+}
+
+// Idempotency 处理 POST、PATCH 请求的 Idempotency-Key 请求头
+// 首个请求正常处理并保存响应，相同调用方携带相同幂等键的重试直接返回保存的响应；
+// 请求内容不同时返回 409，并发的重复请求等待首个请求完成后返回其响应
+func Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyStore == nil || key == "" || IsSubRequest(c.Request.Context()) ||
+			(c.Request.Method != http.MethodPost && c.Request.Method != http.MethodPatch) {
+			c.Next()
+			return
+		}
+		if len(key) > 255 {
+			response.Fail(c, errcode.IdempotencyKeyInvalid)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, idempotencyStore.MaxBodySize()))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				response.Fail(c, errcode.RequestTooLarge)
+			} else {
+				response.Fail(c, errcode.InvalidParams)
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scopedKey := callerKey(c) + ":" + key
+		hash := idempotency.Hash(c.Request.Method, c.Request.URL.RequestURI(), body)
+		owner, ok := acquireIdempotencyKey(c, scopedKey, hash)
+		if !ok {
+			c.Abort()
+			return
+		}
+
+		// 请求处理完后即使客户端已断开也要保存结果
+		ctx := context.WithoutCancel(c.Request.Context())
+		defer func() {
+			if r := recover(); r != nil {
+				idempotencyStore.Release(ctx, scopedKey, owner)
+				panic(r)
+			}
+		}()
+
+		writer := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		status := writer.Status()
+		if !replayable(status) {
+			err = idempotencyStore.Release(ctx, scopedKey, owner)
+		} else {
+			err = idempotencyStore.Complete(ctx, scopedKey, owner, status, writer.Header().Get("Content-Type"), writer.body.Bytes())
+		}
+		if err != nil {
+			slog.ErrorContext(c, "保存幂等键结果失败", slog.Any("error", err))
+		}
+	}
+}
+
+// replayable 判断响应能否保存供重试重放；
+// 服务端错误与认证、限流拒绝都是暂时性的，不应让后续重试拿到同样的结果
+func replayable(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	}
+	return status < http.StatusInternalServerError
+}
+
+// acquireIdempotencyKey 占用幂等键，返回 true 表示由当前请求处理，owner 为本次占用的标识；
+// 返回 false 时已写出响应（重放的响应或错误）
+func acquireIdempotencyKey(c *gin.Context, key, hash string) (string, bool) {
+	deadline := time.Now().Add(idempotencyStore.WaitTimeout())
+	for {
+		owner, acquired, err := idempotencyStore.Acquire(c, key, hash)
+		if err != nil {
+			response.DBError(c, err, errcode.DatabaseError)
+			return "", false
+		}
+		if acquired {
+			return owner, true
+		}
+
+		record, err := idempotencyStore.Get(c, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 首个请求失败后已释放，重新尝试占用
+			continue
+		}
+		if err != nil {
+			response.DBError(c, err, errcode.DatabaseError)
+			return "", false
+		}
+
+		if record.RequestHash != hash {
+			response.Fail(c, errcode.IdempotencyKeyReused)
+			return "", false
+		}
+		if record.Status == idempotency.StatusCompleted {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.ResponseStatus, record.ContentType, record.ResponseBody)
+			return "", false
+		}
+
+		if time.Now().After(deadline) {
+			c.Header("Retry-After", "1")
+			response.Fail(c, errcode.IdempotencyInProgress)
+			return "", false
+		}
+		select {
+		case <-c.Request.Context().Done():
+			return "", false
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// bodyRecorder 在写出响应的同时记录响应体
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 实现 io.Writer
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString 实现 io.StringWriter
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
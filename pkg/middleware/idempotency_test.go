@@ -0,0 +1,327 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/idempotency"
+)
+
+// memoryIdempotencyStore 内存中的幂等键存储，行为与 *idempotency.Store 一致（不含过期与超时接管）
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+	wait    time.Duration
+}
+
+func (s *memoryIdempotencyStore) Acquire(_ context.Context, key, hash string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.records[key]; exists {
+		return "", false, nil
+	}
+	owner := key + ":owner"
+	s.records[key] = idempotency.Record{Key: key, RequestHash: hash, Status: idempotency.StatusProcessing, Owner: owner}
+	return owner, true, nil
+}
+
+func (s *memoryIdempotencyStore) Get(_ context.Context, key string) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, exists := s.records[key]
+	if !exists {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &record, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key, owner string, status int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, exists := s.records[key]
+	if !exists || record.Owner != owner || record.Status != idempotency.StatusProcessing {
+		return nil
+	}
+	record.Status = idempotency.StatusCompleted
+	record.ResponseStatus = status
+	record.ContentType = contentType
+	record.ResponseBody = body
+	s.records[key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, exists := s.records[key]; exists && record.Owner == owner && record.Status == idempotency.StatusProcessing {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) WaitTimeout() time.Duration { return s.wait }
+
+func (s *memoryIdempotencyStore) MaxBodySize() int64 { return 64 }
+
+// useIdempotencyStore 设置内存幂等键存储，测试结束后恢复为未设置
+func useIdempotencyStore(t *testing.T, wait time.Duration) *memoryIdempotencyStore {
+	t.Helper()
+	store := &memoryIdempotencyStore{records: map[string]idempotency.Record{}, wait: wait}
+	idempotencyStore = store
+	t.Cleanup(func() { SetIdempotencyStore(nil) })
+	return store
+}
+
+// newIdempotencyEngine 返回挂载幂等键中间件的路由，handler 处理 POST /orders
+func newIdempotencyEngine(handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.Recovery(), Idempotency())
+	r.POST("/orders", handler)
+	r.POST("/refunds", handler)
+	r.GET("/orders", handler)
+	return r
+}
+
+// send 以指定的幂等键发送请求
+func send(r http.Handler, method, target, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// errorCodeOf 返回错误响应中的业务错误码
+func errorCodeOf(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp struct {
+		ErrorCode string `json:"error_code"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("json.Unmarshal(%s) 错误 = %v", w.Body.String(), err)
+	}
+	return resp.ErrorCode
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	useIdempotencyStore(t, time.Second)
+	var calls atomic.Int32
+	r := newIdempotencyEngine(func(c *gin.Context) {
+		n := calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"id": n})
+	})
+
+	first := send(r, http.MethodPost, "/orders", "k1", `{"qty":1}`)
+	if first.Code != http.StatusCreated || first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("首个请求 状态码 = %d, 头 = %v", first.Code, first.Header())
+	}
+	replay := send(r, http.MethodPost, "/orders", "k1", `{"qty":1}`)
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() || replay.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("重试 = (%d, %s, %v), 期望重放首个响应 %s", replay.Code, replay.Body.String(), replay.Header(), first.Body.String())
+	}
+	if got := replay.Header().Get("Content-Type"); got != first.Header().Get("Content-Type") {
+		t.Errorf("重放的 Content-Type = %q, 期望 %q", got, first.Header().Get("Content-Type"))
+	}
+	if calls.Load() != 1 {
+		t.Errorf("处理次数 = %d, 期望 1", calls.Load())
+	}
+
+	// 未携带幂等键、GET 请求不经过幂等处理
+	send(r, http.MethodPost, "/orders", "", `{"qty":1}`)
+	send(r, http.MethodGet, "/orders", "k1", "")
+	if calls.Load() != 3 {
+		t.Errorf("处理次数 = %d, 期望 3", calls.Load())
+	}
+}
+
+func TestIdempotencyKeyReused(t *testing.T) {
+	useIdempotencyStore(t, time.Second)
+	var calls atomic.Int32
+	r := newIdempotencyEngine(func(c *gin.Context) {
+		calls.Add(1)
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	send(r, http.MethodPost, "/orders", "k1", `{"qty":1}`)
+	for _, tt := range []struct{ name, target, body string }{
+		{"请求体不同", "/orders", `{"qty":2}`},
+		{"路径不同", "/refunds", `{"qty":1}`},
+		{"查询参数不同", "/orders?dry_run=true", `{"qty":1}`},
+	} {
+		w := send(r, http.MethodPost, tt.target, "k1", tt.body)
+		if w.Code != http.StatusConflict || errorCodeOf(t, w) != errcode.IdempotencyKeyReused.Key {
+			t.Errorf("%s: 响应 = (%d, %s), 期望 %s", tt.name, w.Code, w.Body.String(), errcode.IdempotencyKeyReused.Key)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("处理次数 = %d, 期望 1", calls.Load())
+	}
+}
+
+func TestIdempotencyRejects(t *testing.T) {
+	useIdempotencyStore(t, time.Second)
+	r := newIdempotencyEngine(func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	if w := send(r, http.MethodPost, "/orders", strings.Repeat("k", 256), `{}`); w.Code != http.StatusBadRequest || errorCodeOf(t, w) != errcode.IdempotencyKeyInvalid.Key {
+		t.Errorf("幂等键过长 响应 = (%d, %s)", w.Code, w.Body.String())
+	}
+	if w := send(r, http.MethodPost, "/orders", "k1", `{"note":"`+strings.Repeat("a", 64)+`"}`); w.Code != http.StatusRequestEntityTooLarge || errorCodeOf(t, w) != errcode.RequestTooLarge.Key {
+		t.Errorf("请求体过大 响应 = (%d, %s)", w.Code, w.Body.String())
+	}
+}
+
+func TestIdempotencyNotStored(t *testing.T) {
+	for _, status := range []int{
+		http.StatusUnauthorized,
+		http.StatusForbidden,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusServiceUnavailable,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			store := useIdempotencyStore(t, time.Second)
+			var calls atomic.Int32
+			r := newIdempotencyEngine(func(c *gin.Context) {
+				calls.Add(1)
+				c.JSON(status, gin.H{})
+			})
+
+			for i := 0; i < 2; i++ {
+				if w := send(r, http.MethodPost, "/orders", "k1", `{}`); w.Code != status || w.Header().Get("Idempotent-Replayed") != "" {
+					t.Fatalf("第 %d 次 响应 = (%d, %v), 期望重新处理", i+1, w.Code, w.Header())
+				}
+			}
+			if calls.Load() != 2 || len(store.records) != 0 {
+				t.Errorf("处理次数 = %d, 保存的记录 = %v, 期望处理 2 次且不保存", calls.Load(), store.records)
+			}
+		})
+	}
+
+	// 客户端错误是确定的结果，照常保存
+	t.Run("客户端错误照常保存", func(t *testing.T) {
+		useIdempotencyStore(t, time.Second)
+		var calls atomic.Int32
+		r := newIdempotencyEngine(func(c *gin.Context) {
+			calls.Add(1)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{})
+		})
+		send(r, http.MethodPost, "/orders", "k1", `{}`)
+		if w := send(r, http.MethodPost, "/orders", "k1", `{}`); w.Code != http.StatusUnprocessableEntity || w.Header().Get("Idempotent-Replayed") != "true" || calls.Load() != 1 {
+			t.Errorf("重试 = (%d, %v), 处理次数 = %d, 期望重放", w.Code, w.Header(), calls.Load())
+		}
+	})
+
+	// 处理时 panic 释放幂等键，重试重新处理
+	t.Run("panic 释放幂等键", func(t *testing.T) {
+		store := useIdempotencyStore(t, time.Second)
+		var calls atomic.Int32
+		r := newIdempotencyEngine(func(c *gin.Context) {
+			if calls.Add(1) == 1 {
+				panic("boom")
+			}
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+		if w := send(r, http.MethodPost, "/orders", "k1", `{}`); w.Code != http.StatusInternalServerError || len(store.records) != 0 {
+			t.Fatalf("panic 后 状态码 = %d, 保存的记录 = %v", w.Code, store.records)
+		}
+		if w := send(r, http.MethodPost, "/orders", "k1", `{}`); w.Code != http.StatusCreated || calls.Load() != 2 {
+			t.Errorf("重试 状态码 = %d, 处理次数 = %d, 期望重新处理", w.Code, calls.Load())
+		}
+	})
+}
+
+func TestIdempotencyConcurrent(t *testing.T) {
+	// startBlocked 发送首个请求并等待其进入处理函数，关闭返回的 release 后完成处理
+	startBlocked := func(t *testing.T, r http.Handler, started <-chan struct{}) <-chan *httptest.ResponseRecorder {
+		t.Helper()
+		done := make(chan *httptest.ResponseRecorder, 1)
+		go func() { done <- send(r, http.MethodPost, "/orders", "k1", `{}`) }()
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("首个请求未进入处理函数")
+		}
+		return done
+	}
+
+	t.Run("等待首个请求完成后重放", func(t *testing.T) {
+		useIdempotencyStore(t, 5*time.Second)
+		started, release := make(chan struct{}), make(chan struct{})
+		var calls atomic.Int32
+		r := newIdempotencyEngine(func(c *gin.Context) {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-release
+			}
+			c.JSON(http.StatusCreated, gin.H{"id": 1})
+		})
+
+		first := startBlocked(t, r, started)
+		second := make(chan *httptest.ResponseRecorder, 1)
+		go func() { second <- send(r, http.MethodPost, "/orders", "k1", `{}`) }()
+		time.Sleep(150 * time.Millisecond)
+		close(release)
+
+		if w := <-first; w.Code != http.StatusCreated {
+			t.Fatalf("首个请求 状态码 = %d", w.Code)
+		}
+		if w := <-second; w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+			t.Errorf("并发请求 = (%d, %v), 期望重放首个响应", w.Code, w.Header())
+		}
+		if calls.Load() != 1 {
+			t.Errorf("处理次数 = %d, 期望 1", calls.Load())
+		}
+	})
+
+	t.Run("等待超时", func(t *testing.T) {
+		useIdempotencyStore(t, 150*time.Millisecond)
+		started, release := make(chan struct{}), make(chan struct{})
+		r := newIdempotencyEngine(func(c *gin.Context) {
+			close(started)
+			<-release
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+
+		first := startBlocked(t, r, started)
+		w := send(r, http.MethodPost, "/orders", "k1", `{}`)
+		close(release)
+		<-first
+		if w.Code != http.StatusConflict || errorCodeOf(t, w) != errcode.IdempotencyInProgress.Key || w.Header().Get("Retry-After") != "1" {
+			t.Errorf("并发请求 = (%d, %s, %v), 期望 %s", w.Code, w.Body.String(), w.Header(), errcode.IdempotencyInProgress.Key)
+		}
+	})
+
+	t.Run("内容不同的并发请求", func(t *testing.T) {
+		useIdempotencyStore(t, 5*time.Second)
+		started, release := make(chan struct{}), make(chan struct{})
+		r := newIdempotencyEngine(func(c *gin.Context) {
+			close(started)
+			<-release
+			c.JSON(http.StatusCreated, gin.H{})
+		})
+
+		first := startBlocked(t, r, started)
+		w := send(r, http.MethodPost, "/orders", "k1", `{"qty":2}`)
+		close(release)
+		<-first
+		if w.Code != http.StatusConflict || errorCodeOf(t, w) != errcode.IdempotencyKeyReused.Key {
+			t.Errorf("并发请求 = (%d, %s), 期望立即返回 %s", w.Code, w.Body.String(), errcode.IdempotencyKeyReused.Key)
+		}
+	})
+}
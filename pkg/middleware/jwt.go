@@ -1,11 +1,14 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"os"
 	"strings"
 	"time"
 
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
 	"erp_backend/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -23,16 +26,20 @@ func getJWTSecret() string {
 }
 
 type Claims struct {
-	UserID   uint   `json:"user_id"`
-	UserType string `json:"user_type"`
+	UserID     uint   `json:"user_id"`
+	UserType   string `json:"user_type"`
+	SupplierID uint   `json:"supplier_id,omitempty"` // 所属供应商ID，供应商用户据此限定可修改的商品
+	Language   string `json:"language,omitempty"`    // 用户语言偏好
 	jwt.RegisteredClaims
 }
 
-// GenerateToken 生成JWT令牌
-func GenerateToken(userID uint, userType string) (string, error) {
+// GenerateToken 生成JWT令牌，supplierID 为用户所属供应商，没有时为 0
+func GenerateToken(userID uint, userType string, supplierID uint, language string) (string, error) {
 	claims := Claims{
-		UserID:   userID,
-		UserType: userType,
+		UserID:     userID,
+		UserType:   userType,
+		SupplierID: supplierID,
+		Language:   language,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 24小时后过期
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -44,39 +51,51 @@ func GenerateToken(userID uint, userType string) (string, error) {
 	return token.SignedString(jwtSecret)
 }
 
-// JWTAuth JWT认证中间件
-func JWTAuth() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			response.UnauthorizedResponse(c, "未提供认证信息")
-			c.Abort()
-			return
+// ParseToken 解析并校验JWT令牌
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("无效的签名方法")
 		}
+		return jwtSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenExpired
+	}
+	return claims, nil
+}
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if !(len(parts) == 2 && parts[0] == "Bearer") {
-			response.UnauthorizedResponse(c, "认证格式错误")
-			c.Abort()
-			return
-		}
+// Authenticate 校验 Authorization 头中的 Bearer 令牌，失败时返回对应的错误码，供 gRPC 等非 gin 入口复用
+func Authenticate(authHeader string) (*Claims, error) {
+	if authHeader == "" {
+		return nil, errcode.TokenMissing
+	}
 
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(parts[1], claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("无效的签名方法")
-			}
-			return jwtSecret, nil
-		})
+	parts := strings.SplitN(authHeader, " ", 2)
+	if !(len(parts) == 2 && parts[0] == "Bearer") {
+		return nil, errcode.TokenMalformed
+	}
 
-		if err != nil {
-			response.UnauthorizedResponse(c, "无效的令牌")
-			c.Abort()
-			return
-		}
+	claims, err := ParseToken(parts[1])
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, errcode.TokenExpired
+	}
+	if err != nil {
+		return nil, errcode.TokenInvalid
+	}
+	return claims, nil
+}
 
-		if !token.Valid {
-			response.UnauthorizedResponse(c, "令牌已过期")
+// JWTAuth JWT认证中间件
+func JWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := Authenticate(c.GetHeader("Authorization"))
+		if err != nil {
+			response.FailWithError(c, err)
 			c.Abort()
 			return
 		}
@@ -84,31 +103,65 @@ func JWTAuth() gin.HandlerFunc {
 		// 将用户信息存储到上下文中
 		c.Set("user_id", claims.UserID)
 		c.Set("user_type", claims.UserType)
+		c.Set(CallerKey, &Caller{UserID: claims.UserID, UserType: claims.UserType, SupplierID: claims.SupplierID})
+		if claims.Language != "" {
+			c.Set(i18n.ContextKey, claims.Language)
+		}
 		c.Next()
 	}
 }
 
-// RequireUserType 检查用户类型的中间件
-func RequireUserType(allowedTypes ...string) gin.HandlerFunc {
+// Caller 当前调用方，由 JWTAuth 与 gRPC 认证拦截器写入，供服务层按调用方授权
+type Caller struct {
+	UserID     uint   // 用户ID，API 密钥调用时为 0
+	UserType   string // 用户类型
+	SupplierID uint   // 所属供应商ID，没有时为 0
+}
+
+// CallerKey 调用方在 gin.Context 中的键，gin.Context 的 Value 按字符串键读取 c.Set 写入的值
+const CallerKey = "caller"
+
+// callerContextKey 调用方在 context 中的键
+type callerContextKey struct{}
+
+// WithCaller 将调用方写入 context，供 gRPC 等非 gin 入口使用
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext 读取当前调用方，ctx 可以是 *gin.Context 或 WithCaller 返回的 context
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	if caller, ok := ctx.Value(callerContextKey{}).(*Caller); ok {
+		return caller, true
+	}
+	caller, ok := ctx.Value(CallerKey).(*Caller)
+	return caller, ok
+}
+
+// TokenFromQuery 请求未携带 Authorization 头时从查询参数 param 读取令牌，需放在 JWTAuth 之前
+// 用于浏览器 EventSource、WebSocket 等无法设置请求头的场景
+func TokenFromQuery(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query(param); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission 检查当前用户是否拥有权限 code 的中间件，需放在 JWTAuth 之后
+// userTypes 为拥有该权限的用户类型，为空表示所有登录用户；路由通常经由 Router 按访问要求挂载
+func RequirePermission(code string, userTypes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userType, exists := c.Get("user_type")
 		if !exists {
-			response.UnauthorizedResponse(c, "未找到用户类型信息")
+			response.Fail(c, errcode.Unauthorized)
 			c.Abort()
 			return
 		}
 
-		userTypeStr := userType.(string)
-		allowed := false
-		for _, t := range allowedTypes {
-			if userTypeStr == t {
-				allowed = true
-				break
-			}
-		}
-
-		if !allowed {
-			response.ForbiddenResponse(c, "权限不足")
+		if len(userTypes) > 0 && !contains(userTypes, userType.(string)) {
+			response.Fail(c, errcode.Forbidden)
 			c.Abort()
 			return
 		}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"erp_backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader 请求ID请求头
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID 为每个请求分配请求ID，已携带 X-Request-ID 时沿用，并在响应头中返回
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+
+		c.Set(logger.RequestIDKey, requestID)
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// AccessLog 以结构化日志记录每个请求
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		attrs := []any{
+			slog.String(logger.RequestIDKey, c.GetString(logger.RequestIDKey)),
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if userID, exists := c.Get("user_id"); exists {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		slog.Log(c.Request.Context(), level, "HTTP 请求", attrs...)
+	}
+}
+
+// newRequestID 生成随机请求ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/response"
+	"erp_backend/pkg/settings"
+)
+
+// MaintenanceModeKey 维护模式的设置键，由 system 模块声明
+const MaintenanceModeKey = "system.maintenance_mode"
+
+// Maintenance 维护模式开启时拒绝写请求，只允许 GET、HEAD、OPTIONS
+// skipRoutes 为不受限制的路由（如登录、修改设置），批量接口与 GraphQL 的子请求仍逐个检查
+func Maintenance(skipRoutes ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(skipRoutes))
+	for _, route := range skipRoutes {
+		skip[route] = true
+	}
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if skip[c.FullPath()] || !settings.Get[bool](c, MaintenanceModeKey) {
+			c.Next()
+			return
+		}
+		response.Fail(c, errcode.MaintenanceMode)
+		c.Abort()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"erp_backend/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 记录 HTTP 请求耗时指标，按路由模板而非实际路径统计以控制标签数量
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/ratelimit"
+)
+
+// useRateLimiter 设置内存存储的全局限流器，测试结束后恢复为未设置
+func useRateLimiter(t *testing.T, policy string) {
+	t.Helper()
+	l, err := ratelimit.New(&config.RateLimitConfig{Store: "memory", Default: policy, Login: "1/1m", Bulk: "1/1m"}, nil)
+	if err != nil {
+		t.Fatalf("ratelimit.New() 错误 = %v", err)
+	}
+	SetRateLimiter(l)
+	t.Cleanup(func() { SetRateLimiter(nil) })
+}
+
+// newRateLimitEngine 返回挂载默认额度限流的路由，setup 在限流之前执行，用于模拟认证中间件
+func newRateLimitEngine(setup gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	if setup != nil {
+		r.Use(setup)
+	}
+	r.Use(RateLimit(ratelimit.PolicyDefault, "/health"))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.GET("/items", ok)
+	r.GET("/health", ok)
+	return r
+}
+
+// get 发送 GET 请求，header 为可选的 Authorization 头
+func get(r http.Handler, target, remoteAddr, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.RemoteAddr = remoteAddr
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	useRateLimiter(t, "2/1m")
+	r := newRateLimitEngine(nil)
+
+	for _, remaining := range []string{"1", "0"} {
+		w := get(r, "/items", "10.0.0.1:1234", "")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("状态码 = %d, 期望 %d", w.Code, http.StatusNoContent)
+		}
+		want := map[string]string{
+			"RateLimit-Limit":     "2",
+			"RateLimit-Remaining": remaining,
+			"RateLimit-Policy":    "2;w=60",
+			"Retry-After":         "",
+		}
+		for header, value := range want {
+			if got := w.Header().Get(header); got != value {
+				t.Errorf("%s = %q, 期望 %q", header, got, value)
+			}
+		}
+	}
+
+	// 用完后拒绝：每 30 秒补充一个，Retry-After 与 Reset 向上取整为秒
+	w := get(r, "/items", "10.0.0.1:1234", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("状态码 = %d, 期望 %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, 期望 30", got)
+	}
+	if got := w.Header().Get("RateLimit-Reset"); got != "60" {
+		t.Errorf("RateLimit-Reset = %q, 期望 60", got)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("RateLimit-Remaining = %q, 期望 0", got)
+	}
+}
+
+func TestRateLimitSkip(t *testing.T) {
+	r := newRateLimitEngine(nil)
+	// 未设置限流器时直接放行
+	if w := get(r, "/items", "10.0.0.1:1234", ""); w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("未设置限流器时 状态码 = %d, 头 = %v", w.Code, w.Header())
+	}
+
+	useRateLimiter(t, "1/1m")
+	for i := 0; i < 3; i++ {
+		if w := get(r, "/health", "10.0.0.1:1234", ""); w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("跳过的路由 状态码 = %d, 头 = %v", w.Code, w.Header())
+		}
+	}
+}
+
+func TestRateLimitCallerKey(t *testing.T) {
+	useRateLimiter(t, "1/1m")
+	token, err := GenerateToken(7, "admin", 0, "")
+	if err != nil {
+		t.Fatalf("GenerateToken() 错误 = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		set           map[string]interface{}
+		authorization string
+		want          string
+	}{
+		{"API Key 优先", map[string]interface{}{"api_key_id": uint(3), "user_id": uint(7)}, "", "key:3"},
+		{"登录用户", map[string]interface{}{"user_id": uint(7)}, "", "user:7"},
+		{"未经认证的有效令牌", nil, "Bearer " + token, "user:7"},
+		{"无效令牌按IP", nil, "Bearer invalid", "ip:10.0.0.1"},
+		{"匿名按IP", nil, "", "ip:10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+			c.Request.RemoteAddr = "10.0.0.1:1234"
+			if tt.authorization != "" {
+				c.Request.Header.Set("Authorization", tt.authorization)
+			}
+			for k, v := range tt.set {
+				c.Set(k, v)
+			}
+			if got := callerKey(c); got != tt.want {
+				t.Errorf("callerKey() = %q, 期望 %q", got, tt.want)
+			}
+		})
+	}
+
+	// 不同调用方各自计数，同一用户的令牌与登录态共用额度
+	r := newRateLimitEngine(nil)
+	if w := get(r, "/items", "10.0.0.1:1234", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("IP 10.0.0.1 状态码 = %d", w.Code)
+	}
+	if w := get(r, "/items", "10.0.0.2:1234", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("IP 10.0.0.2 状态码 = %d, 期望不受其他 IP 影响", w.Code)
+	}
+	if w := get(r, "/items", "10.0.0.1:1234", "Bearer "+token); w.Code != http.StatusNoContent {
+		t.Fatalf("令牌用户 状态码 = %d, 期望不受同 IP 匿名请求影响", w.Code)
+	}
+	authed := newRateLimitEngine(func(c *gin.Context) { c.Set("user_id", uint(7)) })
+	if w := get(authed, "/items", "10.0.0.3:1234", ""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("登录用户 状态码 = %d, 期望与令牌共用额度而被拒绝", w.Code)
+	}
+}
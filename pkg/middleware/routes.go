@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Access 路由的访问要求，零值表示公开访问
+type Access struct {
+	Auth       bool     // 是否需要登录
+	Permission string   // 所需的权限编码，为空表示不检查权限
+	UserTypes  []string // 拥有该权限的用户类型，为空表示所有登录用户
+}
+
+// 无需权限编码的访问要求
+var (
+	Public        = Access{}
+	Authenticated = Access{Auth: true}
+)
+
+// handlers 按访问要求生成鉴权中间件：需要登录时校验令牌，声明了权限编码时再检查用户类型
+func (a Access) handlers() []gin.HandlerFunc {
+	var handlers []gin.HandlerFunc
+	if a.Auth {
+		handlers = append(handlers, JWTAuth())
+	}
+	if a.Permission != "" {
+		handlers = append(handlers, RequirePermission(a.Permission, a.UserTypes...))
+	}
+	return handlers
+}
+
+// RouteAccess 路由及其访问要求
+type RouteAccess struct {
+	Method     string   `json:"method"`     // 请求方法
+	Path       string   `json:"path"`       // 路由模板
+	Handler    string   `json:"handler"`    // 处理函数
+	Auth       bool     `json:"auth"`       // 是否需要登录
+	Permission string   `json:"permission"` // 调用所需的权限编码；无需权限时为 authenticated（只需登录）或 public
+	UserTypes  []string `json:"user_types"` // 拥有该权限的用户类型，为空表示所有登录用户
+}
+
+// Router 路由组的包装，注册路由时连同访问要求一起登记，鉴权中间件按访问要求生成，
+// 因此路由表与实际生效的鉴权一致；同一个根 Router 派生的路由组共用一张路由表
+type Router struct {
+	group  *gin.RouterGroup
+	routes *[]RouteAccess
+}
+
+// NewRouter 包装路由组，创建新的路由表
+func NewRouter(group *gin.RouterGroup) *Router {
+	return &Router{group: group, routes: &[]RouteAccess{}}
+}
+
+// Group 创建子路由组，handlers 在组内每个路由的鉴权中间件之前执行
+func (r *Router) Group(relativePath string, handlers ...gin.HandlerFunc) *Router {
+	return &Router{group: r.group.Group(relativePath, handlers...), routes: r.routes}
+}
+
+// BasePath 路由组的路径前缀
+func (r *Router) BasePath() string {
+	return r.group.BasePath()
+}
+
+// Handle 注册路由并登记访问要求，鉴权中间件放在 handlers 之前
+func (r *Router) Handle(method, relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	r.group.Handle(method, relativePath, append(access.handlers(), handlers...)...)
+
+	route := RouteAccess{
+		Method:     method,
+		Path:       joinPaths(r.group.BasePath(), relativePath),
+		Handler:    shortName(handlerName(handlers[len(handlers)-1])),
+		Auth:       access.Auth,
+		Permission: access.Permission,
+		UserTypes:  access.UserTypes,
+	}
+	switch {
+	case route.Permission != "":
+	case route.Auth:
+		route.Permission = "authenticated"
+	default:
+		route.Permission = "public"
+	}
+	if route.UserTypes == nil {
+		route.UserTypes = []string{}
+	}
+	*r.routes = append(*r.routes, route)
+}
+
+// GET 注册 GET 路由
+func (r *Router) GET(relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodGet, relativePath, access, handlers...)
+}
+
+// POST 注册 POST 路由
+func (r *Router) POST(relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodPost, relativePath, access, handlers...)
+}
+
+// PUT 注册 PUT 路由
+func (r *Router) PUT(relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodPut, relativePath, access, handlers...)
+}
+
+// PATCH 注册 PATCH 路由
+func (r *Router) PATCH(relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodPatch, relativePath, access, handlers...)
+}
+
+// DELETE 注册 DELETE 路由
+func (r *Router) DELETE(relativePath string, access Access, handlers ...gin.HandlerFunc) {
+	r.Handle(http.MethodDelete, relativePath, access, handlers...)
+}
+
+// Routes 已登记的路由及其访问要求，按路径与方法排序
+func (r *Router) Routes() []RouteAccess {
+	routes := append([]RouteAccess(nil), *r.routes...)
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes
+}
+
+// joinPaths 拼接路由组前缀与相对路径，与 gin 的规则一致：保留相对路径末尾的斜杠
+func joinPaths(base, relativePath string) string {
+	if relativePath == "" {
+		return base
+	}
+	joined := path.Join(base, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// handlerName 处理函数的完整名称，与 gin 处理链中的名称一致
+func handlerName(h gin.HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+}
+
+// shortName 去掉处理函数名称中的包路径与方法值后缀，如 product.(*Handler).Create
+func shortName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// contains 判断切片中是否包含 s
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
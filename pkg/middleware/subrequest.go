@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+)
+
+type subRequestKey struct{}
+
+// WithSubRequest 标记 context 属于批量接口或 GraphQL 发起的子请求
+// 子请求的幂等由外层请求整体处理；限流仍按子请求对应路由的额度计数
+func WithSubRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, subRequestKey{}, true)
+}
+
+// IsSubRequest 判断请求是否为批量接口或 GraphQL 发起的子请求
+func IsSubRequest(ctx context.Context) bool {
+	sub, _ := ctx.Value(subRequestKey{}).(bool)
+	return sub
+}
@@ -0,0 +1,137 @@
+package module
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/jobs"
+	"erp_backend/pkg/middleware"
+	"erp_backend/pkg/settings"
+)
+
+// Module 业务模块，声明自身的数据模型、迁移、路由、权限、种子数据与后台任务，
+// 在包的 init 中通过 Register 注册，由 main 按依赖顺序统一装配
+type Module interface {
+	// Name 模块名，用于配置启用/禁用与声明依赖，全局唯一
+	Name() string
+	// DependsOn 依赖的模块名，依赖的模块先迁移、先注册路由；依赖未启用时启动失败
+	DependsOn() []string
+	// Models 需要自动迁移的数据模型
+	Models() []interface{}
+	// Migrate 在自动迁移所有模型后执行的额外迁移（索引、扩展等），需可重复执行
+	Migrate(db *gorm.DB) error
+	// RegisterRoutes 在 /api/v1 路由组下注册路由，每个路由连同访问要求一起登记
+	RegisterRoutes(r *middleware.Router, app *App)
+	// Permissions 模块定义的权限，路由通过 RequirePermission 按权限编码引用
+	Permissions() []Permission
+	// Seed 初始化种子数据，需可重复执行（已有数据时跳过）
+	Seed(ctx context.Context, db *gorm.DB) error
+	// Jobs 返回随服务运行的后台任务，服务启动时调用一次，可在其中订阅领域事件、
+	// 通过 app.Queue 注册持久化任务类型与定时计划
+	Jobs(app *App) []Job
+}
+
+// GRPCModule 提供 gRPC 服务的模块
+type GRPCModule interface {
+	Module
+	// RegisterGRPC 注册 gRPC 服务
+	RegisterGRPC(s grpc.ServiceRegistrar, app *App)
+	// GRPCPermissions 方法全名（/{服务}/{方法}）到权限编码的映射，每个方法都需登记，
+	// 与对应 REST 接口引用相同的权限；以用户令牌调用未登记的方法时拒绝
+	GRPCPermissions() map[string]string
+}
+
+// MetricsModule 提供监控指标的模块
+type MetricsModule interface {
+	Module
+	// Collectors 返回模块的 Prometheus 采集器
+	Collectors(app *App) []prometheus.Collector
+}
+
+// CommandModule 提供运维命令的模块
+type CommandModule interface {
+	Module
+	// Commands 返回模块的命令行子命令
+	Commands() []Command
+}
+
+// SettingsModule 声明运行时设置的模块
+type SettingsModule interface {
+	Module
+	// Settings 返回模块拥有的设置项及默认值，设置键以模块名为命名空间，管理员可通过设置接口修改
+	Settings() []settings.Definition
+}
+
+// EventsModule 发布领域事件的模块
+type EventsModule interface {
+	Module
+	// Events 返回模块发布的领域事件（零值即可，只用于取事件名），Webhook 据此确定可订阅的事件类型
+	Events() []events.Event
+}
+
+// App 模块装配时可用的公共依赖
+type App struct {
+	DB       *gorm.DB        // 数据库连接
+	Router   http.Handler    // 完整的路由，供批量接口与 GraphQL 以普通请求执行子请求
+	Relay    *events.Relay   // 领域事件转发器，用于订阅事件
+	Queue    *jobs.Queue     // 后台任务队列，用于注册任务类型与定时计划
+	Settings *settings.Store // 运行时设置
+}
+
+// Permission 权限定义，授予哪些用户类型由声明决定，RequirePermission 据此生成路由的访问要求
+type Permission struct {
+	Code        string   `json:"code" example:"product.write"`      // 权限编码，格式为 模块.操作，如 product.write
+	Description string   `json:"description" example:"创建、修改与删除商品"`  // 权限说明
+	UserTypes   []string `json:"user_types" example:"admin,管理员,员工"` // 拥有该权限的用户类型，为空表示所有登录用户
+}
+
+// 常用的用户类型组合，与登录令牌中的取值一致
+var (
+	Admins = []string{"admin", "管理员"}
+	Staff  = []string{"admin", "管理员", "员工"}
+)
+
+// Job 后台任务
+type Job struct {
+	Name string                    // 任务名，用于日志
+	Run  func(ctx context.Context) // 任务主体，应在 ctx 取消后尽快返回
+	Stop func()                    // 开始优雅关闭时调用，用于通知长连接尽快结束，可为空
+}
+
+// Command 运维命令，以 <程序> <Name> [参数] 运行，与服务共用配置加载与数据库连接
+type Command struct {
+	Name        string                                                   // 命令名，可包含空格表示子命令，如 user create
+	Usage       string                                                   // 参数说明，如 -name <用户名>
+	Description string                                                   // 命令说明
+	Run         func(ctx context.Context, app *App, args []string) error // 执行命令，args 为命令名之后的参数；结果输出到标准输出
+	NoDB        bool                                                     // 不连接数据库，App.DB 为未连接的句柄，只能用于注册路由等不执行查询的操作
+}
+
+// Base 模块的空实现，嵌入后只需实现 Name 及用到的方法
+type Base struct{}
+
+// DependsOn 实现 Module 接口
+func (Base) DependsOn() []string { return nil }
+
+// Models 实现 Module 接口
+func (Base) Models() []interface{} { return nil }
+
+// Migrate 实现 Module 接口
+func (Base) Migrate(*gorm.DB) error { return nil }
+
+// RegisterRoutes 实现 Module 接口
+func (Base) RegisterRoutes(*middleware.Router, *App) {}
+
+// Permissions 实现 Module 接口
+func (Base) Permissions() []Permission { return nil }
+
+// Seed 实现 Module 接口
+func (Base) Seed(context.Context, *gorm.DB) error { return nil }
+
+// Jobs 实现 Module 接口
+func (Base) Jobs(*App) []Job { return nil }
@@ -0,0 +1,194 @@
+package module
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/middleware"
+)
+
+// registry 已注册的模块
+var registry = struct {
+	mu      sync.RWMutex
+	modules map[string]Module
+	enabled []Module
+}{modules: make(map[string]Module)}
+
+// Register 注册模块，通常在模块包的 init 中调用；模块名为空或重复时 panic
+func Register(m Module) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	name := m.Name()
+	if name == "" {
+		panic("module: 模块名不能为空")
+	}
+	if _, ok := registry.modules[name]; ok {
+		panic("module: 重复注册模块 " + name)
+	}
+	registry.modules[name] = m
+}
+
+// Resolve 按配置确定启用的模块并按依赖排序，结果同时保存供 Enabled 查询
+// 依赖的模块排在前面，无依赖关系的模块按名称排序，因此启动顺序与迁移顺序是确定的；
+// 配置了未注册的模块、依赖未启用或存在循环依赖时返回错误
+func Resolve(cfg *config.ModuleConfig) ([]Module, error) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	selected := make(map[string]bool, len(registry.modules))
+	if len(cfg.Enabled) == 0 {
+		for name := range registry.modules {
+			selected[name] = true
+		}
+	}
+	for _, name := range cfg.Enabled {
+		if _, ok := registry.modules[name]; !ok {
+			return nil, fmt.Errorf("MODULES_ENABLED 中的模块 %s 未注册", name)
+		}
+		selected[name] = true
+	}
+	for _, name := range cfg.Disabled {
+		if _, ok := registry.modules[name]; !ok {
+			return nil, fmt.Errorf("MODULES_DISABLED 中的模块 %s 未注册", name)
+		}
+		delete(selected, name)
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// 剩余依赖数与被依赖关系
+	pending := make(map[string]int, len(selected))
+	dependents := make(map[string][]string)
+	for _, name := range names {
+		for _, dep := range registry.modules[name].DependsOn() {
+			if _, ok := registry.modules[dep]; !ok {
+				return nil, fmt.Errorf("模块 %s 依赖的模块 %s 未注册", name, dep)
+			}
+			if !selected[dep] {
+				return nil, fmt.Errorf("模块 %s 依赖的模块 %s 未启用", name, dep)
+			}
+			pending[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	var ready []string
+	for _, name := range names {
+		if pending[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	ordered := make([]Module, 0, len(selected))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, registry.modules[name])
+		for _, dependent := range dependents[name] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if len(ordered) < len(selected) {
+		var cycle []string
+		for _, name := range names {
+			if pending[name] > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		return nil, fmt.Errorf("模块存在循环依赖: %s", strings.Join(cycle, ", "))
+	}
+
+	registry.enabled = ordered
+	return ordered, nil
+}
+
+// Enabled 返回 Resolve 确定的已启用模块，按启动顺序排列
+func Enabled() []Module {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.enabled
+}
+
+// IsEnabled 模块是否已启用
+func IsEnabled(name string) bool {
+	for _, m := range Enabled() {
+		if m.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions 返回已启用模块定义的全部权限
+func Permissions() []Permission {
+	var permissions []Permission
+	for _, m := range Enabled() {
+		permissions = append(permissions, m.Permissions()...)
+	}
+	return permissions
+}
+
+// RequirePermission 按模块声明的权限生成路由的访问要求，注册路由时传给 middleware.Router；
+// 权限编码的前缀为声明它的模块名，编码未声明时 panic，在注册路由时即可发现拼写错误
+func RequirePermission(code string) middleware.Access {
+	name, _, _ := strings.Cut(code, ".")
+	registry.mu.RLock()
+	m, ok := registry.modules[name]
+	registry.mu.RUnlock()
+	if ok {
+		for _, p := range m.Permissions() {
+			if p.Code == code {
+				return middleware.Access{Auth: true, Permission: p.Code, UserTypes: p.UserTypes}
+			}
+		}
+	}
+	panic("module: 权限 " + code + " 未声明")
+}
+
+// GRPCAccess 按模块登记的 gRPC 方法权限生成各方法的访问要求，供 gRPC 认证拦截器检查用户类型；
+// 引用未声明的权限编码时 panic，与 RequirePermission 一致
+func GRPCAccess(modules []Module) map[string]middleware.Access {
+	methods := make(map[string]middleware.Access)
+	for _, m := range modules {
+		if gm, ok := m.(GRPCModule); ok {
+			for method, code := range gm.GRPCPermissions() {
+				methods[method] = RequirePermission(code)
+			}
+		}
+	}
+	return methods
+}
+
+// Commands 返回已启用模块提供的运维命令
+func Commands() []Command {
+	var commands []Command
+	for _, m := range Enabled() {
+		if cm, ok := m.(CommandModule); ok {
+			commands = append(commands, cm.Commands()...)
+		}
+	}
+	return commands
+}
+
+// EventNames 返回已启用模块声明的领域事件名
+func EventNames() []string {
+	var names []string
+	for _, m := range Enabled() {
+		if em, ok := m.(EventsModule); ok {
+			for _, e := range em.Events() {
+				names = append(names, e.EventName())
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,334 @@
+package pagination
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"erp_backend/pkg/errcode"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// DefaultPageSize 默认每页条数
+	DefaultPageSize = 20
+	// MaxPageSize 服务端允许的最大每页条数
+	MaxPageSize = 100
+)
+
+// schemaCache 缓存模型解析结果
+var schemaCache = &sync.Map{}
+
+// Config 列表分页排序配置
+type Config struct {
+	SortFields  []string // 允许排序的字段（与数据库列名一致）
+	DefaultSort string   // 默认排序，如 "-created_at"
+}
+
+// SortField 排序字段
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// Params 分页参数
+type Params struct {
+	Page     int
+	PageSize int
+	Cursor   string
+	Sort     []SortField
+}
+
+// Page 分页响应结构
+// @Description 分页数据
+type Page struct {
+	Items      interface{} `json:"items"`                 // 数据列表
+	Total      int64       `json:"total"`                 // 总条数
+	Page       int         `json:"page"`                  // 当前页码（游标模式下为0）
+	PageSize   int         `json:"page_size"`             // 每页条数
+	NextCursor string      `json:"next_cursor,omitempty"` // 下一页游标
+}
+
+// Parse 从请求中解析分页排序参数
+// 支持 page、page_size、cursor 以及 sort=-price,name 形式的多字段排序
+func Parse(c *gin.Context, cfg Config) (*Params, error) {
+	return ParseValues(c.Request.URL.Query(), cfg)
+}
+
+// ParseValues 从 url.Values 中解析分页排序参数
+func ParseValues(query url.Values, cfg Config) (*Params, error) {
+	params := &Params{Page: 1, PageSize: DefaultPageSize, Cursor: query.Get("cursor")}
+
+	if page := query.Get("page"); page != "" {
+		n, err := strconv.Atoi(page)
+		if err != nil || n < 1 {
+			return nil, errcode.InvalidPagination.New("page")
+		}
+		params.Page = n
+	}
+
+	if pageSize := query.Get("page_size"); pageSize != "" {
+		n, err := strconv.Atoi(pageSize)
+		if err != nil || n < 1 {
+			return nil, errcode.InvalidPagination.New("page_size")
+		}
+		params.PageSize = n
+	}
+	if params.PageSize > MaxPageSize {
+		params.PageSize = MaxPageSize
+	}
+
+	sort := query.Get("sort")
+	if sort == "" {
+		sort = cfg.DefaultSort
+	}
+	sortFields, err := parseSort(sort, cfg.SortFields)
+	if err != nil {
+		return nil, err
+	}
+	params.Sort = sortFields
+
+	return params, nil
+}
+
+// parseSort 解析排序表达式并校验白名单
+func parseSort(sort string, allowed []string) ([]SortField, error) {
+	var fields []SortField
+	hasID := false
+	for _, item := range strings.Split(sort, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		field := SortField{Column: item}
+		if strings.HasPrefix(item, "-") {
+			field = SortField{Column: item[1:], Desc: true}
+		} else if strings.HasPrefix(item, "+") {
+			field.Column = item[1:]
+		}
+
+		if field.Column != "id" && !contains(allowed, field.Column) {
+			return nil, errcode.InvalidSort.New(field.Column)
+		}
+		if field.Column == "id" {
+			hasID = true
+		}
+		fields = append(fields, field)
+	}
+
+	// 以主键兜底，保证排序稳定，游标分页依赖这一点
+	if !hasID {
+		fields = append(fields, SortField{Column: "id"})
+	}
+	return fields, nil
+}
+
+// Find 执行分页查询，dest 必须是指向切片的指针
+// 提供 cursor 时使用游标分页并忽略 page，否则使用页码分页
+func (p *Params) Find(query *gorm.DB, dest interface{}) (*Page, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	modelSchema, err := schema.Parse(dest, schemaCache, query.NamingStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	find := query.Session(&gorm.Session{})
+	if p.Cursor != "" {
+		condition, args, err := p.cursorCondition(modelSchema)
+		if err != nil {
+			return nil, err
+		}
+		find = find.Where(condition, args...)
+	} else {
+		find = find.Offset((p.Page - 1) * p.PageSize)
+	}
+
+	// 空值位置与 PostgreSQL 默认一致并显式写出，游标条件依赖这一顺序
+	for _, field := range p.Sort {
+		order := qualify(modelSchema, field.Column) + " ASC NULLS LAST"
+		if field.Desc {
+			order = qualify(modelSchema, field.Column) + " DESC NULLS FIRST"
+		}
+		find = find.Order(order)
+	}
+
+	if err := find.Limit(p.PageSize).Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	page := &Page{
+		Items:    dest,
+		Total:    total,
+		Page:     p.Page,
+		PageSize: p.PageSize,
+	}
+	if p.Cursor != "" {
+		page.Page = 0
+	}
+
+	items := reflect.Indirect(reflect.ValueOf(dest))
+	if items.Len() == p.PageSize {
+		cursor, err := p.encodeCursor(query, modelSchema, items.Index(items.Len()-1))
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
+
+// cursorCondition 根据游标生成键集分页条件
+// 形如 (a > ?) OR (a = ? AND b > ?) ...，每个字段按自身排序方向比较；
+// 可为空的字段按排序中的空值位置生成 IS NULL 分支，列名带表名，查询关联其他表时不会产生歧义
+func (p *Params) cursorCondition(modelSchema *schema.Schema) (string, []interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return "", nil, errcode.InvalidCursor
+	}
+
+	var encoded []json.RawMessage
+	if err := json.Unmarshal(raw, &encoded); err != nil || len(encoded) != len(p.Sort) {
+		return "", nil, errcode.InvalidCursor
+	}
+
+	values := make([]interface{}, len(p.Sort))
+	nullable := make([]bool, len(p.Sort))
+	for i, sortField := range p.Sort {
+		field := modelSchema.LookUpField(sortField.Column)
+		if field == nil {
+			return "", nil, errcode.InvalidCursor
+		}
+		value := reflect.New(field.FieldType)
+		if err := json.Unmarshal(encoded[i], value.Interface()); err != nil {
+			return "", nil, errcode.InvalidCursor
+		}
+		values[i] = value.Elem().Interface()
+		nullable[i] = isNullable(field)
+	}
+
+	var clauses []string
+	var args []interface{}
+	for i, sortField := range p.Sort {
+		after, afterArgs := keysetAfter(qualify(modelSchema, sortField.Column), sortField.Desc, nullable[i], values[i])
+		if after == "" {
+			continue
+		}
+
+		var parts []string
+		for j := 0; j < i; j++ {
+			equal, equalArgs := keysetEqual(qualify(modelSchema, p.Sort[j].Column), values[j])
+			parts = append(parts, equal)
+			args = append(args, equalArgs...)
+		}
+		parts = append(parts, after)
+		args = append(args, afterArgs...)
+
+		if len(parts) == 1 {
+			clauses = append(clauses, after)
+		} else {
+			clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+		}
+	}
+	if len(clauses) == 0 {
+		return "1 = 0", nil, nil
+	}
+
+	return strings.Join(clauses, " OR "), args, nil
+}
+
+// keysetAfter 排在游标值 value 之后的条件，升序时空值在最后，降序时空值在最前；
+// 没有值排在其后时返回空字符串
+func keysetAfter(column string, desc, nullable bool, value interface{}) (string, []interface{}) {
+	switch {
+	case isNull(value) && desc:
+		return "(" + column + " IS NOT NULL)", nil
+	case isNull(value):
+		return "", nil
+	case desc:
+		return "(" + column + " < ?)", []interface{}{value}
+	case !nullable:
+		return "(" + column + " > ?)", []interface{}{value}
+	default:
+		return "(" + column + " > ? OR " + column + " IS NULL)", []interface{}{value}
+	}
+}
+
+// keysetEqual 与游标值 value 相等的条件
+func keysetEqual(column string, value interface{}) (string, []interface{}) {
+	if isNull(value) {
+		return column + " IS NULL", nil
+	}
+	return column + " = ?", []interface{}{value}
+}
+
+// isNull 判断游标值是否对应数据库中的 NULL：空指针，或写入数据库时为 NULL 的值（如无效的 gorm.DeletedAt）
+func isNull(value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return true
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+	return false
+}
+
+// isNullable 判断字段是否可为空：指针，或 sql.NullTime、gorm.DeletedAt 等实现 driver.Valuer 的类型
+func isNullable(field *schema.Field) bool {
+	if field.PrimaryKey || field.NotNull {
+		return false
+	}
+	return field.FieldType.Kind() == reflect.Ptr || reflect.PointerTo(field.FieldType).Implements(valuerType)
+}
+
+// valuerType driver.Valuer 接口类型
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// qualify 带表名的列名
+func qualify(modelSchema *schema.Schema, column string) string {
+	return modelSchema.Table + "." + column
+}
+
+// encodeCursor 使用最后一条记录的排序字段值生成游标
+func (p *Params) encodeCursor(query *gorm.DB, modelSchema *schema.Schema, last reflect.Value) (string, error) {
+	values := make([]interface{}, len(p.Sort))
+	for i, sortField := range p.Sort {
+		field := modelSchema.LookUpField(sortField.Column)
+		if field == nil {
+			return "", fmt.Errorf("排序字段不存在: %s", sortField.Column)
+		}
+		values[i], _ = field.ValueOf(query.Statement.Context, reflect.Indirect(last))
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// contains 判断字符串是否在列表中
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// job 含可为空排序字段的测试模型
+type job struct {
+	ID         uint
+	FinishedAt *time.Time
+}
+
+// cursor 编码游标
+func cursor(t *testing.T, values ...interface{}) string {
+	t.Helper()
+	raw, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("json.Marshal() 错误 = %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func TestCursorConditionNull(t *testing.T) {
+	modelSchema, err := schema.Parse(&job{}, schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("schema.Parse() 错误 = %v", err)
+	}
+	finished := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		desc      bool
+		value     interface{}
+		condition string
+		args      int
+	}{
+		{"升序非空", false, finished, "(jobs.finished_at > ? OR jobs.finished_at IS NULL) OR (jobs.finished_at = ? AND (jobs.id > ?))", 3},
+		{"升序空值", false, nil, "(jobs.finished_at IS NULL AND (jobs.id > ?))", 1},
+		{"降序非空", true, finished, "(jobs.finished_at < ?) OR (jobs.finished_at = ? AND (jobs.id > ?))", 3},
+		{"降序空值", true, nil, "(jobs.finished_at IS NOT NULL) OR (jobs.finished_at IS NULL AND (jobs.id > ?))", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Params{
+				Cursor: cursor(t, tt.value, 7),
+				Sort:   []SortField{{Column: "finished_at", Desc: tt.desc}, {Column: "id"}},
+			}
+			condition, args, err := p.cursorCondition(modelSchema)
+			if err != nil {
+				t.Fatalf("cursorCondition() 错误 = %v", err)
+			}
+			if condition != tt.condition {
+				t.Errorf("cursorCondition() = %q, 期望 %q", condition, tt.condition)
+			}
+			if len(args) != tt.args {
+				t.Errorf("参数个数 = %d, 期望 %d", len(args), tt.args)
+			}
+			if !reflect.DeepEqual(args[len(args)-1], uint(7)) {
+				t.Errorf("最后一个参数 = %v, 期望 7", args[len(args)-1])
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package patch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm/schema"
+)
+
+// 补丁格式
+const (
+	MergePatchType = "application/merge-patch+json" // RFC 7396 JSON Merge Patch，application/json 按此处理
+	JSONPatchType  = "application/json-patch+json"  // RFC 6902 JSON Patch
+)
+
+// Fields 允许修改的字段，键为 JSON 字段名，值为校验规则（validator 语法，可为空）
+type Fields map[string]string
+
+// schemaCache 模型结构缓存
+var schemaCache = &sync.Map{}
+
+// maxBodySize 补丁请求体上限，首次使用时读取配置
+var maxBodySize = sync.OnceValue(func() int64 { return int64(config.GetPatchConfig().MaxBodySize) })
+
+// namer 字段到列名的命名策略，与数据库连接使用的 GORM 默认命名策略一致
+var namer schema.Namer = schema.NamingStrategy{}
+
+// Apply 将请求体中的补丁应用到 model（指向已加载模型的指针），按 Content-Type 选择补丁格式
+// 只允许修改 fields 中的字段并按其规则校验，成功后 model 更新为修改后的值，
+// 返回变更字段对应的列与值，可直接用于 db.Model(model).Updates
+func Apply(c *gin.Context, model interface{}, fields Fields) (map[string]interface{}, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize()))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, errcode.RequestTooLarge.New()
+		}
+		return nil, errcode.InvalidParams.New()
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	return apply(i18n.Locale(c), model, fields, func(original []byte) ([]byte, error) {
+		if mediaType == JSONPatchType {
+			operations, err := jsonpatch.DecodePatch(body)
+			if err != nil {
+				return nil, errcode.InvalidPatch.New(err.Error())
+			}
+			return operations.Apply(original)
+		}
+
+		var doc map[string]interface{}
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return nil, errcode.InvalidPatch.New("合并补丁必须是 JSON 对象")
+		}
+		return jsonpatch.MergePatch(original, body)
+	})
+}
+
+// Merge 以合并补丁的方式设置字段，供切换状态、更新库存等只修改固定字段的接口复用
+// values 的键为 JSON 字段名，同样受 fields 白名单与校验规则约束，校验失败的提示信息使用 ctx 所属请求的语言
+func Merge(ctx context.Context, model interface{}, fields Fields, values map[string]interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return apply(i18n.FromContext(ctx), model, fields, func(original []byte) ([]byte, error) {
+		return jsonpatch.MergePatch(original, body)
+	})
+}
+
+// apply 将模型序列化为 JSON 文档，应用补丁后找出变更的字段，校验后写回模型，locale 为校验提示信息的语言
+func apply(locale string, model interface{}, fields Fields, patchFunc func([]byte) ([]byte, error)) (map[string]interface{}, error) {
+	original, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := patchFunc(original)
+	if err != nil {
+		var codeErr *errcode.Error
+		if errors.As(err, &codeErr) {
+			return nil, codeErr
+		}
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, errcode.PatchTestFailed.New()
+		}
+		return nil, errcode.InvalidPatch.New(err.Error())
+	}
+
+	changed, err := changedFields(original, patched)
+	if err != nil {
+		return nil, errcode.InvalidPatch.New(err.Error())
+	}
+	for _, field := range changed {
+		if _, ok := fields[field]; !ok {
+			return nil, errcode.FieldNotPatchable.New(field)
+		}
+	}
+	if len(changed) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	// 解码到新的实例，被补丁删除（置为 null）的字段得到零值
+	updated := reflect.New(reflect.TypeOf(model).Elem())
+	if err := json.Unmarshal(patched, updated.Interface()); err != nil {
+		return nil, errcode.FromBinding(err)
+	}
+
+	modelSchema, err := schema.Parse(model, schemaCache, namer)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]interface{}, len(changed))
+	var details []errcode.FieldError
+	for _, name := range changed {
+		field := fieldByJSONName(modelSchema, name)
+		if field == nil || field.DBName == "" {
+			return nil, errcode.FieldNotPatchable.New(name)
+		}
+		value := updated.Elem().FieldByIndex(field.StructField.Index).Interface()
+		if detail := validate(locale, name, value, fields[name]); detail != nil {
+			details = append(details, *detail)
+			continue
+		}
+		columns[field.DBName] = value
+	}
+	if len(details) > 0 {
+		return nil, errcode.ValidationFailed.WithDetails(details...)
+	}
+
+	// 只覆盖变更的字段，其余字段保持加载时的值
+	target := reflect.ValueOf(model).Elem()
+	for _, name := range changed {
+		field := fieldByJSONName(modelSchema, name)
+		target.FieldByIndex(field.StructField.Index).Set(updated.Elem().FieldByIndex(field.StructField.Index))
+	}
+	return columns, nil
+}
+
+// changedFields 比较补丁前后的 JSON 文档，返回值发生变化的顶层字段
+func changedFields(original, patched []byte) ([]string, error) {
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key, old := range before {
+		if _, ok := after[key]; !ok && old != nil {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// fieldByJSONName 按 JSON 字段名查找模型字段
+func fieldByJSONName(s *schema.Schema, name string) *schema.Field {
+	for _, field := range s.Fields {
+		if strings.SplitN(field.Tag.Get("json"), ",", 2)[0] == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// validate 按规则校验单个字段，提示信息使用 locale 语言
+func validate(locale, name string, value interface{}, rule string) *errcode.FieldError {
+	if rule == "" {
+		return nil
+	}
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if err := v.Var(value, rule); errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		fieldErr := validationErrors[0]
+		return &errcode.FieldError{
+			Field:   name,
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: errcode.RuleMessage(locale, fieldErr.Tag(), fieldErr.Param()),
+		}
+	}
+	return nil
+}
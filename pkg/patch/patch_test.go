@@ -0,0 +1,225 @@
+package patch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+)
+
+// item 测试模型
+type item struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Price     float64   `json:"price"`
+	Remark    *string   `json:"remark"`
+	Tags      []string  `gorm:"serializer:json" json:"tags"`
+}
+
+// fields 测试使用的字段白名单
+var fields = Fields{
+	"name":   "required,max=10",
+	"price":  "min=0",
+	"remark": "",
+	"tags":   "",
+}
+
+// newItem 返回已加载的测试记录
+func newItem() *item {
+	remark := "备注"
+	return &item{ID: 1, Name: "a", Price: 10, Remark: &remark, Tags: []string{"x"}}
+}
+
+// newContext 以指定的 Content-Type、语言与请求体构造请求上下文
+func newContext(contentType, language, body string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", contentType)
+	if language != "" {
+		c.Request.Header.Set("Accept-Language", language)
+	}
+	return c
+}
+
+// errorCode 返回业务错误的错误码
+func errorCode(err error) errcode.Code {
+	var e *errcode.Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return errcode.Code{}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		columns     map[string]interface{}
+		check       func(*item) bool
+	}{
+		{
+			name:        "合并补丁",
+			contentType: MergePatchType,
+			body:        `{"name": "b", "price": 12.5}`,
+			columns:     map[string]interface{}{"name": "b", "price": 12.5},
+			check:       func(m *item) bool { return m.Name == "b" && m.Price == 12.5 && *m.Remark == "备注" },
+		},
+		{
+			name:        "application/json 按合并补丁处理",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"tags": ["y", "z"]}`,
+			columns:     map[string]interface{}{"tags": []string{"y", "z"}},
+			check:       func(m *item) bool { return reflect.DeepEqual(m.Tags, []string{"y", "z"}) },
+		},
+		{
+			name:        "null 清空字段",
+			contentType: MergePatchType,
+			body:        `{"remark": null}`,
+			columns:     map[string]interface{}{"remark": (*string)(nil)},
+			check:       func(m *item) bool { return m.Remark == nil && m.Name == "a" },
+		},
+		{
+			name:        "值未变化",
+			contentType: MergePatchType,
+			body:        `{"name": "a", "id": 1}`,
+			columns:     map[string]interface{}{},
+			check:       func(m *item) bool { return m.Name == "a" },
+		},
+		{
+			name:        "JSON Patch",
+			contentType: JSONPatchType,
+			body:        `[{"op": "test", "path": "/price", "value": 10}, {"op": "replace", "path": "/price", "value": 0}, {"op": "add", "path": "/tags/-", "value": "y"}]`,
+			columns:     map[string]interface{}{"price": 0.0, "tags": []string{"x", "y"}},
+			check:       func(m *item) bool { return m.Price == 0 && reflect.DeepEqual(m.Tags, []string{"x", "y"}) },
+		},
+		{
+			name:        "JSON Patch 删除字段",
+			contentType: JSONPatchType,
+			body:        `[{"op": "remove", "path": "/remark"}]`,
+			columns:     map[string]interface{}{"remark": (*string)(nil)},
+			check:       func(m *item) bool { return m.Remark == nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := newItem()
+			columns, err := Apply(newContext(tt.contentType, "", tt.body), model, fields)
+			if err != nil {
+				t.Fatalf("Apply() 错误 = %v", err)
+			}
+			if !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("Apply() 列 = %#v, 期望 %#v", columns, tt.columns)
+			}
+			if !tt.check(model) {
+				t.Errorf("Apply() 后的模型 = %+v", model)
+			}
+		})
+	}
+}
+
+func TestApplyRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        errcode.Code
+	}{
+		{"字段不在白名单", MergePatchType, `{"id": 2}`, errcode.FieldNotPatchable},
+		{"删除不在白名单的字段", MergePatchType, `{"created_at": null}`, errcode.FieldNotPatchable},
+		{"JSON Patch 修改不在白名单的字段", JSONPatchType, `[{"op": "replace", "path": "/id", "value": 2}]`, errcode.FieldNotPatchable},
+		{"test 操作未通过", JSONPatchType, `[{"op": "test", "path": "/price", "value": 11}, {"op": "replace", "path": "/price", "value": 0}]`, errcode.PatchTestFailed},
+		{"合并补丁不是对象", MergePatchType, `["name"]`, errcode.InvalidPatch},
+		{"JSON Patch 格式错误", JSONPatchType, `{"op": "replace"}`, errcode.InvalidPatch},
+		{"JSON Patch 路径不存在", JSONPatchType, `[{"op": "replace", "path": "/missing/x", "value": 1}]`, errcode.InvalidPatch},
+		{"类型不符", MergePatchType, `{"price": "abc"}`, errcode.ValidationFailed},
+		{"校验失败", MergePatchType, `{"name": "", "price": -1}`, errcode.ValidationFailed},
+		{"请求体过大", MergePatchType, `{"remark": "` + strings.Repeat("a", 1<<20) + `"}`, errcode.RequestTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := newItem()
+			_, err := Apply(newContext(tt.contentType, "", tt.body), model, fields)
+			if code := errorCode(err); code != tt.want {
+				t.Fatalf("Apply() 错误 = %v, 期望 %s", err, tt.want.Key)
+			}
+			if !reflect.DeepEqual(model, newItem()) {
+				t.Errorf("Apply() 失败后模型被修改 = %+v", model)
+			}
+		})
+	}
+}
+
+func TestApplyValidationLocale(t *testing.T) {
+	for _, tt := range []struct{ header, locale string }{{"", i18n.ZhCN}, {"en-US,en;q=0.9", i18n.EnUS}} {
+		_, err := Apply(newContext(MergePatchType, tt.header, `{"name": "", "price": -1}`), newItem(), fields)
+		var e *errcode.Error
+		if !errors.As(err, &e) || len(e.Details) != 2 {
+			t.Fatalf("Apply() 错误 = %v, 期望两个字段的校验错误", err)
+		}
+		want := []errcode.FieldError{
+			{Field: "name", Rule: "required", Message: errcode.RuleMessage(tt.locale, "required", "")},
+			{Field: "price", Rule: "min", Param: "0", Message: errcode.RuleMessage(tt.locale, "min", "0")},
+		}
+		if !reflect.DeepEqual(e.Details, want) {
+			t.Errorf("Accept-Language %q 校验错误 = %+v, 期望 %+v", tt.header, e.Details, want)
+		}
+	}
+	if errcode.RuleMessage(i18n.ZhCN, "min", "0") == errcode.RuleMessage(i18n.EnUS, "min", "0") {
+		t.Error("RuleMessage() 中英文提示相同，期望按语言翻译")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	model := newItem()
+	columns, err := Merge(context.Background(), model, fields, map[string]interface{}{"price": 3})
+	if err != nil || !reflect.DeepEqual(columns, map[string]interface{}{"price": 3.0}) || model.Price != 3 {
+		t.Errorf("Merge() = (%v, %v), 模型 %+v, 期望只修改 price", columns, err, model)
+	}
+
+	if _, err := Merge(context.Background(), newItem(), fields, map[string]interface{}{"id": 2}); errorCode(err) != errcode.FieldNotPatchable {
+		t.Errorf("Merge() 修改 id 错误 = %v, 期望 %s", err, errcode.FieldNotPatchable.Key)
+	}
+
+	// 非 HTTP 入口通过 WithLocale 指定提示信息的语言
+	ctx := i18n.WithLocale(context.Background(), i18n.EnUS)
+	_, err = Merge(ctx, newItem(), fields, map[string]interface{}{"price": -1})
+	var e *errcode.Error
+	if !errors.As(err, &e) || len(e.Details) != 1 || e.Details[0].Message != errcode.RuleMessage(i18n.EnUS, "min", "0") {
+		t.Errorf("Merge() 错误 = %v, 期望英文的 min 校验提示", err)
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	tests := []struct {
+		original, patched string
+		want              []string
+	}{
+		{`{"a": 1, "b": "x"}`, `{"a": 1, "b": "x"}`, nil},
+		{`{"a": 1, "b": "x"}`, `{"a": 2, "b": "x"}`, []string{"a"}},
+		{`{"a": 1}`, `{"a": 1, "c": null}`, []string{"c"}},
+		{`{"a": 1, "b": null}`, `{"a": 1}`, nil},
+		{`{"a": 1, "b": "x"}`, `{"b": "y"}`, []string{"a", "b"}},
+		{`{"a": {"x": 1}}`, `{"a": {"x": 1, "y": 2}}`, []string{"a"}},
+	}
+	for _, tt := range tests {
+		got, err := changedFields([]byte(tt.original), []byte(tt.patched))
+		if err != nil {
+			t.Fatalf("changedFields() 错误 = %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("changedFields(%s, %s) = %v, 期望 %v", tt.original, tt.patched, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// MemoryStore 进程内令牌桶存储，仅适用于单实例部署
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket 令牌桶状态
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewMemoryStore 创建进程内令牌桶存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}}
+}
+
+// Take 实现 Store
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy) (*Result, error) {
+	now := time.Now()
+	limit := float64(policy.Limit)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit, updatedAt: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(limit, b.tokens+now.Sub(b.updatedAt).Seconds()*policy.Rate())
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return newResult(policy, b.tokens, allowed), nil
+}
+
+// Cleanup 实现 Store
+func (s *MemoryStore) Cleanup(_ context.Context, idle time.Duration) error {
+	cutoff := time.Now().Add(-idle)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, b := range s.buckets {
+		if b.updatedAt.Before(cutoff) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
+
+// Reset 实现 Store
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.buckets, key)
+	return nil
+}
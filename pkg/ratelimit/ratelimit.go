@@ -0,0 +1,165 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"erp_backend/pkg/config"
+
+	"gorm.io/gorm"
+)
+
+// 内置额度名称
+const (
+	PolicyDefault = "default" // 全部接口
+	PolicyLogin   = "login"   // 登录、注册
+	PolicyBulk    = "bulk"    // 批量与同步类接口
+)
+
+// Policy 令牌桶额度：桶容量为 Limit，每个 Window 补满一次
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// ParsePolicy 解析 次数/时间窗口 格式的额度，如 300/1m
+func ParsePolicy(name, value string) (Policy, error) {
+	limit, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("限流额度 %s 格式错误: %s", name, value)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(limit))
+	if err != nil || n <= 0 {
+		return Policy{}, fmt.Errorf("限流额度 %s 次数无效: %s", name, value)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return Policy{}, fmt.Errorf("限流额度 %s 时间窗口无效: %s", name, value)
+	}
+	return Policy{Name: name, Limit: n, Window: d}, nil
+}
+
+// Rate 每秒补充的令牌数
+func (p Policy) Rate() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+// Result 一次取令牌的结果
+type Result struct {
+	Allowed    bool          // 是否放行
+	Limit      int           // 桶容量
+	Remaining  int           // 剩余令牌数
+	Reset      time.Duration // 令牌补满所需时间
+	RetryAfter time.Duration // 被拒绝时，距下一个令牌可用的时间
+}
+
+// newResult 根据取令牌后的剩余令牌数计算结果
+func newResult(p Policy, tokens float64, allowed bool) *Result {
+	rate := p.Rate()
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     p.Limit,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     time.Duration((float64(p.Limit) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return result
+}
+
+// Store 令牌桶存储，内置内存与 PostgreSQL 两种实现
+// 项目不依赖 Redis，未提供 Redis 实现；接入时需在一次脚本调用中原子地完成补充与取令牌
+type Store interface {
+	// Take 从 key 对应的桶中取一个令牌
+	Take(ctx context.Context, key string, policy Policy) (*Result, error)
+	// Cleanup 清理超过 idle 未使用的桶，这些桶早已补满，删除不影响限流结果
+	Cleanup(ctx context.Context, idle time.Duration) error
+	// Reset 删除 key 对应的桶，下次请求时重新补满
+	Reset(ctx context.Context, key string) error
+}
+
+// Limiter 按额度名称限流
+type Limiter struct {
+	store    Store
+	policies map[string]Policy
+}
+
+// New 根据配置创建 Limiter，postgres 存储需传入数据库连接
+func New(cfg *config.RateLimitConfig, db *gorm.DB) (*Limiter, error) {
+	var store Store
+	switch strings.ToLower(cfg.Store) {
+	case "", "memory":
+		store = NewMemoryStore()
+	case "postgres":
+		pg := NewPostgresStore(db)
+		if err := pg.Migrate(); err != nil {
+			return nil, err
+		}
+		store = pg
+	default:
+		return nil, fmt.Errorf("不支持的限流存储: %s", cfg.Store)
+	}
+
+	limiter := &Limiter{store: store, policies: map[string]Policy{}}
+	for name, value := range map[string]string{
+		PolicyDefault: cfg.Default,
+		PolicyLogin:   cfg.Login,
+		PolicyBulk:    cfg.Bulk,
+	} {
+		policy, err := ParsePolicy(name, value)
+		if err != nil {
+			return nil, err
+		}
+		limiter.policies[name] = policy
+	}
+	return limiter, nil
+}
+
+// Policy 返回指定名称的额度
+func (l *Limiter) Policy(name string) (Policy, bool) {
+	p, ok := l.policies[name]
+	return p, ok
+}
+
+// Take 按额度为调用方 key 取一个令牌，不同额度的桶相互独立
+func (l *Limiter) Take(ctx context.Context, policy Policy, key string) (*Result, error) {
+	return l.store.Take(ctx, policy.Name+":"+key, policy)
+}
+
+// Reset 重置调用方 key 在全部额度下的桶，如 user:1
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	for name := range l.policies {
+		if err := l.store.Reset(ctx, name+":"+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cleanup 定期清理闲置的桶，直到 ctx 取消
+func (l *Limiter) Cleanup(ctx context.Context) {
+	// 闲置超过最长时间窗口的桶必然已补满
+	idle := time.Hour
+	for _, p := range l.policies {
+		if p.Window > idle {
+			idle = p.Window
+		}
+	}
+
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = l.store.Cleanup(ctx, idle)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"erp_backend/pkg/config"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("default", " 300 / 1m ")
+	if err != nil || policy != (Policy{Name: "default", Limit: 300, Window: time.Minute}) {
+		t.Errorf("ParsePolicy() = (%+v, %v), 期望 300/1m", policy, err)
+	}
+	if rate := policy.Rate(); rate != 5 {
+		t.Errorf("Rate() = %v, 期望 5", rate)
+	}
+
+	for _, value := range []string{"300", "0/1m", "-1/1m", "x/1m", "10/0s", "10/-1m", "10/abc", ""} {
+		if _, err := ParsePolicy("default", value); err == nil {
+			t.Errorf("ParsePolicy(%q) 期望返回错误", value)
+		}
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	policy := Policy{Name: "default", Limit: 60, Window: time.Minute}
+	tests := []struct {
+		name    string
+		tokens  float64
+		allowed bool
+		want    Result
+	}{
+		{"满桶取一个", 59, true, Result{Allowed: true, Limit: 60, Remaining: 59, Reset: time.Second}},
+		{"剩余不足一个时向下取整", 0.5, true, Result{Allowed: true, Limit: 60, Remaining: 0, Reset: 59500 * time.Millisecond}},
+		{"拒绝时给出下一个令牌的等待时间", 0.25, false, Result{Allowed: false, Limit: 60, Remaining: 0, Reset: 59750 * time.Millisecond, RetryAfter: 750 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		if got := newResult(policy, tt.tokens, tt.allowed); *got != tt.want {
+			t.Errorf("%s: newResult() = %+v, 期望 %+v", tt.name, *got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore(), "memory")
+}
+
+// TestPostgresStore 需设置 ERP_TEST_DATABASE_DSN 指向可写的 PostgreSQL 数据库
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv("ERP_TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("未设置 ERP_TEST_DATABASE_DSN")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm.Open() 错误 = %v", err)
+	}
+	store := NewPostgresStore(db)
+	if err := store.Migrate(); err != nil {
+		t.Fatalf("Migrate() 错误 = %v", err)
+	}
+	testStore(t, store, fmt.Sprintf("test-%d", time.Now().UnixNano()))
+}
+
+// testStore 两种存储共用的行为测试：突发、补充、容量上限、按键独立、重置与清理
+// 额度为每 300ms 补满 3 个（每 100ms 一个），补充依赖真实时间，等待时长留有余量
+func testStore(t *testing.T, store Store, prefix string) {
+	ctx := context.Background()
+	policy := Policy{Name: "test", Limit: 3, Window: 300 * time.Millisecond}
+	key := prefix + ":a"
+
+	take := func(key string) *Result {
+		t.Helper()
+		result, err := store.Take(ctx, key, policy)
+		if err != nil {
+			t.Fatalf("Take() 错误 = %v", err)
+		}
+		return result
+	}
+
+	// 新的桶是满的，允许一次性用完容量
+	for want := 2; want >= 0; want-- {
+		if result := take(key); !result.Allowed || result.Remaining != want || result.Limit != 3 {
+			t.Fatalf("Take() = %+v, 期望放行且剩余 %d", result, want)
+		}
+	}
+	result := take(key)
+	if result.Allowed || result.RetryAfter <= 0 || result.RetryAfter > 100*time.Millisecond {
+		t.Fatalf("用完后 Take() = %+v, 期望拒绝且 RetryAfter 在 (0, 100ms]", result)
+	}
+
+	// 按经过的时间补充
+	time.Sleep(150 * time.Millisecond)
+	if result := take(key); !result.Allowed {
+		t.Fatalf("补充后 Take() = %+v, 期望放行", result)
+	}
+	if result := take(key); result.Allowed {
+		t.Fatalf("补充的令牌用完后 Take() = %+v, 期望拒绝", result)
+	}
+
+	// 补充不超过容量
+	time.Sleep(500 * time.Millisecond)
+	if result := take(key); !result.Allowed || result.Remaining != 2 {
+		t.Fatalf("闲置后 Take() = %+v, 期望放行且剩余 2", result)
+	}
+
+	// 不同的键相互独立
+	if result := take(prefix + ":b"); !result.Allowed || result.Remaining != 2 {
+		t.Fatalf("另一个键 Take() = %+v, 期望放行且剩余 2", result)
+	}
+
+	// 重置后重新补满
+	for take(key).Allowed {
+	}
+	if err := store.Reset(ctx, key); err != nil {
+		t.Fatalf("Reset() 错误 = %v", err)
+	}
+	if result := take(key); !result.Allowed || result.Remaining != 2 {
+		t.Fatalf("重置后 Take() = %+v, 期望放行且剩余 2", result)
+	}
+
+	// 清理闲置的桶，之后按满桶计算
+	for take(key).Allowed {
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := store.Cleanup(ctx, time.Millisecond); err != nil {
+		t.Fatalf("Cleanup() 错误 = %v", err)
+	}
+	if result := take(key); !result.Allowed || result.Remaining != 2 {
+		t.Fatalf("清理后 Take() = %+v, 期望放行且剩余 2", result)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.RateLimitConfig{Store: "memory", Default: "2/1m", Login: "1/1m", Bulk: "5/1m"}
+	limiter, err := New(cfg, nil)
+	if err != nil {
+		t.Fatalf("New() 错误 = %v", err)
+	}
+
+	login, ok := limiter.Policy(PolicyLogin)
+	if !ok || login.Limit != 1 {
+		t.Fatalf("Policy(login) = (%+v, %v), 期望 1/1m", login, ok)
+	}
+	defaults, _ := limiter.Policy(PolicyDefault)
+
+	// 不同额度的桶相互独立
+	if result, _ := limiter.Take(ctx, login, "user:1"); !result.Allowed {
+		t.Errorf("login Take() = %+v, 期望放行", result)
+	}
+	if result, _ := limiter.Take(ctx, login, "user:1"); result.Allowed {
+		t.Errorf("login 用完后 Take() = %+v, 期望拒绝", result)
+	}
+	if result, _ := limiter.Take(ctx, defaults, "user:1"); !result.Allowed || result.Remaining != 1 {
+		t.Errorf("default Take() = %+v, 期望放行且剩余 1", result)
+	}
+
+	// Reset 重置调用方在全部额度下的桶
+	if err := limiter.Reset(ctx, "user:1"); err != nil {
+		t.Fatalf("Reset() 错误 = %v", err)
+	}
+	if result, _ := limiter.Take(ctx, login, "user:1"); !result.Allowed {
+		t.Errorf("重置后 login Take() = %+v, 期望放行", result)
+	}
+	if result, _ := limiter.Take(ctx, defaults, "user:1"); result.Remaining != 1 {
+		t.Errorf("重置后 default Take() = %+v, 期望剩余 1", result)
+	}
+
+	for _, bad := range []*config.RateLimitConfig{
+		{Store: "redis", Default: "2/1m", Login: "1/1m", Bulk: "5/1m"},
+		{Store: "memory", Default: "2", Login: "1/1m", Bulk: "5/1m"},
+	} {
+		if _, err := New(bad, nil); err == nil {
+			t.Errorf("New(%+v) 期望返回错误", *bad)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+)
+
+// schemaCache 模型结构缓存
+var schemaCache = &sync.Map{}
+
+// Memory 基于内存的 CRUD 与 Trashable 实现，供服务层单元测试替代数据库，T 需有 uint 类型的 ID 字段
+// 筛选只支持 eq、ne、in、nin 与 null 操作符，按值的字符串形式比较；排序与游标分页与数据库实现一致，空值排在升序的末尾
+// 删除的记录移入回收站，DeletedAt 为 gorm.DeletedAt 时同时设置删除时间
+type Memory[T any] struct {
+	mu      sync.Mutex
+	items   map[uint]T
+	trashed map[uint]T
+	nextID  uint
+}
+
+// NewMemory 创建内存 CRUD 实现，items 为初始数据，主键为 0 的记录自动分配主键
+func NewMemory[T any](items ...T) *Memory[T] {
+	m := &Memory[T]{items: make(map[uint]T), trashed: make(map[uint]T)}
+	for i := range items {
+		m.Create(context.Background(), &items[i])
+	}
+	return m
+}
+
+// Find 返回满足 match 的记录，按主键升序，供模块仓储的内存实现编写自定义查询
+func (m *Memory[T]) Find(match func(*T) bool) []T {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return find(m.items, match)
+}
+
+// List 实现 CRUD 接口
+func (m *Memory[T]) List(_ context.Context, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return list(m.items, params, conditions)
+}
+
+// ListTrashed 实现 Trashable 接口
+func (m *Memory[T]) ListTrashed(_ context.Context, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return list(m.trashed, params, conditions)
+}
+
+// Get 实现 CRUD 接口
+func (m *Memory[T]) Get(_ context.Context, id uint) (*T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entity, ok := m.items[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &entity, nil
+}
+
+// GetMany 实现 CRUD 接口
+func (m *Memory[T]) GetMany(_ context.Context, ids []uint) ([]T, error) {
+	wanted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return m.Find(func(entity *T) bool { return wanted[idOf(entity)] }), nil
+}
+
+// Create 实现 CRUD 接口
+func (m *Memory[T]) Create(_ context.Context, entity *T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := idOf(entity)
+	if id == 0 {
+		m.nextID++
+		id = m.nextID
+		reflect.ValueOf(entity).Elem().FieldByName("ID").SetUint(uint64(id))
+	} else if id > m.nextID {
+		m.nextID = id
+	}
+	now := time.Now()
+	setTime(entity, "CreatedAt", now)
+	setTime(entity, "UpdatedAt", now)
+	m.items[id] = *entity
+	return nil
+}
+
+// Save 实现 CRUD 接口
+func (m *Memory[T]) Save(_ context.Context, entity *T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	setTime(entity, "UpdatedAt", time.Now())
+	m.items[idOf(entity)] = *entity
+	return nil
+}
+
+// Update 实现 CRUD 接口，entity 已是修改后的值，直接保存
+func (m *Memory[T]) Update(ctx context.Context, entity *T, _ map[string]interface{}) error {
+	return m.Save(ctx, entity)
+}
+
+// Delete 实现 CRUD 接口
+func (m *Memory[T]) Delete(_ context.Context, id uint) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entity, ok := m.items[id]
+	if !ok {
+		return false, nil
+	}
+	setDeletedAt(&entity, gorm.DeletedAt{Time: time.Now(), Valid: true})
+	m.trashed[id] = entity
+	delete(m.items, id)
+	return true, nil
+}
+
+// GetTrashed 实现 Trashable 接口
+func (m *Memory[T]) GetTrashed(_ context.Context, id uint) (*T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entity, ok := m.trashed[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &entity, nil
+}
+
+// Restore 实现 Trashable 接口
+func (m *Memory[T]) Restore(_ context.Context, id uint) (*T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entity, ok := m.trashed[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	setDeletedAt(&entity, gorm.DeletedAt{})
+	setTime(&entity, "UpdatedAt", time.Now())
+	m.items[id] = entity
+	delete(m.trashed, id)
+	return &entity, nil
+}
+
+// find 返回 items 中满足 match 的记录，按主键升序
+func find[T any](items map[uint]T, match func(*T) bool) []T {
+	ids := make([]uint, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var result []T
+	for _, id := range ids {
+		entity := items[id]
+		if match == nil || match(&entity) {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+// list 按筛选条件、排序与分页参数查询 items，提供 cursor 时使用游标分页并忽略 page，游标格式与数据库实现相同
+func list[T any](items map[uint]T, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error) {
+	modelSchema, err := schema.Parse(new(T), schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		return nil, err
+	}
+	var matchErr error
+	matched := find(items, func(entity *T) bool {
+		ok, err := matches(modelSchema, reflect.ValueOf(entity).Elem(), conditions)
+		if err != nil {
+			matchErr = err
+		}
+		return ok
+	})
+	if matchErr != nil {
+		return nil, matchErr
+	}
+
+	order := params.Sort
+	if len(order) == 0 {
+		order = []pagination.SortField{{Column: "id"}}
+	}
+	fields := make([]*schema.Field, len(order))
+	for i, sortField := range order {
+		if fields[i] = modelSchema.LookUpField(sortField.Column); fields[i] == nil {
+			return nil, fmt.Errorf("unknown column %q", sortField.Column)
+		}
+	}
+	key := func(entity *T) []reflect.Value {
+		values := make([]reflect.Value, len(fields))
+		for i, field := range fields {
+			values[i] = reflect.ValueOf(entity).Elem().FieldByIndex(field.StructField.Index)
+		}
+		return values
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return compareKeys(key(&matched[i]), key(&matched[j]), order) < 0
+	})
+
+	start := (params.Page - 1) * params.PageSize
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor, fields)
+		if err != nil {
+			return nil, err
+		}
+		start = sort.Search(len(matched), func(i int) bool {
+			return compareKeys(key(&matched[i]), after, order) > 0
+		})
+	} else if start < 0 {
+		start = 0
+	}
+
+	page := []T{}
+	if start < len(matched) {
+		page = matched[start:min(start+params.PageSize, len(matched))]
+	}
+	result := &pagination.Page{Items: &page, Total: int64(len(matched)), Page: params.Page, PageSize: params.PageSize}
+	if params.Cursor != "" {
+		result.Page = 0
+	}
+	if len(page) == params.PageSize {
+		values := key(&page[len(page)-1])
+		encoded := make([]interface{}, len(values))
+		for i, value := range values {
+			encoded[i] = value.Interface()
+		}
+		raw, err := json.Marshal(encoded)
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = base64.RawURLEncoding.EncodeToString(raw)
+	}
+	return result, nil
+}
+
+// decodeCursor 解析游标中各排序字段的值
+func decodeCursor(cursor string, fields []*schema.Field) ([]reflect.Value, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errcode.InvalidCursor
+	}
+	var encoded []json.RawMessage
+	if err := json.Unmarshal(raw, &encoded); err != nil || len(encoded) != len(fields) {
+		return nil, errcode.InvalidCursor
+	}
+	values := make([]reflect.Value, len(fields))
+	for i, field := range fields {
+		value := reflect.New(field.StructField.Type)
+		if err := json.Unmarshal(encoded[i], value.Interface()); err != nil {
+			return nil, errcode.InvalidCursor
+		}
+		values[i] = value.Elem()
+	}
+	return values, nil
+}
+
+// compareKeys 按排序字段依次比较两条记录的排序键
+func compareKeys(a, b []reflect.Value, order []pagination.SortField) int {
+	for i, sortField := range order {
+		c := compareValues(a[i], b[i])
+		if sortField.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareValues 比较两个字段值，空值大于任何值，与 PostgreSQL 默认的空值排序一致
+func compareValues(a, b reflect.Value) int {
+	a, b = reflect.Indirect(a), reflect.Indirect(b)
+	switch {
+	case !a.IsValid() || !b.IsValid():
+		return cmp.Compare(boolRank(!a.IsValid()), boolRank(!b.IsValid()))
+	case a.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanFloat():
+		return cmp.Compare(a.Float(), b.Float())
+	case a.Kind() == reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case a.Kind() == reflect.Bool:
+		return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool()))
+	}
+	switch av := a.Interface().(type) {
+	case time.Time:
+		return av.Compare(b.Interface().(time.Time))
+	case gorm.DeletedAt:
+		bv := b.Interface().(gorm.DeletedAt)
+		if !av.Valid || !bv.Valid {
+			return cmp.Compare(boolRank(!av.Valid), boolRank(!bv.Valid))
+		}
+		return av.Time.Compare(bv.Time)
+	}
+	return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+// boolRank 将布尔值转换为可比较的整数，false 排在 true 之前
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// idOf 读取主键
+func idOf[T any](entity *T) uint {
+	return uint(reflect.ValueOf(entity).Elem().FieldByName("ID").Uint())
+}
+
+// setTime 设置时间戳字段，字段不存在时忽略
+func setTime[T any](entity *T, name string, t time.Time) {
+	field := reflect.ValueOf(entity).Elem().FieldByName(name)
+	if field.IsValid() && field.Type() == reflect.TypeOf(t) {
+		field.Set(reflect.ValueOf(t))
+	}
+}
+
+// setDeletedAt 设置 gorm.DeletedAt 类型的删除时间字段，字段不存在或类型不同时忽略
+func setDeletedAt[T any](entity *T, deletedAt gorm.DeletedAt) {
+	field := reflect.ValueOf(entity).Elem().FieldByName("DeletedAt")
+	if field.IsValid() && field.Type() == reflect.TypeOf(deletedAt) {
+		field.Set(reflect.ValueOf(deletedAt))
+	}
+}
+
+// matches 判断记录是否满足全部筛选条件
+func matches(modelSchema *schema.Schema, entity reflect.Value, conditions filter.Conditions) (bool, error) {
+	for _, cond := range conditions {
+		field := modelSchema.LookUpField(cond.Column)
+		if field == nil {
+			return false, fmt.Errorf("unknown column %q", cond.Column)
+		}
+		value := reflect.Indirect(entity.FieldByIndex(field.StructField.Index))
+
+		var ok bool
+		switch cond.Operator {
+		case "eq":
+			ok = value.IsValid() && equal(value, cond.Values[0])
+		case "ne":
+			ok = !value.IsValid() || !equal(value, cond.Values[0])
+		case "in", "nin":
+			for _, v := range cond.Values {
+				if value.IsValid() && equal(value, v) {
+					ok = true
+					break
+				}
+			}
+			ok = ok == (cond.Operator == "in")
+		case "null":
+			ok = value.IsValid() == !cond.Values[0].(bool)
+		default:
+			return false, fmt.Errorf("operator %q is not supported in memory", cond.Operator)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// equal 按字符串形式比较字段值与筛选值
+func equal(value reflect.Value, want interface{}) bool {
+	return fmt.Sprint(value.Interface()) == fmt.Sprint(want)
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+)
+
+// item 测试用模型
+type item struct {
+	ID        uint
+	Name      string
+	Price     float64
+	Note      *string
+	DeletedAt gorm.DeletedAt
+}
+
+func newItems() *Memory[item] {
+	note := "n"
+	return NewMemory(
+		item{Name: "b", Price: 20},
+		item{Name: "a", Price: 10, Note: &note},
+		item{Name: "c", Price: 20},
+		item{Name: "d", Price: 5, Note: &note},
+	)
+}
+
+// names 返回分页结果中各记录的名称
+func names(page *pagination.Page) []string {
+	result := []string{}
+	for _, entity := range *page.Items.(*[]item) {
+		result = append(result, entity.Name)
+	}
+	return result
+}
+
+func TestMemoryList(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     pagination.Params
+		conditions filter.Conditions
+		want       []string
+		total      int64
+	}{
+		{"默认按主键升序", pagination.Params{Page: 1, PageSize: 10}, nil, []string{"b", "a", "c", "d"}, 4},
+		{
+			"多字段排序",
+			pagination.Params{Page: 1, PageSize: 10, Sort: []pagination.SortField{{Column: "price", Desc: true}, {Column: "name"}}},
+			nil, []string{"b", "c", "a", "d"}, 4,
+		},
+		{
+			"空值排在升序末尾",
+			pagination.Params{Page: 1, PageSize: 10, Sort: []pagination.SortField{{Column: "note"}, {Column: "id"}}},
+			nil, []string{"a", "d", "b", "c"}, 4,
+		},
+		{
+			"页码分页",
+			pagination.Params{Page: 2, PageSize: 3, Sort: []pagination.SortField{{Column: "name"}}},
+			nil, []string{"d"}, 4,
+		},
+		{
+			"超出范围的页码",
+			pagination.Params{Page: 3, PageSize: 3},
+			nil, []string{}, 4,
+		},
+		{
+			"等值筛选",
+			pagination.Params{Page: 1, PageSize: 10},
+			filter.Conditions{{Column: "price", Operator: "eq", Values: []interface{}{20}}}, []string{"b", "c"}, 2,
+		},
+		{
+			"in 与 null 筛选",
+			pagination.Params{Page: 1, PageSize: 10},
+			filter.Conditions{
+				{Column: "name", Operator: "in", Values: []interface{}{"a", "b", "c"}},
+				{Column: "note", Operator: "null", Values: []interface{}{true}},
+			},
+			[]string{"b", "c"}, 2,
+		},
+	}
+	m := newItems()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := m.List(context.Background(), &tt.params, tt.conditions)
+			if err != nil {
+				t.Fatalf("List() 错误 = %v", err)
+			}
+			if got := names(page); !reflect.DeepEqual(got, tt.want) || page.Total != tt.total {
+				t.Errorf("List() = %v（共 %d 条）, 期望 %v（共 %d 条）", got, page.Total, tt.want, tt.total)
+			}
+		})
+	}
+}
+
+func TestMemoryListUnsupported(t *testing.T) {
+	m := newItems()
+	params := &pagination.Params{Page: 1, PageSize: 10}
+	if _, err := m.List(context.Background(), params, filter.Conditions{{Column: "name", Operator: "contains", Values: []interface{}{"a"}}}); err == nil {
+		t.Error("List() 使用不支持的操作符时期望返回错误")
+	}
+	params.Sort = []pagination.SortField{{Column: "missing"}}
+	if _, err := m.List(context.Background(), params, nil); err == nil {
+		t.Error("List() 按不存在的字段排序时期望返回错误")
+	}
+}
+
+func TestMemoryListCursor(t *testing.T) {
+	m := newItems()
+	params := &pagination.Params{Page: 1, PageSize: 2, Sort: []pagination.SortField{{Column: "price", Desc: true}, {Column: "id"}}}
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		page, err := m.List(context.Background(), params, nil)
+		if err != nil {
+			t.Fatalf("List() 错误 = %v", err)
+		}
+		got = append(got, names(page)...)
+		if page.NextCursor == "" {
+			break
+		}
+		params.Cursor = page.NextCursor
+		if i > 0 && page.Page != 0 {
+			t.Errorf("游标分页时 Page = %d, 期望 0", page.Page)
+		}
+	}
+	if want := []string{"b", "c", "a", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("按游标逐页查询 = %v, 期望 %v", got, want)
+	}
+
+	params.Cursor = "invalid"
+	if _, err := m.List(context.Background(), params, nil); !errors.Is(err, errcode.InvalidCursor) {
+		t.Errorf("List() 无效游标错误 = %v, 期望 %v", err, errcode.InvalidCursor)
+	}
+}
+
+func TestMemoryTrash(t *testing.T) {
+	ctx := context.Background()
+	m := newItems()
+
+	deleted, err := m.Delete(ctx, 1)
+	if err != nil || !deleted {
+		t.Fatalf("Delete() = (%v, %v), 期望 (true, nil)", deleted, err)
+	}
+	if deleted, _ := m.Delete(ctx, 1); deleted {
+		t.Error("重复删除时 Delete() 期望返回 false")
+	}
+	if _, err := m.Get(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() 已删除记录的错误 = %v, 期望 ErrNotFound", err)
+	}
+
+	trash, err := m.ListTrashed(ctx, &pagination.Params{Page: 1, PageSize: 10}, nil)
+	if err != nil {
+		t.Fatalf("ListTrashed() 错误 = %v", err)
+	}
+	items := *trash.Items.(*[]item)
+	if len(items) != 1 || items[0].ID != 1 || !items[0].DeletedAt.Valid {
+		t.Errorf("ListTrashed() = %+v, 期望只含已删除的记录 1", items)
+	}
+
+	if trashed, err := m.GetTrashed(ctx, 1); err != nil || trashed.ID != 1 {
+		t.Errorf("GetTrashed() = (%+v, %v), 期望已删除的记录 1", trashed, err)
+	}
+	if _, err := m.GetTrashed(ctx, 2); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetTrashed() 未删除记录的错误 = %v, 期望 ErrNotFound", err)
+	}
+
+	restored, err := m.Restore(ctx, 1)
+	if err != nil || restored.DeletedAt.Valid {
+		t.Fatalf("Restore() = (%+v, %v), 期望清除删除时间", restored, err)
+	}
+	if _, err := m.Restore(ctx, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Restore() 未删除记录的错误 = %v, 期望 ErrNotFound", err)
+	}
+	if _, err := m.Get(ctx, 1); err != nil {
+		t.Errorf("Get() 恢复后的记录错误 = %v", err)
+	}
+}
+
+func TestMemoryCreate(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(item{ID: 5, Name: "e"})
+	entity := &item{Name: "f"}
+	if err := m.Create(ctx, entity); err != nil {
+		t.Fatalf("Create() 错误 = %v", err)
+	}
+	if entity.ID != 6 {
+		t.Errorf("Create() 分配的主键 = %d, 期望 6", entity.ID)
+	}
+	many, _ := m.GetMany(ctx, []uint{6, 5, 7})
+	if len(many) != 2 || many[0].ID != 5 || many[1].ID != 6 {
+		t.Errorf("GetMany() = %+v, 期望主键 5、6", many)
+	}
+}
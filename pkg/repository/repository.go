@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+)
+
+// ErrNotFound 记录不存在，与 gorm.ErrRecordNotFound 相同，errcode.FromDB 可直接识别
+// 内存实现同样返回该错误，服务层与单元测试无需引用 GORM
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// CRUD 通用的增删改查数据访问接口，T 为模型类型
+// 方法通过 ctx 加入调用方开启的事务（见 database.Transactor），返回未经转换的错误，由服务层转换为错误码
+type CRUD[T any] interface {
+	// List 按筛选条件分页查询
+	List(ctx context.Context, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error)
+	// Get 按主键查询，不存在时返回 ErrNotFound
+	Get(ctx context.Context, id uint) (*T, error)
+	// GetMany 按主键批量查询，结果按主键升序，不存在的主键被忽略
+	GetMany(ctx context.Context, ids []uint) ([]T, error)
+	// Create 创建记录，成功后 entity 带有主键与时间戳
+	Create(ctx context.Context, entity *T) error
+	// Save 写入 entity 的全部字段
+	Save(ctx context.Context, entity *T) error
+	// Update 只写入 columns 中的列（键为列名），entity 为修改后的值
+	Update(ctx context.Context, entity *T, columns map[string]interface{}) error
+	// Delete 按主键删除，返回是否删除了记录
+	Delete(ctx context.Context, id uint) (bool, error)
+}
+
+// Trashable 支持回收站的数据访问接口，模型的 DeletedAt 需为 gorm.DeletedAt：
+// Delete 只标记删除时间，被删除的记录不再出现在其他查询中，可通过 Restore 恢复
+type Trashable[T any] interface {
+	CRUD[T]
+	// ListTrashed 按筛选条件分页查询已删除的记录
+	ListTrashed(ctx context.Context, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error)
+	// GetTrashed 按主键查询已删除的记录，记录不存在或未被删除时返回 ErrNotFound
+	GetTrashed(ctx context.Context, id uint) (*T, error)
+	// Restore 恢复已删除的记录并返回恢复后的值，记录不存在或未被删除时返回 ErrNotFound
+	Restore(ctx context.Context, id uint) (*T, error)
+}
+
+// Gorm 基于 GORM 的 CRUD 与 Trashable 实现，嵌入模块仓储中复用
+type Gorm[T any] struct {
+	db *gorm.DB
+}
+
+// NewGorm 创建基于 GORM 的 CRUD 实现
+func NewGorm[T any](db *gorm.DB) Gorm[T] {
+	return Gorm[T]{db: db}
+}
+
+// DB 返回绑定 ctx 的连接，供模块仓储编写自定义查询
+func (r Gorm[T]) DB(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx)
+}
+
+// List 实现 CRUD 接口
+func (r Gorm[T]) List(ctx context.Context, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error) {
+	var items []T
+	return params.Find(conditions.Apply(r.db.WithContext(ctx).Model(new(T))), &items)
+}
+
+// Get 实现 CRUD 接口
+func (r Gorm[T]) Get(ctx context.Context, id uint) (*T, error) {
+	var entity T
+	if err := r.db.WithContext(ctx).First(&entity, id).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// GetMany 实现 CRUD 接口
+func (r Gorm[T]) GetMany(ctx context.Context, ids []uint) ([]T, error) {
+	var items []T
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Order("id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Create 实现 CRUD 接口
+func (r Gorm[T]) Create(ctx context.Context, entity *T) error {
+	return r.db.WithContext(ctx).Create(entity).Error
+}
+
+// Save 实现 CRUD 接口
+func (r Gorm[T]) Save(ctx context.Context, entity *T) error {
+	return r.db.WithContext(ctx).Save(entity).Error
+}
+
+// Update 实现 CRUD 接口
+func (r Gorm[T]) Update(ctx context.Context, entity *T, columns map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(entity).Updates(columns).Error
+}
+
+// Delete 实现 CRUD 接口
+func (r Gorm[T]) Delete(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(new(T), id)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListTrashed 实现 Trashable 接口
+func (r Gorm[T]) ListTrashed(ctx context.Context, params *pagination.Params, conditions filter.Conditions) (*pagination.Page, error) {
+	var items []T
+	return params.Find(conditions.Apply(r.db.WithContext(ctx).Unscoped().Model(new(T)).Where("deleted_at IS NOT NULL")), &items)
+}
+
+// GetTrashed 实现 Trashable 接口
+func (r Gorm[T]) GetTrashed(ctx context.Context, id uint) (*T, error) {
+	var entity T
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&entity, id).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// Restore 实现 Trashable 接口
+func (r Gorm[T]) Restore(ctx context.Context, id uint) (*T, error) {
+	db := r.db.WithContext(ctx)
+	result := db.Unscoped().Model(new(T)).Where("id = ? AND deleted_at IS NOT NULL", id).Updates(map[string]interface{}{
+		"deleted_at": nil,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrNotFound
+	}
+	return r.Get(ctx, id)
+}
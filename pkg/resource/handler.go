@@ -0,0 +1,174 @@
+package resource
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/response"
+)
+
+// Handler 资源的通用 HTTP 处理器，模块处理器在带 Swagger 注释的方法中调用对应方法，
+// 使各资源的请求格式、响应与错误码保持一致
+type Handler[T events.Aggregate] struct {
+	resource *Resource[T]
+}
+
+// NewHandler 创建资源的 HTTP 处理器
+func NewHandler[T events.Aggregate](resource *Resource[T]) *Handler[T] {
+	return &Handler[T]{resource: resource}
+}
+
+// Create 创建记录
+func (h *Handler[T]) Create(c *gin.Context) {
+	var entity T
+	if err := c.ShouldBindJSON(&entity); err != nil {
+		response.BindError(c, err)
+		return
+	}
+
+	if err := h.resource.Create(c, &entity); err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
+}
+
+// List 分页查询
+func (h *Handler[T]) List(c *gin.Context) {
+	page, err := h.resource.List(c, c.Request.URL.Query())
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// Trash 分页查询回收站
+func (h *Handler[T]) Trash(c *gin.Context) {
+	page, err := h.resource.Trash(c, c.Request.URL.Query())
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, page)
+}
+
+// Get 查询单个记录
+func (h *Handler[T]) Get(c *gin.Context) {
+	id, ok := ID(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.resource.Get(c, id)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
+}
+
+// Update 全量更新
+func (h *Handler[T]) Update(c *gin.Context) {
+	id, ok := ID(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.resource.Update(c, id, func(entity *T) error {
+		if err := c.ShouldBindJSON(entity); err != nil {
+			return errcode.FromBinding(err)
+		}
+		return nil
+	})
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
+}
+
+// Patch 部分更新，支持 JSON Merge Patch 与 JSON Patch
+func (h *Handler[T]) Patch(c *gin.Context) {
+	id, ok := ID(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.resource.Patch(c, id, func(entity *T) (map[string]interface{}, error) {
+		return patch.Apply(c, entity, h.resource.config.PatchFields)
+	})
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
+}
+
+// Toggle 切换启用状态
+func (h *Handler[T]) Toggle(c *gin.Context) {
+	id, ok := ID(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.resource.Toggle(c, id)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
+}
+
+// Delete 移入回收站
+func (h *Handler[T]) Delete(c *gin.Context) {
+	id, ok := ID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.resource.Delete(c, id); err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": i18n.T(c, "message.deleted")})
+}
+
+// Restore 从回收站恢复
+func (h *Handler[T]) Restore(c *gin.Context) {
+	id, ok := ID(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.resource.Restore(c, id)
+	if err != nil {
+		response.FailWithError(c, err)
+		return
+	}
+
+	response.Success(c, entity)
+}
+
+// ID 解析路径参数 id，无效时输出 INVALID_ID 错误并返回 false
+func ID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		response.Fail(c, errcode.InvalidID)
+		return 0, false
+	}
+	return uint(id), true
+}
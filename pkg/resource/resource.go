@@ -0,0 +1,354 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/filter"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/patch"
+	"erp_backend/pkg/repository"
+)
+
+// Action 资源操作，传给授权钩子
+type Action string
+
+// 资源操作
+const (
+	ActionList    Action = "list"    // 查询列表
+	ActionGet     Action = "get"     // 查询单个
+	ActionCreate  Action = "create"  // 创建
+	ActionUpdate  Action = "update"  // 全量或部分更新
+	ActionToggle  Action = "toggle"  // 切换启用状态
+	ActionDelete  Action = "delete"  // 删除（移入回收站）
+	ActionTrash   Action = "trash"   // 查询回收站
+	ActionRestore Action = "restore" // 从回收站恢复
+)
+
+// Config 资源配置
+type Config[T events.Aggregate] struct {
+	Errors      errcode.Resource  // 各操作失败时的错误码
+	List        pagination.Config // 列表与回收站的排序配置
+	Filters     filter.Fields     // 列表与回收站的筛选字段
+	Legacy      []string          // 旧版等值筛选参数，如 supplier_id=1
+	PatchFields patch.Fields      // 允许通过 PATCH 修改的字段及校验规则，切换状态要求包含 is_enabled
+	Hooks       Hooks[T]          // 钩子
+}
+
+// Hooks 资源操作钩子，均可为空，返回的错误原样返回给调用方（应为 *errcode.Error）
+type Hooks[T events.Aggregate] struct {
+	// Authorize 在操作执行前调用；列表、回收站与创建时 entity 为 nil 或待创建的值，恢复时为回收站中的记录，其余操作为加载的实体
+	// 可通过 middleware.CallerFromContext 取得当前调用方，HTTP 请求与 gRPC 调用均已写入
+	Authorize func(ctx context.Context, action Action, entity *T) error
+	// Validate 在创建、更新与切换状态写入前调用，entity 为将要写入的值
+	Validate func(ctx context.Context, entity *T) error
+	// BeforeDelete 在删除前调用，返回错误时取消删除
+	BeforeDelete func(ctx context.Context, entity *T) error
+	// Changes 更新后发布的事件，为空时发布 events.Updated
+	Changes func(before, after *T) []events.Event
+}
+
+// Resource 通用的资源业务逻辑：增删改查、切换状态、回收站与恢复，
+// 写入与领域事件在同一事务中完成，返回的错误均为 *errcode.Error（钩子与回调返回的错误除外）
+// 模块服务嵌入 *Resource[T] 复用这些操作，并在其上添加模块特有的业务
+type Resource[T events.Aggregate] struct {
+	repo      repository.Trashable[T]
+	tx        database.Transactor
+	publisher events.Publisher
+	config    Config[T]
+}
+
+// New 创建资源
+func New[T events.Aggregate](repo repository.Trashable[T], tx database.Transactor, publisher events.Publisher, config Config[T]) *Resource[T] {
+	return &Resource[T]{repo: repo, tx: tx, publisher: publisher, config: config}
+}
+
+// Config 返回资源配置
+func (r *Resource[T]) Config() *Config[T] {
+	return &r.config
+}
+
+// Conditions 解析查询参数中的筛选条件，包括旧版等值筛选参数
+func (r *Resource[T]) Conditions(query url.Values) (filter.Conditions, error) {
+	conditions, err := filter.ParseValues(query, r.config.Filters)
+	if err != nil {
+		return nil, err
+	}
+	return append(filter.Legacy(query, r.config.Legacy...), conditions...), nil
+}
+
+// List 分页查询，query 与 REST 列表接口的查询参数一致
+func (r *Resource[T]) List(ctx context.Context, query url.Values) (*pagination.Page, error) {
+	return r.list(ctx, ActionList, query, r.repo.List)
+}
+
+// Trash 分页查询回收站中的记录，查询参数同 List
+func (r *Resource[T]) Trash(ctx context.Context, query url.Values) (*pagination.Page, error) {
+	return r.list(ctx, ActionTrash, query, r.repo.ListTrashed)
+}
+
+// list 授权并解析查询参数后调用 find 分页查询
+func (r *Resource[T]) list(ctx context.Context, action Action, query url.Values, find func(context.Context, *pagination.Params, filter.Conditions) (*pagination.Page, error)) (*pagination.Page, error) {
+	if err := r.authorize(ctx, action, nil); err != nil {
+		return nil, err
+	}
+
+	params, err := pagination.ParseValues(query, r.config.List)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, err := r.Conditions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := find(ctx, params, conditions)
+	if err != nil {
+		return nil, errcode.FromDB(err, r.config.Errors.ListFailed)
+	}
+	return page, nil
+}
+
+// Get 查询单个记录
+func (r *Resource[T]) Get(ctx context.Context, id uint) (*T, error) {
+	entity, err := r.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.authorize(ctx, ActionGet, entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// GetMany 按主键批量查询，结果按主键升序，不存在的主键被忽略
+func (r *Resource[T]) GetMany(ctx context.Context, ids []uint) ([]T, error) {
+	if err := r.authorize(ctx, ActionList, nil); err != nil {
+		return nil, err
+	}
+	items, err := r.repo.GetMany(ctx, ids)
+	if err != nil {
+		return nil, errcode.FromDB(err, r.config.Errors.ListFailed)
+	}
+	return items, nil
+}
+
+// Create 创建记录
+func (r *Resource[T]) Create(ctx context.Context, entity *T) error {
+	// 主键、时间戳与删除时间由数据库生成，忽略请求中的值，避免覆盖已有记录或直接创建到回收站中
+	var zero T
+	preserve(entity, &zero, "ID", "CreatedAt", "UpdatedAt", "DeletedAt")
+	if err := r.authorize(ctx, ActionCreate, entity); err != nil {
+		return err
+	}
+	if err := r.validate(ctx, entity); err != nil {
+		return err
+	}
+
+	err := r.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.repo.Create(ctx, entity); err != nil {
+			return err
+		}
+		return r.publisher.Publish(ctx, events.Created[T]{Entity: *entity})
+	})
+	if err != nil {
+		return errcode.FromDB(err, r.config.Errors.CreateFailed)
+	}
+	return nil
+}
+
+// Update 全量更新，bind 将新的值写入加载的记录；主键、创建时间与删除时间保持不变
+func (r *Resource[T]) Update(ctx context.Context, id uint, bind func(*T) error) (*T, error) {
+	entity, err := r.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.authorize(ctx, ActionUpdate, entity); err != nil {
+		return nil, err
+	}
+
+	before := *entity
+	if err := bind(entity); err != nil {
+		return nil, err
+	}
+	preserve(entity, &before, "ID", "CreatedAt", "DeletedAt")
+	if err := r.validate(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	err = r.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.repo.Save(ctx, entity); err != nil {
+			return err
+		}
+		return r.publisher.Publish(ctx, r.changes(&before, entity)...)
+	})
+	if err != nil {
+		return nil, errcode.FromDB(err, r.config.Errors.UpdateFailed)
+	}
+	return entity, nil
+}
+
+// Patch 部分更新，apply 将补丁应用到加载的记录并返回变更的列
+func (r *Resource[T]) Patch(ctx context.Context, id uint, apply func(*T) (map[string]interface{}, error)) (*T, error) {
+	return r.Change(ctx, id, ActionUpdate, r.config.Errors.UpdateFailed, r.changes, apply)
+}
+
+// Merge 部分更新，values 的键为 JSON 字段名，受 PATCH 接口相同的白名单与校验规则约束
+func (r *Resource[T]) Merge(ctx context.Context, id uint, values map[string]interface{}) (*T, error) {
+	return r.Patch(ctx, id, func(entity *T) (map[string]interface{}, error) {
+		return patch.Merge(ctx, entity, r.config.PatchFields, values)
+	})
+}
+
+// Toggle 切换启用状态
+func (r *Resource[T]) Toggle(ctx context.Context, id uint) (*T, error) {
+	toggled := func(_, after *T) []events.Event {
+		return []events.Event{events.Toggled[T]{Entity: *after}}
+	}
+	return r.Change(ctx, id, ActionToggle, r.config.Errors.ToggleFailed, toggled, func(entity *T) (map[string]interface{}, error) {
+		enabled := reflect.ValueOf(entity).Elem().FieldByName("IsEnabled").Bool()
+		return patch.Merge(ctx, entity, r.config.PatchFields, map[string]interface{}{"is_enabled": !enabled})
+	})
+}
+
+// Change 加载记录并应用补丁，只写入变更的列，并在同一事务中发布 changes 返回的事件，
+// 供模块实现只修改特定字段的操作（如更新库存）；没有变更时不写入也不发布事件
+func (r *Resource[T]) Change(ctx context.Context, id uint, action Action, failed errcode.Code, changes func(before, after *T) []events.Event, apply func(*T) (map[string]interface{}, error)) (*T, error) {
+	entity, err := r.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.authorize(ctx, action, entity); err != nil {
+		return nil, err
+	}
+
+	before := *entity
+	columns, err := apply(entity)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return entity, nil
+	}
+	if err := r.validate(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	err = r.tx.Transaction(ctx, func(ctx context.Context) error {
+		if err := r.repo.Update(ctx, entity, columns); err != nil {
+			return err
+		}
+		return r.publisher.Publish(ctx, changes(&before, entity)...)
+	})
+	if err != nil {
+		return nil, errcode.FromDB(err, failed)
+	}
+	return entity, nil
+}
+
+// Delete 将记录移入回收站，记录不存在时视为成功
+func (r *Resource[T]) Delete(ctx context.Context, id uint) error {
+	entity, err := r.repo.Get(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return errcode.FromDB(err, r.config.Errors.DeleteFailed)
+	}
+	if err := r.authorize(ctx, ActionDelete, entity); err != nil {
+		return err
+	}
+	if hook := r.config.Hooks.BeforeDelete; hook != nil {
+		if err := hook(ctx, entity); err != nil {
+			return err
+		}
+	}
+
+	err = r.tx.Transaction(ctx, func(ctx context.Context) error {
+		deleted, err := r.repo.Delete(ctx, id)
+		if err != nil || !deleted {
+			return err
+		}
+		return r.publisher.Publish(ctx, events.DeletedOf(*entity))
+	})
+	if err != nil {
+		return errcode.FromDB(err, r.config.Errors.DeleteFailed)
+	}
+	return nil
+}
+
+// Restore 从回收站恢复记录，记录不存在或未被删除时返回 NotFound
+func (r *Resource[T]) Restore(ctx context.Context, id uint) (*T, error) {
+	trashed, err := r.repo.GetTrashed(ctx, id)
+	if err != nil {
+		return nil, errcode.FromDB(err, r.config.Errors.NotFound)
+	}
+	if err := r.authorize(ctx, ActionRestore, trashed); err != nil {
+		return nil, err
+	}
+
+	var entity *T
+	err = r.tx.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if entity, err = r.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		return r.publisher.Publish(ctx, events.Restored[T]{Entity: *entity})
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, r.config.Errors.NotFound.New()
+	}
+	if err != nil {
+		return nil, errcode.FromDB(err, r.config.Errors.RestoreFailed)
+	}
+	return entity, nil
+}
+
+// load 按主键加载记录
+func (r *Resource[T]) load(ctx context.Context, id uint) (*T, error) {
+	entity, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return nil, errcode.FromDB(err, r.config.Errors.NotFound)
+	}
+	return entity, nil
+}
+
+// authorize 调用授权钩子
+func (r *Resource[T]) authorize(ctx context.Context, action Action, entity *T) error {
+	if hook := r.config.Hooks.Authorize; hook != nil {
+		return hook(ctx, action, entity)
+	}
+	return nil
+}
+
+// validate 调用校验钩子
+func (r *Resource[T]) validate(ctx context.Context, entity *T) error {
+	if hook := r.config.Hooks.Validate; hook != nil {
+		return hook(ctx, entity)
+	}
+	return nil
+}
+
+// changes 更新后发布的事件
+func (r *Resource[T]) changes(before, after *T) []events.Event {
+	if hook := r.config.Hooks.Changes; hook != nil {
+		return hook(before, after)
+	}
+	return []events.Event{events.Updated[T]{Entity: *after}}
+}
+
+// preserve 将 entity 中的 fields 字段恢复为 original 中的值，字段不存在时忽略
+func preserve[T any](entity, original *T, fields ...string) {
+	dst, src := reflect.ValueOf(entity).Elem(), reflect.ValueOf(original).Elem()
+	for _, name := range fields {
+		if field := dst.FieldByName(name); field.IsValid() {
+			field.Set(src.FieldByName(name))
+		}
+	}
+}
@@ -3,14 +3,21 @@ package response
 import (
 	"net/http"
 
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/tracing"
+
 	"github.com/gin-gonic/gin"
 )
 
 // Response 标准响应结构
 type Response struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Code      int                  `json:"code"`                 // 0 表示成功，否则为 HTTP 状态码
+	ErrorCode string               `json:"error_code,omitempty"` // 业务错误码，见 GET /error-codes
+	Message   string               `json:"message"`              // 提示信息
+	Details   []errcode.FieldError `json:"details,omitempty"`    // 字段级错误详情
+	Data      interface{}          `json:"data,omitempty"`       // 响应数据
+	TraceID   string               `json:"trace_id,omitempty"`   // 追踪ID，错误响应时返回，便于排查
 }
 
 // Success 成功响应
@@ -27,9 +34,52 @@ func Error(c *gin.Context, code int, message string) {
 	c.JSON(code, Response{
 		Code:    code,
 		Message: message,
+		TraceID: tracing.TraceID(c.Request.Context()),
 	})
 }
 
+// Fail 业务错误响应
+func Fail(c *gin.Context, code errcode.Code, details ...errcode.FieldError) {
+	FailWithError(c, code.WithDetails(details...))
+}
+
+// FailWithError 根据错误输出响应，非业务错误按服务器内部错误处理
+// 提示信息与字段详情按请求语言翻译
+func FailWithError(c *gin.Context, err error) {
+	resp := ErrorResponse(c, err)
+	c.JSON(resp.Code, resp)
+}
+
+// ErrorResponse 根据错误构造错误响应体，非业务错误按服务器内部错误处理
+func ErrorResponse(c *gin.Context, err error) Response {
+	e := errcode.FromDB(err, errcode.InternalError)
+	locale := i18n.Locale(c)
+
+	var details []errcode.FieldError
+	for _, detail := range e.Details {
+		detail.Message = errcode.RuleMessage(locale, detail.Rule, detail.Param)
+		details = append(details, detail)
+	}
+
+	return Response{
+		Code:      e.Code.Status,
+		ErrorCode: e.Code.Key,
+		Message:   i18n.Translate(locale, e.Code.Key, e.Code.Message, e.Args...),
+		Details:   details,
+		TraceID:   tracing.TraceID(c.Request.Context()),
+	}
+}
+
+// BindError 请求参数绑定失败响应，校验错误给出字段级详情
+func BindError(c *gin.Context, err error) {
+	FailWithError(c, errcode.FromBinding(err))
+}
+
+// DBError 数据库错误响应，识别记录不存在、唯一约束冲突等情况，其余使用 fallback
+func DBError(c *gin.Context, err error, fallback errcode.Code) {
+	FailWithError(c, errcode.FromDB(err, fallback))
+}
+
 // BadRequestResponse 400错误响应
 func BadRequestResponse(c *gin.Context, message string) {
 	Error(c, http.StatusBadRequest, message)
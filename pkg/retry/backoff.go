@@ -0,0 +1,17 @@
+package retry
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff 第 n 次失败后的等待时间：base * 2^(n-1)，不超过 limit，附加最多 10% 的随机抖动
+func Backoff(n int, base, limit time.Duration) time.Duration {
+	delay := limit
+	if n >= 1 && n-1 < 32 {
+		if exp := base << (n - 1); exp > 0 && exp < delay {
+			delay = exp
+		}
+	}
+	return delay + time.Duration(rand.Int64N(int64(delay)/10+1))
+}
@@ -0,0 +1,29 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want time.Duration // 不含抖动的等待时间
+	}{
+		{"第一次失败", 1, time.Second},
+		{"按指数增长", 4, 8 * time.Second},
+		{"不超过上限", 10, time.Minute},
+		{"移位溢出时取上限", 64, time.Minute},
+		{"非正数次数取上限", 0, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := Backoff(tt.n, time.Second, time.Minute); got < tt.want || got > tt.want+tt.want/10 {
+					t.Fatalf("Backoff(%d) = %v, 期望在 [%v, %v] 之间", tt.n, got, tt.want, tt.want+tt.want/10)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/middleware"
+)
+
+// 认证使用的元数据键
+const (
+	AuthorizationKey = "authorization" // Bearer JWT，与 REST 接口使用的令牌相同
+	APIKeyKey        = "x-api-key"     // 服务间调用的 API 密钥
+)
+
+// ServiceUserType 以 API 密钥认证的调用方的用户类型
+const ServiceUserType = "service"
+
+// publicServices 无需认证的服务，健康检查与服务反射
+var publicServices = []string{"/grpc.health.v1.", "/grpc.reflection."}
+
+// Principal 认证通过的调用方
+type Principal struct {
+	UserID     uint   // 用户ID，API 密钥调用时为 0
+	UserType   string // 用户类型，API 密钥调用时为 service
+	SupplierID uint   // 所属供应商ID，没有时为 0
+	Language   string // 用户语言偏好
+	APIKey     bool   // 是否以 API 密钥认证
+}
+
+// Authenticator 校验调用方携带的 JWT 或 API 密钥，并按方法的访问要求检查用户类型
+type Authenticator struct {
+	apiKeys [][]byte
+	methods map[string]middleware.Access
+}
+
+// NewAuthenticator 创建认证器，apiKeys 为允许的 API 密钥，为空时只接受 JWT；
+// methods 为方法全名（/{服务}/{方法}）到访问要求的映射，与 REST 接口使用相同的权限声明，
+// 以 JWT 调用未登记的方法时拒绝，API 密钥调用方为内部服务，不检查权限
+func NewAuthenticator(apiKeys []string, methods map[string]middleware.Access) *Authenticator {
+	a := &Authenticator{methods: methods}
+	for _, key := range apiKeys {
+		a.apiKeys = append(a.apiKeys, []byte(key))
+	}
+	return a
+}
+
+// Authenticate 从元数据中认证调用方，携带 x-api-key 时按 API 密钥校验，否则校验 authorization 中的 JWT
+func (a *Authenticator) Authenticate(md metadata.MD) (*Principal, error) {
+	if key := first(md, APIKeyKey); key != "" {
+		for _, allowed := range a.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(key), allowed) == 1 {
+				return &Principal{UserType: ServiceUserType, APIKey: true}, nil
+			}
+		}
+		return nil, errcode.APIKeyInvalid
+	}
+
+	claims, err := middleware.Authenticate(first(md, AuthorizationKey))
+	if err != nil {
+		return nil, err
+	}
+	return &Principal{UserID: claims.UserID, UserType: claims.UserType, SupplierID: claims.SupplierID, Language: claims.Language}, nil
+}
+
+// UnaryInterceptor 一元调用认证拦截器
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor 流式调用认证拦截器
+func (a *Authenticator) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize 认证调用方、检查方法的访问要求并写入 context，公开服务直接放行
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	principal, err := a.Authenticate(md)
+	if err != nil {
+		return nil, err
+	}
+	if call := callFromContext(ctx); call != nil {
+		call.principal = principal
+	}
+	if !principal.APIKey {
+		access, ok := a.methods[method]
+		if !ok || (len(access.UserTypes) > 0 && !slices.Contains(access.UserTypes, principal.UserType)) {
+			return nil, errcode.Forbidden
+		}
+	}
+	ctx = middleware.WithCaller(ctx, &middleware.Caller{UserID: principal.UserID, UserType: principal.UserType, SupplierID: principal.SupplierID})
+	ctx = i18n.WithLocale(ctx, Locale(ctx))
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+// principalKey Principal 在 context 中的键
+type principalKey struct{}
+
+// PrincipalFromContext 读取认证通过的调用方
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// first 元数据中 key 的第一个值
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/pagination"
+	"erp_backend/pkg/rpc/erpv1"
+)
+
+// Query 将列表参数转换为 REST 列表接口的查询参数，交给服务层按相同规则解析与校验
+func Query(opts *erpv1.ListOptions) url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+	if opts.Page != 0 {
+		query.Set("page", strconv.Itoa(int(opts.Page)))
+	}
+	if opts.PageSize != 0 {
+		query.Set("page_size", strconv.Itoa(int(opts.PageSize)))
+	}
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	Filter(query, opts.Filter)
+	return query
+}
+
+// Filter 将 字段[操作符] 形式的筛选条件写入查询参数 filter[字段][操作符]
+func Filter(query url.Values, filter map[string]string) {
+	for key, value := range filter {
+		field, operator, _ := strings.Cut(key, "[")
+		name := "filter[" + field + "]"
+		if operator != "" {
+			name += "[" + operator
+		}
+		query.Add(name, value)
+	}
+}
+
+// PageInfo 分页信息
+func PageInfo(page *pagination.Page) *erpv1.PageInfo {
+	return &erpv1.PageInfo{
+		Total:      page.Total,
+		Page:       int32(page.Page),
+		PageSize:   int32(page.PageSize),
+		NextCursor: page.NextCursor,
+	}
+}
+
+// ID 校验主键，0 或超出范围时返回 INVALID_ID
+func ID(id uint64) (uint, error) {
+	if id == 0 || id > math.MaxUint32 {
+		return 0, errcode.InvalidID.New()
+	}
+	return uint(id), nil
+}
+
+// Fields 按字段掩码从 values（键为字段名）中取出要修改的字段，掩码为空时返回全部字段，
+// 掩码包含 values 之外的字段时返回 FIELD_NOT_PATCHABLE
+func Fields(values map[string]interface{}, mask *fieldmaskpb.FieldMask) (map[string]interface{}, error) {
+	if len(mask.GetPaths()) == 0 {
+		return values, nil
+	}
+	selected := make(map[string]interface{}, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		value, ok := values[path]
+		if !ok {
+			return nil, errcode.FieldNotPatchable.New(path)
+		}
+		selected[path] = value
+	}
+	return selected, nil
+}
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/logger"
+)
+
+// ErrorDomain 错误详情 ErrorInfo 的 domain
+const ErrorDomain = "erp_backend"
+
+// codeByStatus 业务错误码的 HTTP 状态码与 gRPC 状态码的对应关系
+var codeByStatus = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusMethodNotAllowed:    codes.Unimplemented,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusFailedDependency:    codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// statusByCode gRPC 状态码对应的 HTTP 状态码，用于 JSON 网关
+var statusByCode = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// Status 将错误转换为 gRPC 状态：业务错误按请求语言翻译提示信息，
+// 并以 ErrorInfo（reason 为业务错误码）、BadRequest（字段级错误）与 RequestInfo 附带详情；
+// 已是 gRPC 状态的错误原样返回，其余错误按服务器内部错误处理
+func Status(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	e := errcode.FromDB(err, errcode.InternalError)
+	locale := Locale(ctx)
+
+	code, ok := codeByStatus[e.Code.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+	st := status.New(code, i18n.Translate(locale, e.Code.Key, e.Code.Message, e.Args...))
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: e.Code.Key, Domain: ErrorDomain},
+	}
+	if len(e.Details) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(e.Details))
+		for _, detail := range e.Details {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       detail.Field,
+				Description: errcode.RuleMessage(locale, detail.Rule, detail.Param),
+			})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if id := logger.RequestID(ctx); id != "" {
+		details = append(details, &errdetails.RequestInfo{RequestId: id})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// HTTPStatus gRPC 状态码对应的 HTTP 状态码
+func HTTPStatus(code codes.Code) int {
+	if s, ok := statusByCode[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/logger"
+)
+
+// 网关 JSON 编解码选项：字段名与 REST 接口一致使用下划线形式，零值字段同样输出
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// skippedHeaders 不转换为元数据的请求头
+var skippedHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"content-type":      true,
+	"host":              true,
+	"keep-alive":        true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+// gateway JSON 网关：POST /{包名.服务}/{方法}，请求体与响应为 protojson 格式的消息，
+// 与 gRPC 调用经过相同的拦截器（认证、日志、错误转换）；服务端流式方法以 NDJSON 逐条返回，
+// 出错时返回 google.rpc.Status 格式的错误
+type gateway struct {
+	server *Server
+}
+
+// ServeHTTP 实现 http.Handler 接口
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), gatewayKey{}, struct{}{})
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		if key := strings.ToLower(name); !skippedHeaders[key] {
+			md.Append(key, values...)
+		}
+	}
+	requestID := first(md, RequestIDKey)
+	if requestID == "" || len(requestID) > 128 {
+		requestID = newRequestID()
+		md.Set(RequestIDKey, requestID)
+	}
+	ctx = logger.WithRequestID(metadata.NewIncomingContext(ctx, md), requestID)
+	w.Header().Set(textproto.CanonicalMIMEHeaderKey(RequestIDKey), requestID)
+
+	svc, method, ok := g.server.lookup(r.URL.Path)
+	if !ok {
+		writeError(w, Status(ctx, errcode.GRPCMethodNotFound.New(r.URL.Path)))
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		// UNIMPLEMENTED 对应 501，此处按 HTTP 语义返回 405
+		writeMessage(w, http.StatusMethodNotAllowed, status.Convert(Status(ctx, errcode.GRPCGatewayPostOnly.New())).Proto())
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(g.server.cfg.MaxRecvMsgSize)))
+	if err != nil {
+		writeError(w, status.Error(codes.ResourceExhausted, err.Error()))
+		return
+	}
+	decode := func(m any) error {
+		if len(body) == 0 {
+			return nil
+		}
+		if err := unmarshalOptions.Unmarshal(body, m.(proto.Message)); err != nil {
+			return Status(ctx, errcode.InvalidParams.New())
+		}
+		return nil
+	}
+
+	fullMethod := "/" + svc.desc.ServiceName + "/" + method
+	for _, m := range svc.desc.Methods {
+		if m.MethodName == method {
+			resp, err := m.Handler(svc.impl, ctx, decode, g.server.unary)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeMessage(w, http.StatusOK, resp.(proto.Message))
+			return
+		}
+	}
+	for _, sd := range svc.desc.Streams {
+		if sd.StreamName == method && sd.ServerStreams && !sd.ClientStreams {
+			stream := &gatewayStream{ctx: ctx, w: w, decode: decode}
+			info := &grpc.StreamServerInfo{FullMethod: fullMethod, IsServerStream: true}
+			if err := g.server.stream(svc.impl, stream, info, sd.Handler); err != nil {
+				stream.fail(err)
+			}
+			return
+		}
+	}
+	// 客户端流与双向流无法映射为单个 HTTP 请求
+	writeError(w, Status(ctx, errcode.GRPCMethodNotFound.New(r.URL.Path)))
+}
+
+// writeMessage 以 JSON 输出消息
+func writeMessage(w http.ResponseWriter, code int, m proto.Message) {
+	data, err := marshalOptions.Marshal(m)
+	if err != nil {
+		code = http.StatusInternalServerError
+		data, _ = marshalOptions.Marshal(status.New(codes.Internal, err.Error()).Proto())
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+// writeError 以 google.rpc.Status 格式输出错误，HTTP 状态码由 gRPC 状态码决定
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeMessage(w, HTTPStatus(st.Code()), st.Proto())
+}
+
+// gatewayStream 服务端流式方法在网关中的 grpc.ServerStream 实现，
+// 请求消息来自请求体，每条响应消息以一行 JSON 写出并立即刷新
+type gatewayStream struct {
+	ctx      context.Context
+	w        http.ResponseWriter
+	decode   func(any) error
+	received bool
+	started  bool
+}
+
+// SetHeader 实现 grpc.ServerStream 接口，网关不转发响应元数据
+func (s *gatewayStream) SetHeader(metadata.MD) error { return nil }
+
+// SendHeader 实现 grpc.ServerStream 接口
+func (s *gatewayStream) SendHeader(metadata.MD) error { return nil }
+
+// SetTrailer 实现 grpc.ServerStream 接口
+func (s *gatewayStream) SetTrailer(metadata.MD) {}
+
+// Context 实现 grpc.ServerStream 接口
+func (s *gatewayStream) Context() context.Context { return s.ctx }
+
+// RecvMsg 实现 grpc.ServerStream 接口，只能读取一次请求消息
+func (s *gatewayStream) RecvMsg(m any) error {
+	if s.received {
+		return io.EOF
+	}
+	s.received = true
+	return s.decode(m)
+}
+
+// SendMsg 实现 grpc.ServerStream 接口
+func (s *gatewayStream) SendMsg(m any) error {
+	data, err := marshalOptions.Marshal(m.(proto.Message))
+	if err != nil {
+		return err
+	}
+	return s.writeLine(data)
+}
+
+// fail 输出错误：尚未开始输出时返回错误状态码，否则以 {"error": ...} 作为最后一行
+func (s *gatewayStream) fail(err error) {
+	if !s.started {
+		writeError(s.w, err)
+		return
+	}
+	data, _ := marshalOptions.Marshal(status.Convert(err).Proto())
+	s.writeLine(append(append([]byte(`{"error":`), data...), '}'))
+}
+
+// writeLine 写出一行并刷新
+func (s *gatewayStream) writeLine(data []byte) error {
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		s.w.WriteHeader(http.StatusOK)
+	}
+	if _, err := s.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/i18n"
+	"erp_backend/pkg/logger"
+)
+
+// 调用相关的元数据键
+const (
+	RequestIDKey      = "x-request-id"    // 请求ID，未携带时自动生成，并在响应头中返回
+	LangKey           = "lang"            // 提示信息语言，优先于用户偏好与 accept-language
+	AcceptLanguageKey = "accept-language" // 提示信息语言
+)
+
+// call 单次调用的信息，由日志拦截器创建，认证拦截器补充调用方
+type call struct {
+	method    string
+	protocol  string // grpc 或 gateway
+	principal *Principal
+}
+
+// callKey call 在 context 中的键
+type callKey struct{}
+
+// callFromContext 读取当前调用的信息
+func callFromContext(ctx context.Context) *call {
+	c, _ := ctx.Value(callKey{}).(*call)
+	return c
+}
+
+// gatewayKey 标记经由 JSON 网关发起的调用
+type gatewayKey struct{}
+
+// Locale 解析调用使用的语言
+// 优先级：lang 元数据 > 用户偏好 > accept-language 元数据 > 默认语言
+func Locale(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if lang := first(md, LangKey); lang != "" {
+		return i18n.Match(lang)
+	}
+	if c := callFromContext(ctx); c != nil && c.principal != nil && c.principal.Language != "" {
+		return i18n.Match(c.principal.Language)
+	}
+	if header := first(md, AcceptLanguageKey); header != "" {
+		if tags, _, err := language.ParseAcceptLanguage(header); err == nil && len(tags) > 0 {
+			names := make([]string, len(tags))
+			for i, tag := range tags {
+				names[i] = tag.String()
+			}
+			return i18n.Match(names...)
+		}
+	}
+	return i18n.Default
+}
+
+// LoggingUnaryInterceptor 为一元调用分配请求ID、记录访问日志，并将返回的错误转换为 gRPC 状态
+func LoggingUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	ctx, finish := begin(ctx, info.FullMethod)
+	resp, err = handler(ctx, req)
+	return resp, finish(err)
+}
+
+// LoggingStreamInterceptor 为流式调用分配请求ID、记录访问日志，并将返回的错误转换为 gRPC 状态
+func LoggingStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, finish := begin(ss.Context(), info.FullMethod)
+	return finish(handler(srv, &serverStream{ServerStream: ss, ctx: ctx}))
+}
+
+// begin 开始一次调用，返回的 finish 在调用结束时转换错误并记录日志
+func begin(ctx context.Context, method string) (context.Context, func(error) error) {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := first(md, RequestIDKey)
+	if requestID == "" || len(requestID) > 128 {
+		requestID = newRequestID()
+	}
+
+	c := &call{method: method, protocol: "grpc"}
+	if ctx.Value(gatewayKey{}) != nil {
+		c.protocol = "gateway"
+	}
+	ctx = logger.WithRequestID(context.WithValue(ctx, callKey{}, c), requestID)
+	// 经由网关的调用没有 gRPC 传输流，请求ID由网关写入响应头
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+
+	return ctx, func(err error) error {
+		err = Status(ctx, err)
+		code := status.Code(err)
+
+		attrs := []any{
+			slog.String(logger.RequestIDKey, requestID),
+			slog.String("protocol", c.protocol),
+			slog.String("method", method),
+			slog.String("code", code.String()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			attrs = append(attrs, slog.String("client_ip", p.Addr.String()))
+		}
+		if c.principal != nil {
+			if c.principal.APIKey {
+				attrs = append(attrs, slog.String("user_type", c.principal.UserType))
+			} else {
+				attrs = append(attrs, slog.Any("user_id", c.principal.UserID))
+			}
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+		}
+
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK, codes.Canceled:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+		slog.Log(ctx, level, "gRPC 请求", attrs...)
+		return err
+	}
+}
+
+// RecoveryUnaryInterceptor 将一元调用中的 panic 转换为服务器内部错误
+func RecoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ctx, info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor 将流式调用中的 panic 转换为服务器内部错误
+func RecoveryStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recovered 记录 panic 与调用栈
+func recovered(ctx context.Context, method string, r any) error {
+	logger.FromContext(ctx).Error("gRPC 调用 panic",
+		slog.String("method", method),
+		slog.String("panic", fmt.Sprint(r)),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return errcode.InternalError.New()
+}
+
+// chainUnary 将多个一元拦截器合并为一个，按顺序由外到内执行，供 gRPC 服务与 JSON 网关共用
+func chainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// chainStream 将多个流式拦截器合并为一个，按顺序由外到内执行
+func chainStream(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return next(srv, ss)
+	}
+}
+
+// serverStream 替换 context 的 ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context 实现 grpc.ServerStream 接口
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// newRequestID 生成随机请求ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+)
+
+// http2Preface HTTP/2 连接前言的开头，gRPC 客户端建立连接后首先发送
+var http2Preface = []byte("PRI")
+
+// prefaceTimeout 等待客户端发送首个字节的时间
+const prefaceTimeout = 10 * time.Second
+
+// splitListener 按连接的首个字节区分协议：以 HTTP/2 连接前言开头的交给 gRPC 服务，其余交给 HTTP/1.1 JSON 网关，
+// 两者各自管理连接并优雅关闭
+type splitListener struct {
+	net.Listener
+	grpc *childListener
+	http *childListener
+}
+
+// newSplitListener 包装监听器，需调用 serve 开始分发连接
+func newSplitListener(l net.Listener) *splitListener {
+	return &splitListener{
+		Listener: l,
+		grpc:     newChildListener(l.Addr()),
+		http:     newChildListener(l.Addr()),
+	}
+}
+
+// serve 接收连接并按协议分发，监听器关闭后返回并关闭两个子监听器
+func (s *splitListener) serve() {
+	defer s.grpc.Close()
+	defer s.http.Close()
+	for {
+		conn, err := s.Accept()
+		if err != nil {
+			return
+		}
+		go s.dispatch(conn)
+	}
+}
+
+// dispatch 读取首个字节判断协议，读取的内容在交给子监听器后仍可读到
+func (s *splitListener) dispatch(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(prefaceTimeout))
+	head, err := reader.Peek(len(http2Preface))
+	conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		slog.Debug("gRPC 连接未发送数据即关闭", slog.String("client_ip", conn.RemoteAddr().String()), slog.Any("error", err))
+		conn.Close()
+		return
+	}
+
+	target := s.http
+	if bytes.Equal(head, http2Preface) {
+		target = s.grpc
+	}
+	target.push(&peekedConn{Conn: conn, reader: reader})
+}
+
+// childListener 接收 splitListener 分发的连接
+type childListener struct {
+	addr  net.Addr
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newChildListener(addr net.Addr) *childListener {
+	return &childListener{addr: addr, conns: make(chan net.Conn), done: make(chan struct{})}
+}
+
+// push 交付连接，子监听器已关闭时关闭连接
+func (l *childListener) push(conn net.Conn) {
+	select {
+	case l.conns <- conn:
+	case <-l.done:
+		conn.Close()
+	}
+}
+
+// Accept 实现 net.Listener 接口
+func (l *childListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close 实现 net.Listener 接口
+func (l *childListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+// Addr 实现 net.Listener 接口
+func (l *childListener) Addr() net.Addr {
+	return l.addr
+}
+
+// peekedConn 先读出已预读的内容再读连接
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// Read 实现 net.Conn 接口
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"erp_backend/pkg/config"
+)
+
+// Server gRPC 服务，开启网关时在同一端口同时提供 JSON 网关
+// 实现 grpc.ServiceRegistrar，注册的服务同时供 gRPC 与网关调用
+type Server struct {
+	cfg      *config.GRPCConfig
+	grpc     *grpc.Server
+	health   *health.Server
+	services map[string]*service
+	unary    grpc.UnaryServerInterceptor
+	stream   grpc.StreamServerInterceptor
+}
+
+// service 已注册的服务
+type service struct {
+	desc *grpc.ServiceDesc
+	impl any
+}
+
+// New 创建 gRPC 服务，拦截器依次为访问日志与错误转换、panic 恢复、认证
+func New(cfg *config.GRPCConfig, auth *Authenticator) *Server {
+	s := &Server{
+		cfg:      cfg,
+		health:   health.NewServer(),
+		services: make(map[string]*service),
+		unary:    chainUnary(LoggingUnaryInterceptor, RecoveryUnaryInterceptor, auth.UnaryInterceptor),
+		stream:   chainStream(LoggingStreamInterceptor, RecoveryStreamInterceptor, auth.StreamInterceptor),
+	}
+	s.grpc = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unary),
+		grpc.StreamInterceptor(s.stream),
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+	)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	if cfg.Reflection {
+		reflection.Register(s.grpc)
+	}
+	return s
+}
+
+// RegisterService 实现 grpc.ServiceRegistrar 接口
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	s.grpc.RegisterService(desc, impl)
+	s.services[desc.ServiceName] = &service{desc: desc, impl: impl}
+	s.health.SetServingStatus(desc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+// lookup 按 /{服务}/{方法} 路径查找服务
+func (s *Server) lookup(path string) (*service, string, bool) {
+	name, method, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok || method == "" || strings.Contains(method, "/") {
+		return nil, "", false
+	}
+	svc, ok := s.services[name]
+	return svc, method, ok
+}
+
+// Run 监听并提供服务，直到 ctx 取消后优雅关闭：停止接收新连接，
+// 在 ShutdownTimeout 内等待进行中的调用（含流式导出）完成，超时后强制关闭
+func (s *Server) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	slog.Info("gRPC 服务启动", slog.String("addr", lis.Addr().String()), slog.Bool("gateway", s.cfg.Gateway))
+	return s.Serve(ctx, lis)
+}
+
+// Serve 在 lis 上提供服务，关闭流程同 Run
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	errCh := make(chan error, 2)
+	var gatewayServer *http.Server
+
+	if s.cfg.Gateway {
+		split := newSplitListener(lis)
+		gatewayServer = &http.Server{
+			Handler:           &gateway{server: s},
+			ReadHeaderTimeout: 10 * time.Second,
+			ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+		}
+		go split.serve()
+		go func() {
+			if err := gatewayServer.Serve(split.http); !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
+				errCh <- err
+			}
+		}()
+		go func() {
+			if err := s.grpc.Serve(split.grpc); err != nil {
+				errCh <- err
+			}
+		}()
+	} else {
+		go func() {
+			if err := s.grpc.Serve(lis); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		lis.Close()
+		s.grpc.Stop()
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("gRPC 服务停止接收新连接，等待进行中的调用完成", slog.Duration("timeout", s.cfg.ShutdownTimeout))
+	s.health.Shutdown()
+	lis.Close()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	if gatewayServer != nil {
+		if err := gatewayServer.Shutdown(shutdownCtx); err != nil {
+			gatewayServer.Close()
+		}
+	}
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		slog.Error("等待 gRPC 调用完成超时，强制关闭")
+		s.grpc.Stop()
+	}
+	return nil
+}
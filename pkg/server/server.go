@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"erp_backend/pkg/config"
+)
+
+// ready 服务是否就绪，收到关闭信号后置为 false
+var ready atomic.Bool
+
+// Ready 服务是否就绪，供就绪检查使用
+func Ready() bool {
+	return ready.Load()
+}
+
+// Server 带超时配置、优雅关闭与后台任务管理的 HTTP 服务
+type Server struct {
+	cfg     *config.ServerConfig
+	http    *http.Server
+	workers sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// New 创建 HTTP 服务
+func New(cfg *config.ServerConfig, handler http.Handler) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{
+		cfg: cfg,
+		http: &http.Server{
+			Addr:              ":" + cfg.Port,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+			ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go 启动后台任务，ctx 在服务关闭、进行中的请求处理完后取消
+// 任务应在 ctx 取消后尽快返回，关闭流程会等待所有任务退出
+func (s *Server) Go(name string, fn func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		fn(s.ctx)
+		slog.Info("后台任务已停止", slog.String("worker", name))
+	}()
+}
+
+// OnShutdown 注册在开始优雅关闭时调用的函数，用于通知 SSE 等长连接尽快结束，避免关闭时一直等待
+func (s *Server) OnShutdown(fn func()) {
+	s.http.RegisterOnShutdown(fn)
+}
+
+// Run 启动服务并阻塞，直到 ctx 取消（通常由 SIGINT/SIGTERM 触发）后完成优雅关闭：
+// 先标记未就绪并等待 ReadinessDelay，再停止接收新连接并在 ShutdownTimeout 内等待进行中的请求，
+// 最后停止后台任务，并在 WorkerShutdownTimeout 内等待其退出，不占用等待请求的时间
+func (s *Server) Run(ctx context.Context) error {
+	if s.cfg.TLSEnabled() {
+		reloader, err := newCertReloader(s.cfg.TLSCertFile, s.cfg.TLSKeyFile)
+		if err != nil {
+			return err
+		}
+		s.http.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+		s.Go("tls-reloader", func(ctx context.Context) {
+			reloader.watch(ctx, s.cfg.TLSReloadInterval)
+		})
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if s.cfg.TLSEnabled() {
+			err = s.http.ListenAndServeTLS("", "")
+		} else {
+			err = s.http.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	ready.Store(true)
+	slog.Info("服务器启动", slog.String("addr", s.http.Addr), slog.Bool("tls", s.cfg.TLSEnabled()))
+
+	select {
+	case err, ok := <-errCh:
+		s.stopWorkers()
+		if ok {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	ready.Store(false)
+	slog.Info("收到关闭信号，停止就绪", slog.Duration("readiness_delay", s.cfg.ReadinessDelay))
+	time.Sleep(s.cfg.ReadinessDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	slog.Info("停止接收新连接，等待进行中的请求完成", slog.Duration("timeout", s.cfg.ShutdownTimeout))
+	err := s.http.Shutdown(shutdownCtx)
+	if err != nil {
+		slog.Error("等待请求完成超时，强制关闭", slog.Any("error", err))
+		s.http.Close()
+	}
+
+	s.stopWorkers()
+	return err
+}
+
+// stopWorkers 取消后台任务并等待其退出，超过 WorkerShutdownTimeout 时不再等待
+// 返回后 main 随即关闭数据库连接，因此等待期限单独计时，不受 HTTP 关闭耗时影响
+func (s *Server) stopWorkers() {
+	s.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.WorkerShutdownTimeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("等待后台任务退出超时", slog.Duration("timeout", s.cfg.WorkerShutdownTimeout))
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader 在证书文件变更后重新加载证书，无需重启服务即可更换证书
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// newCertReloader 加载证书并创建 certReloader
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate 用于 tls.Config.GetCertificate
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// reload 重新读取证书与私钥
+func (r *certReloader) reload() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mu.Unlock()
+	return nil
+}
+
+// latestModTime 返回证书与私钥文件中较新的修改时间
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// watch 定期检查证书文件，变更时重新加载，加载失败时继续使用旧证书
+func (r *certReloader) watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			modTime, err := r.latestModTime()
+			if err != nil {
+				slog.Error("检查证书文件失败", slog.Any("error", err))
+				continue
+			}
+			r.mu.RLock()
+			changed := modTime.After(r.modTime)
+			r.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				slog.Error("重新加载证书失败，继续使用旧证书", slog.Any("error", err))
+				continue
+			}
+			slog.Info("证书已重新加载", slog.String("cert_file", r.certFile))
+		}
+	}
+}
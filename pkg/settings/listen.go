@@ -0,0 +1,20 @@
+package settings
+
+import (
+	"context"
+
+	"erp_backend/pkg/database"
+)
+
+// Listen 占用一个数据库连接监听其他实例修改设置的通知，收到后使缓存失效；
+// 连接断开后自动重连，直到 ctx 取消
+func (s *Store) Listen(ctx context.Context) {
+	listener := &database.Listener{
+		DB:      s.db,
+		Channel: channel,
+		// 断线期间的通知已丢失，每次开始监听时也使缓存失效
+		OnListen: func(context.Context) { s.Invalidate() },
+		OnNotify: func(context.Context, string) { s.Invalidate() },
+	}
+	listener.Run(ctx)
+}
@@ -0,0 +1,173 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"erp_backend/pkg/errcode"
+)
+
+// Schema 设置值的 JSON Schema，支持常用的校验关键字
+// 另支持 format: regex，要求字符串是有效的正则表达式
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`                 // 类型：string、integer、number、boolean、object、array，为空表示不限
+	Description          string             `json:"description,omitempty"`          // 说明
+	Enum                 []interface{}      `json:"enum,omitempty"`                 // 允许的取值
+	Minimum              *float64           `json:"minimum,omitempty"`              // 数值下限（含）
+	Maximum              *float64           `json:"maximum,omitempty"`              // 数值上限（含）
+	MinLength            *int               `json:"minLength,omitempty"`            // 字符串最小长度
+	MaxLength            *int               `json:"maxLength,omitempty"`            // 字符串最大长度
+	Pattern              string             `json:"pattern,omitempty"`              // 字符串需匹配的正则表达式
+	Format               string             `json:"format,omitempty"`               // 字符串格式
+	Items                *Schema            `json:"items,omitempty"`                // 数组元素的 Schema
+	MinItems             *int               `json:"minItems,omitempty"`             // 数组最少元素数
+	MaxItems             *int               `json:"maxItems,omitempty"`             // 数组最多元素数
+	Properties           map[string]*Schema `json:"properties,omitempty"`           // 对象字段的 Schema
+	Required             []string           `json:"required,omitempty"`             // 对象必填字段
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"` // 是否允许 properties 之外的字段，为空表示允许
+}
+
+// Float 返回 v 的指针，用于 Minimum、Maximum
+func Float(v float64) *float64 { return &v }
+
+// Int 返回 v 的指针，用于长度与元素数限制
+func Int(v int) *int { return &v }
+
+// Bool 返回 v 的指针，用于 AdditionalProperties
+func Bool(v bool) *bool { return &v }
+
+// Validate 校验 JSON 解码后的值，返回全部不符合的字段，字段路径以 field 开头
+func (s *Schema) Validate(field string, value interface{}) []errcode.FieldError {
+	var errs []errcode.FieldError
+	s.validate(field, value, &errs)
+	return errs
+}
+
+// validate 递归校验
+func (s *Schema) validate(field string, value interface{}, errs *[]errcode.FieldError) {
+	if s == nil {
+		return
+	}
+	fail := func(rule, param string) {
+		*errs = append(*errs, errcode.FieldError{Field: field, Rule: rule, Param: param})
+	}
+
+	if s.Type != "" && !matchType(s.Type, value) {
+		fail("type", s.Type)
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		fail("oneof", enumText(s.Enum))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("gte", strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("lte", strconv.FormatFloat(*s.Maximum, 'f', -1, 64))
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("min", strconv.Itoa(*s.MinLength))
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("max", strconv.Itoa(*s.MaxLength))
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				fail("pattern", s.Pattern)
+			}
+		}
+		if s.Format == "regex" {
+			if _, err := regexp.Compile(v); err != nil {
+				fail("regex", "")
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("min", strconv.Itoa(*s.MinItems))
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("max", strconv.Itoa(*s.MaxItems))
+		}
+		for i, item := range v {
+			s.Items.validate(fmt.Sprintf("%s[%d]", field, i), item, errs)
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, errcode.FieldError{Field: field + "." + name, Rule: "required"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					*errs = append(*errs, errcode.FieldError{Field: field + "." + name, Rule: "not_allowed"})
+				}
+				continue
+			}
+			property.validate(field+"."+name, v[name], errs)
+		}
+	}
+}
+
+// matchType 判断 JSON 值是否为指定类型，integer 要求没有小数部分
+func matchType(typ string, value interface{}) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		v, ok := value.(float64)
+		return ok && v == float64(int64(v))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return false
+}
+
+// inEnum 按 JSON 编码比较值是否在允许的取值中，使 Go 中声明的 int 与解码得到的 float64 可比较
+func inEnum(enum []interface{}, value interface{}) bool {
+	encoded, _ := json.Marshal(value)
+	for _, allowed := range enum {
+		if b, _ := json.Marshal(allowed); string(b) == string(encoded) {
+			return true
+		}
+	}
+	return false
+}
+
+// enumText 允许的取值，以空格分隔，用于错误提示
+func enumText(enum []interface{}) string {
+	text := ""
+	for i, allowed := range enum {
+		if i > 0 {
+			text += " "
+		}
+		text += fmt.Sprint(allowed)
+	}
+	return text
+}
@@ -0,0 +1,425 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/errcode"
+)
+
+// 修改记录的操作类型
+const (
+	ActionUpdate = "update" // 修改设置值
+	ActionReset  = "reset"  // 恢复默认值
+)
+
+// Definition 模块声明的设置项，值与默认值都是 JSON
+type Definition struct {
+	Key         string      // 设置键，格式为 命名空间.名称，命名空间一般为模块名，如 product.low_stock_threshold
+	Description string      // 说明
+	Schema      *Schema     // 值的 JSON Schema，为空表示不校验
+	Default     interface{} // 默认值，需符合 Schema
+}
+
+// Namespace 设置键的命名空间
+func (d Definition) Namespace() string {
+	namespace, _, _ := strings.Cut(d.Key, ".")
+	return namespace
+}
+
+// Setting 管理员修改过的设置值，未修改过的设置项使用声明的默认值
+type Setting struct {
+	Key       string          `gorm:"type:varchar(100);primarykey" json:"key"`                           // 设置键
+	Value     json.RawMessage `gorm:"type:jsonb;not null;comment:设置值" json:"value" swaggertype:"object"` // 设置值
+	UpdatedAt time.Time       `json:"updated_at"`                                                        // 修改时间
+	UpdatedBy *uint           `gorm:"comment:修改人" json:"updated_by"`                                     // 修改人ID
+}
+
+// TableName 表名
+func (Setting) TableName() string {
+	return "settings"
+}
+
+// Change 设置的修改记录
+// @Description 系统设置的修改记录
+type Change struct {
+	ID        uint            `gorm:"primarykey" json:"id"`                                                    // 主键ID
+	CreatedAt time.Time       `gorm:"index" json:"created_at"`                                                 // 修改时间
+	Key       string          `gorm:"type:varchar(100);not null;index;comment:设置键" json:"key"`                 // 设置键
+	Action    string          `gorm:"type:varchar(20);not null;comment:操作类型" json:"action"`                    // 操作类型：update 修改、reset 恢复默认值
+	OldValue  json.RawMessage `gorm:"type:jsonb;not null;comment:修改前的值" json:"old_value" swaggertype:"object"` // 修改前的值
+	NewValue  json.RawMessage `gorm:"type:jsonb;not null;comment:修改后的值" json:"new_value" swaggertype:"object"` // 修改后的值
+	UserID    *uint           `gorm:"index;comment:修改人" json:"user_id"`                                        // 修改人ID
+}
+
+// TableName 表名
+func (Change) TableName() string {
+	return "setting_changes"
+}
+
+// Item 设置项及其当前值
+// @Description 系统设置项
+type Item struct {
+	Key         string          `json:"key" example:"product.low_stock_threshold"` // 设置键
+	Namespace   string          `json:"namespace" example:"product"`               // 命名空间
+	Description string          `json:"description" example:"低库存阈值"`               // 说明
+	Schema      *Schema         `json:"schema"`                                    // 值的 JSON Schema
+	Default     json.RawMessage `json:"default" swaggertype:"object"`              // 默认值
+	Value       json.RawMessage `json:"value" swaggertype:"object"`                // 当前值
+	IsDefault   bool            `json:"is_default"`                                // 是否使用默认值（未修改过或已恢复默认值）
+	UpdatedAt   *time.Time      `json:"updated_at"`                                // 最近修改时间
+	UpdatedBy   *uint           `json:"updated_by"`                                // 最近修改人ID
+}
+
+// channel 通知各实例设置已修改的 PostgreSQL 频道
+const channel = "erp_settings"
+
+// retryInterval 加载失败后的重试间隔，期间沿用旧值或默认值，避免每次读取都查询数据库
+const retryInterval = 5 * time.Second
+
+// definition 已声明的设置项及编码后的默认值
+type definition struct {
+	Definition
+	def json.RawMessage
+}
+
+// snapshot 缓存的设置值
+type snapshot struct {
+	rows      map[string]Setting
+	expiresAt time.Time // 加载成功时为 SETTINGS_CACHE_TTL 之后，加载失败时为 retryInterval 之后
+}
+
+// Store 设置存储，读取走内存缓存，修改后通过 NOTIFY 让各实例的缓存失效
+// 缓存最长保留 SETTINGS_CACHE_TTL，通知丢失时（如监听连接断开）最迟在此之后读到新值
+type Store struct {
+	db  *gorm.DB
+	ttl time.Duration
+
+	defs map[string]*definition
+
+	mu         sync.RWMutex
+	cache      *snapshot
+	generation uint64     // 每次失效加一，避免失效前发起的加载覆盖缓存
+	loadMu     sync.Mutex // 同一时间只有一个加载
+}
+
+// NewStore 创建设置存储
+func NewStore(db *gorm.DB, cfg *config.SettingsConfig) *Store {
+	return &Store{db: db, ttl: cfg.CacheTTL, defs: make(map[string]*definition)}
+}
+
+// store 全局设置存储，供 Get 读取
+var store *Store
+
+// SetStore 设置全局设置存储
+func SetStore(s *Store) {
+	store = s
+}
+
+// Get 读取设置的当前值并解析为 T；设置项未声明（如所属模块未启用）或未设置全局存储时返回零值
+func Get[T any](ctx context.Context, key string) T {
+	var v T
+	s := store
+	if s == nil {
+		return v
+	}
+	raw, err := s.Value(ctx, key)
+	if err != nil {
+		return v
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		slog.ErrorContext(ctx, "解析系统设置失败", slog.String("key", key), slog.Any("error", err))
+	}
+	return v
+}
+
+// Declare 声明设置项，需在处理请求前调用；设置键重复、格式错误或默认值不符合 Schema 时 panic
+func (s *Store) Declare(defs ...Definition) {
+	for _, d := range defs {
+		namespace, name, ok := strings.Cut(d.Key, ".")
+		if !ok || namespace == "" || name == "" {
+			panic(fmt.Sprintf("settings: 设置键 %q 需为 命名空间.名称 格式", d.Key))
+		}
+		if _, exists := s.defs[d.Key]; exists {
+			panic("settings: 重复的设置键 " + d.Key)
+		}
+		def, err := normalize(d.Default)
+		if err != nil {
+			panic(fmt.Sprintf("settings: 设置 %s 的默认值无法编码: %v", d.Key, err))
+		}
+		var value interface{}
+		_ = json.Unmarshal(def, &value)
+		if errs := d.Schema.Validate("default", value); len(errs) > 0 {
+			panic(fmt.Sprintf("settings: 设置 %s 的默认值不符合 Schema: %s %s", d.Key, errs[0].Field, errs[0].Rule))
+		}
+		s.defs[d.Key] = &definition{Definition: d, def: def}
+	}
+}
+
+// Invalidate 使缓存失效，下次读取时重新加载
+func (s *Store) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = nil
+	s.generation++
+}
+
+// List 获取设置项及当前值，namespace 为空时返回全部，按设置键排序
+func (s *Store) List(ctx context.Context, namespace string) []Item {
+	rows := s.rows(ctx)
+	items := make([]Item, 0, len(s.defs))
+	for _, d := range s.defs {
+		if namespace == "" || d.Namespace() == namespace {
+			items = append(items, d.item(rows))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items
+}
+
+// Get 获取设置项及当前值，设置项未声明时返回 errcode.Setting.NotFound
+func (s *Store) Get(ctx context.Context, key string) (*Item, error) {
+	d, ok := s.defs[key]
+	if !ok {
+		return nil, errcode.Setting.NotFound.New()
+	}
+	item := d.item(s.rows(ctx))
+	return &item, nil
+}
+
+// Value 获取设置的当前值，未修改过时为默认值
+func (s *Store) Value(ctx context.Context, key string) (json.RawMessage, error) {
+	d, ok := s.defs[key]
+	if !ok {
+		return nil, errcode.Setting.NotFound.New()
+	}
+	if row, ok := s.rows(ctx)[key]; ok {
+		return row.Value, nil
+	}
+	return d.def, nil
+}
+
+// Set 修改设置值并记录修改人，值需符合设置项的 Schema；与当前值相同时不做修改
+func (s *Store) Set(ctx context.Context, key string, value json.RawMessage, userID *uint) (*Item, error) {
+	d, ok := s.defs[key]
+	if !ok {
+		return nil, errcode.Setting.NotFound.New()
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return nil, errcode.ValidationFailed.WithDetails(errcode.FieldError{Field: "value", Rule: "type", Param: "JSON"})
+	}
+	if errs := d.Schema.Validate("value", decoded); len(errs) > 0 {
+		return nil, errcode.ValidationFailed.WithDetails(errs...)
+	}
+	value, _ = normalize(decoded)
+
+	err := database.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		current, err := lock(tx, key)
+		if err != nil {
+			return err
+		}
+		old := d.def
+		if current != nil {
+			old = normalizeRaw(current.Value)
+		}
+		if bytes.Equal(old, value) {
+			return nil
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at", "updated_by"}),
+		}).Create(&Setting{Key: key, Value: value, UpdatedBy: userID}).Error
+		if err != nil {
+			return err
+		}
+		return s.record(tx, &Change{Key: key, Action: ActionUpdate, OldValue: old, NewValue: value, UserID: userID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reload(ctx, d)
+}
+
+// Reset 删除修改过的值，恢复为默认值
+func (s *Store) Reset(ctx context.Context, key string, userID *uint) (*Item, error) {
+	d, ok := s.defs[key]
+	if !ok {
+		return nil, errcode.Setting.NotFound.New()
+	}
+
+	err := database.Transaction(ctx, s.db, func(tx *gorm.DB) error {
+		current, err := lock(tx, key)
+		if err != nil || current == nil {
+			return err
+		}
+		if err := tx.Where("key = ?", key).Delete(&Setting{}).Error; err != nil {
+			return err
+		}
+		return s.record(tx, &Change{Key: key, Action: ActionReset, OldValue: normalizeRaw(current.Value), NewValue: d.def, UserID: userID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reload(ctx, d)
+}
+
+// lock 锁定并返回修改过的设置值，没有修改过时返回 nil
+func lock(tx *gorm.DB, key string) (*Setting, error) {
+	var current Setting
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).Take(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+// record 写入修改记录，通知各实例并在提交后使本实例缓存失效
+func (s *Store) record(tx *gorm.DB, change *Change) error {
+	if err := tx.Create(change).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("SELECT pg_notify(?, ?)", channel, change.Key).Error; err != nil {
+		return err
+	}
+	database.AfterCommit(tx.Statement.Context, s.Invalidate)
+	return nil
+}
+
+// reload 修改后读取设置项，ctx 中有外层事务时读取事务内的值
+func (s *Store) reload(ctx context.Context, d *definition) (*Item, error) {
+	var row Setting
+	err := s.db.WithContext(ctx).Where("key = ?", d.Key).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &Item{Key: d.Key, Namespace: d.Namespace(), Description: d.Description, Schema: d.Schema, Default: d.def, Value: d.def, IsDefault: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	item := d.item(map[string]Setting{d.Key: row})
+	return &item, nil
+}
+
+// item 根据缓存的值生成设置项
+func (d *definition) item(rows map[string]Setting) Item {
+	item := Item{
+		Key:         d.Key,
+		Namespace:   d.Namespace(),
+		Description: d.Description,
+		Schema:      d.Schema,
+		Default:     d.def,
+		Value:       d.def,
+		IsDefault:   true,
+	}
+	if row, ok := rows[d.Key]; ok {
+		item.Value = row.Value
+		item.IsDefault = false
+		item.UpdatedAt = &row.UpdatedAt
+		item.UpdatedBy = row.UpdatedBy
+	}
+	return item
+}
+
+// rows 返回缓存的设置值，缓存为空或过期时重新加载；加载失败时沿用旧值，没有旧值时使用默认值，
+// 并在 retryInterval 后重试，而不是按 SETTINGS_CACHE_TTL 长期缓存
+func (s *Store) rows(ctx context.Context) map[string]Setting {
+	s.mu.RLock()
+	cache := s.cache
+	s.mu.RUnlock()
+	if cache != nil && time.Now().Before(cache.expiresAt) {
+		return cache.rows
+	}
+
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+	s.mu.RLock()
+	cache, generation := s.cache, s.generation
+	s.mu.RUnlock()
+	if cache != nil && time.Now().Before(cache.expiresAt) {
+		return cache.rows
+	}
+
+	ttl := s.ttl
+	rows, err := s.load()
+	if err != nil {
+		slog.ErrorContext(ctx, "加载系统设置失败，暂时沿用缓存或默认值", slog.Any("error", err))
+		rows, ttl = map[string]Setting{}, min(retryInterval, s.ttl)
+		if cache != nil {
+			rows = cache.rows
+		}
+	}
+
+	s.mu.Lock()
+	if s.generation == generation {
+		s.cache = &snapshot{rows: rows, expiresAt: time.Now().Add(ttl)}
+	}
+	s.mu.Unlock()
+	return rows
+}
+
+// load 从数据库加载已声明设置项的值，不符合 Schema 的值（如声明变更后的旧值）忽略并使用默认值
+// 不使用请求的 ctx，避免读到请求事务中未提交的值或因请求取消而加载失败
+func (s *Store) load() (map[string]Setting, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var settings []Setting
+	if err := s.db.WithContext(ctx).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]Setting, len(settings))
+	for _, row := range settings {
+		d, ok := s.defs[row.Key]
+		if !ok {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(row.Value, &value); err != nil || len(d.Schema.Validate("value", value)) > 0 {
+			slog.WarnContext(ctx, "系统设置的值不符合声明，使用默认值", slog.String("key", row.Key))
+			continue
+		}
+		row.Value = normalizeRaw(row.Value)
+		rows[row.Key] = row
+	}
+	return rows, nil
+}
+
+// normalize 编码为紧凑的 JSON，对象的字段按名称排序，便于比较是否修改
+func normalize(v interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
+
+// normalizeRaw 规范化数据库中读出的 JSON，jsonb 输出的空格与字段顺序与 normalize 不同
+func normalizeRaw(raw json.RawMessage) json.RawMessage {
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return raw
+	}
+	data, _ := json.Marshal(decoded)
+	return data
+}
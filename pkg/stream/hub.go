@@ -0,0 +1,357 @@
+package stream
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/errcode"
+	"erp_backend/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// resources 聚合类型对应的资源名称，与 REST 路径一致
+var resources = map[string]string{
+	"product":           "products",
+	"supplier":          "suppliers",
+	"shop":              "shops",
+	"link":              "links",
+	"category":          "categories",
+	"attribute":         "attributes",
+	"product_attribute": "product-attributes",
+}
+
+// public 不区分供应商、所有可订阅用户均可接收的资源
+var public = map[string]bool{
+	"categories": true,
+	"attributes": true,
+}
+
+var (
+	// clientsGauge 当前连接数
+	clientsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "stream",
+		Name:      "clients",
+		Help:      "实时推送当前连接数",
+	}, []string{"transport"})
+
+	// droppedTotal 因接收过慢被断开的连接数
+	droppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "stream",
+		Name:      "dropped_total",
+		Help:      "因接收过慢被断开的实时推送连接数",
+	})
+)
+
+func init() {
+	metrics.MustRegister(clientsGauge, droppedTotal)
+}
+
+// Event 推送给客户端的变更事件
+// @Description 实时推送的变更事件
+type Event struct {
+	ID         uint64          `json:"id" example:"1024"`                    // 事件序号，断线重连时作为 Last-Event-ID
+	Type       string          `json:"type" example:"product.stock_changed"` // 事件类型
+	Resource   string          `json:"resource" example:"products"`          // 资源名称
+	ResourceID uint            `json:"resource_id" example:"123"`            // 资源ID
+	Data       json.RawMessage `json:"data" swaggertype:"object"`            // 事件内容，与 Webhook 的 data 相同
+	CreatedAt  time.Time       `json:"created_at"`                           // 事件发生时间
+
+	refs     map[string]uint // 关联资源ID，如 supplier、shop
+	supplier uint            // 所属供应商，0 表示不属于任何供应商或无法确定
+}
+
+// Topic 订阅主题
+// 格式：*、资源:*、资源:ID、资源:关联资源:ID，如 products:*、products:123、links:shop:5
+type Topic struct {
+	raw      string
+	resource string // 为空表示全部资源
+	ref      string // 关联资源，为空时按资源ID匹配
+	id       uint   // 为 0 表示该资源的全部事件
+}
+
+// String 返回主题原文
+func (t Topic) String() string {
+	return t.raw
+}
+
+// ParseTopics 解析订阅主题
+func ParseTopics(raw []string, max int) ([]Topic, error) {
+	if len(raw) > max {
+		return nil, errcode.StreamTooManyTopics.New(max)
+	}
+	topics := make([]Topic, 0, len(raw))
+	for _, s := range raw {
+		topic, ok := parseTopic(strings.TrimSpace(s))
+		if !ok {
+			return nil, errcode.StreamTopicInvalid.New(s)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+// parseTopic 解析单个主题
+func parseTopic(s string) (Topic, bool) {
+	topic := Topic{raw: s}
+	if s == "*" {
+		return topic, true
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 || !validResource(parts[0]) {
+		return topic, false
+	}
+	topic.resource = parts[0]
+	if len(parts) == 2 && parts[1] == "*" {
+		return topic, true
+	}
+
+	id, err := strconv.ParseUint(parts[len(parts)-1], 10, 0)
+	if err != nil || id == 0 {
+		return topic, false
+	}
+	topic.id = uint(id)
+	if len(parts) == 3 {
+		if parts[1] == "" {
+			return topic, false
+		}
+		topic.ref = parts[1]
+	}
+	return topic, true
+}
+
+// validResource 判断资源名称是否有效
+func validResource(resource string) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// match 判断事件是否属于该主题
+func (t Topic) match(e *Event) bool {
+	switch {
+	case t.resource == "":
+		return true
+	case t.resource != e.Resource:
+		return false
+	case t.id == 0:
+		return true
+	case t.ref == "":
+		return t.id == e.ResourceID
+	default:
+		return e.refs[t.ref] == t.id
+	}
+}
+
+// Scope 用户可接收的事件范围
+type Scope struct {
+	All      bool // 可接收全部事件
+	Supplier uint // 仅可接收该供应商的事件以及分类、属性等公共资源
+}
+
+// allows 判断事件是否在范围内
+func (s Scope) allows(e *Event) bool {
+	return s.All || public[e.Resource] || (s.Supplier != 0 && e.supplier == s.Supplier)
+}
+
+// Subscription 一个连接的订阅
+type Subscription struct {
+	C <-chan *Event // 待发送的事件，连接被断开时关闭
+
+	hub       *Hub
+	ch        chan *Event
+	scope     Scope
+	transport string
+	mu        sync.Mutex
+	topics    []Topic
+	reason    errcode.Code // 被服务端断开的原因
+}
+
+// SetTopics 替换订阅的主题，用于 WebSocket 连接中途订阅与取消订阅
+func (s *Subscription) SetTopics(topics []Topic) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topics = topics
+}
+
+// Topics 当前订阅的主题
+func (s *Subscription) Topics() []Topic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.topics
+}
+
+// Reason 连接被服务端断开的原因，C 关闭后可用
+func (s *Subscription) Reason() errcode.Code {
+	return s.reason
+}
+
+// Close 取消订阅
+func (s *Subscription) Close() {
+	s.hub.remove(s, errcode.Code{})
+}
+
+// wants 判断是否需要推送该事件
+func (s *Subscription) wants(e *Event) bool {
+	if !s.scope.allows(e) {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range s.topics {
+		if topic.match(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// hub 全局分发中心，未启用实时推送时为 nil
+var hub *Hub
+
+// SetHub 设置全局分发中心
+func SetHub(h *Hub) {
+	hub = h
+}
+
+// Default 返回全局分发中心，未启用实时推送时为 nil
+func Default() *Hub {
+	return hub
+}
+
+// Hub 保存最近的事件并向各连接分发
+type Hub struct {
+	cfg    *config.StreamConfig
+	mu     sync.Mutex
+	buffer []*Event // 环形缓冲，按接收顺序保存最近的事件
+	next   int      // 下一个写入位置
+	full   bool
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewHub 创建事件分发中心
+func NewHub(cfg *config.StreamConfig) *Hub {
+	return &Hub{
+		cfg:    cfg,
+		buffer: make([]*Event, max(cfg.BufferSize, 1)),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe 注册订阅，返回 lastEventID 之后的待补发事件
+// lastEventID 已不在缓冲中时 reset 为 true，客户端需重新加载数据
+func (h *Hub) Subscribe(transport string, topics []Topic, scope Scope, lastEventID string) (sub *Subscription, replay []*Event, reset bool, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, nil, false, errcode.StreamUnavailable.New()
+	}
+
+	ch := make(chan *Event, max(h.cfg.ClientBuffer, 1))
+	sub = &Subscription{C: ch, hub: h, ch: ch, scope: scope, transport: transport, topics: topics}
+	if lastEventID != "" {
+		replay, reset = h.since(lastEventID)
+		filtered := replay[:0]
+		for _, e := range replay {
+			if sub.wants(e) {
+				filtered = append(filtered, e)
+			}
+		}
+		replay = filtered
+	}
+
+	h.subs[sub] = struct{}{}
+	clientsGauge.WithLabelValues(transport).Inc()
+	return sub, replay, reset, nil
+}
+
+// since 返回缓冲中 id 之后的事件，找不到 id 时返回 reset
+func (h *Hub) since(lastEventID string) ([]*Event, bool) {
+	id, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return nil, true
+	}
+
+	ordered := h.ordered()
+	for i, e := range ordered {
+		if e.ID == id {
+			return append([]*Event(nil), ordered[i+1:]...), false
+		}
+	}
+	return nil, true
+}
+
+// ordered 按接收顺序返回缓冲中的事件
+func (h *Hub) ordered() []*Event {
+	if !h.full {
+		return h.buffer[:h.next]
+	}
+	return append(append([]*Event(nil), h.buffer[h.next:]...), h.buffer[:h.next]...)
+}
+
+// Publish 保存事件并分发给订阅了该事件的连接
+// 连接的待发送队列已满时断开该连接，避免慢客户端占用内存或拖慢其他连接
+func (h *Hub) Publish(e *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+
+	h.buffer[h.next] = e
+	h.next = (h.next + 1) % len(h.buffer)
+	if h.next == 0 {
+		h.full = true
+	}
+
+	for sub := range h.subs {
+		if !sub.wants(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			droppedTotal.Inc()
+			h.removeLocked(sub, errcode.StreamLagging)
+		}
+	}
+}
+
+// Close 断开全部连接并拒绝新的订阅，服务关闭时调用以便长连接及时结束
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		h.removeLocked(sub, errcode.StreamShutdown)
+	}
+}
+
+// remove 移除订阅
+func (h *Hub) remove(sub *Subscription, reason errcode.Code) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(sub, reason)
+}
+
+// removeLocked 移除订阅并关闭其事件通道，调用方需持有锁
+func (h *Hub) removeLocked(sub *Subscription, reason errcode.Code) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.reason = reason
+	close(sub.ch)
+	clientsGauge.WithLabelValues(sub.transport).Dec()
+}
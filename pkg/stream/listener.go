@@ -0,0 +1,129 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// channel 通知各实例有新事件的 PostgreSQL 频道
+const channel = "erp_stream"
+
+// parents 可据以确定所属供应商的关联资源及其表名
+var parents = map[string]string{
+	"shop":    "shops",
+	"product": "products",
+}
+
+// Notifier 发件箱订阅方，在转发事务中发出 NOTIFY
+// 通知随事务提交送达所有实例，各实例的 Listener 据此加载事件并推送给本实例的连接
+type Notifier struct {
+	db *gorm.DB
+}
+
+// NewNotifier 创建通知方
+func NewNotifier(db *gorm.DB) *Notifier {
+	return &Notifier{db: db}
+}
+
+// Handle 实现 events.Handler
+func (n *Notifier) Handle(ctx context.Context, e events.Envelope) error {
+	if _, ok := resources[e.AggregateType]; !ok {
+		return nil
+	}
+	return n.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, e.ID).Error
+}
+
+// Listener 监听新事件通知并交给 Hub 分发
+type Listener struct {
+	db  *gorm.DB
+	hub *Hub
+}
+
+// NewListener 创建监听器
+func NewListener(db *gorm.DB, hub *Hub) *Listener {
+	return &Listener{db: db, hub: hub}
+}
+
+// Run 占用一个数据库连接执行 LISTEN，连接断开后自动重连，直到 ctx 取消
+func (l *Listener) Run(ctx context.Context) {
+	listener := &database.Listener{DB: l.db, Channel: channel, OnNotify: l.ingest}
+	listener.Run(ctx)
+}
+
+// ingest 加载事件并分发
+func (l *Listener) ingest(ctx context.Context, eventID string) {
+	var message events.Message
+	if err := l.db.WithContext(ctx).Where("event_id = ?", eventID).First(&message).Error; err != nil {
+		slog.ErrorContext(ctx, "实时推送加载事件失败", slog.String("event_id", eventID), slog.Any("error", err))
+		return
+	}
+
+	e := &Event{
+		ID:         message.ID,
+		Type:       message.Event,
+		Resource:   resources[message.AggregateType],
+		ResourceID: message.AggregateID,
+		Data:       message.Payload,
+		CreatedAt:  message.CreatedAt,
+		refs:       refs(message.Payload),
+	}
+	e.supplier = l.supplierOf(ctx, message.AggregateType, e)
+	l.hub.Publish(e)
+}
+
+// refs 提取事件内容中的关联资源ID（形如 supplier_id 的字段），库存、价格等事件还会提取嵌套实体中的字段
+func refs(payload json.RawMessage) map[string]uint {
+	var data map[string]interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil
+	}
+
+	result := make(map[string]uint)
+	collect := func(fields map[string]interface{}) {
+		for key, value := range fields {
+			ref, ok := strings.CutSuffix(key, "_id")
+			number, isNumber := value.(float64)
+			if ok && isNumber && number > 0 {
+				if _, exists := result[ref]; !exists {
+					result[ref] = uint(number)
+				}
+			}
+		}
+	}
+	collect(data)
+	for _, value := range data {
+		if nested, ok := value.(map[string]interface{}); ok {
+			collect(nested)
+		}
+	}
+	return result
+}
+
+// supplierOf 确定事件所属的供应商，无法确定时返回 0
+func (l *Listener) supplierOf(ctx context.Context, aggregateType string, e *Event) uint {
+	if aggregateType == "supplier" {
+		return e.ResourceID
+	}
+	if id := e.refs["supplier"]; id != 0 {
+		return id
+	}
+	for ref, table := range parents {
+		id := e.refs[ref]
+		if id == 0 {
+			continue
+		}
+		var supplierID uint
+		err := l.db.WithContext(ctx).Table(table).Select("supplier_id").Where("id = ?", id).Scan(&supplierID).Error
+		if err == nil && supplierID != 0 {
+			return supplierID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package subrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"erp_backend/pkg/middleware"
+)
+
+// skipHeaders 不透传给子请求的请求头，其余请求头（认证、语言等）沿用原请求的值
+var skipHeaders = []string{
+	"Content-Length",
+	"Content-Type",
+	middleware.IdempotencyKeyHeader,
+	middleware.RequestIDHeader,
+	"Traceparent",
+	"Tracestate",
+	"Baggage",
+}
+
+// New 基于原请求创建经由路由执行的子请求，ctx 需经 middleware.WithSubRequest 标记
+// 子请求与单独调用对应接口完全一致（认证、校验、错误码相同）
+func New(ctx context.Context, parent *http.Request, method, target string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Host = parent.Host
+	req.RemoteAddr = parent.RemoteAddr
+	req.Header = parent.Header.Clone()
+	for _, name := range skipHeaders {
+		req.Header.Del(name)
+	}
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// Do 经 router 执行子请求并返回记录的响应
+func Do(router http.Handler, req *http.Request) *Recorder {
+	rec := &Recorder{header: make(http.Header)}
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// Recorder 记录子请求的响应
+type Recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// Header 实现 http.ResponseWriter
+func (r *Recorder) Header() http.Header {
+	return r.header
+}
+
+// Write 实现 http.ResponseWriter
+func (r *Recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+// WriteHeader 实现 http.ResponseWriter
+func (r *Recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+// Status 响应状态码，未写出任何内容时为 200
+func (r *Recorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Body 原始响应体
+func (r *Recorder) Body() []byte {
+	return r.body.Bytes()
+}
+
+// JSON 响应体，非 JSON 内容（如重定向页面）按字符串返回
+func (r *Recorder) JSON() json.RawMessage {
+	if r.body.Len() == 0 {
+		return nil
+	}
+	if json.Valid(r.body.Bytes()) {
+		return r.body.Bytes()
+	}
+	b, _ := json.Marshal(r.body.String())
+	return b
+}
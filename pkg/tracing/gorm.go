@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	spanKey   = "tracing:span"   // 当前 span 在 gorm 实例中的键
+	parentKey = "tracing:parent" // 开启 span 前的 context
+)
+
+// RegisterDB 为数据库注册追踪插件，每条 SQL 生成一个子 span
+// 查询需通过 db.WithContext(c) 传入请求上下文才能挂到请求 span 下
+func RegisterDB(db *gorm.DB) error {
+	return db.Use(&gormPlugin{})
+}
+
+// gormPlugin 为 SQL 创建 span 的 GORM 插件
+type gormPlugin struct{}
+
+// Name 实现 gorm.Plugin
+func (p *gormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize 实现 gorm.Plugin，在各类操作的首尾注册回调
+func (p *gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("*").Register("tracing:before_create", start("create")),
+		cb.Create().After("*").Register("tracing:after_create", end),
+		cb.Query().Before("*").Register("tracing:before_query", start("query")),
+		cb.Query().After("*").Register("tracing:after_query", end),
+		cb.Update().Before("*").Register("tracing:before_update", start("update")),
+		cb.Update().After("*").Register("tracing:after_update", end),
+		cb.Delete().Before("*").Register("tracing:before_delete", start("delete")),
+		cb.Delete().After("*").Register("tracing:after_delete", end),
+		cb.Row().Before("*").Register("tracing:before_row", start("row")),
+		cb.Row().After("*").Register("tracing:after_row", end),
+		cb.Raw().Before("*").Register("tracing:before_raw", start("raw")),
+		cb.Raw().After("*").Register("tracing:after_raw", end),
+	)
+}
+
+// start 返回开启 span 的回调
+func start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil || !trace.SpanContextFromContext(db.Statement.Context).IsValid() {
+			// 没有上层 span 的查询（如启动迁移、指标采集）不单独产生追踪
+			return
+		}
+		ctx, span := Tracer().Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperationName(operation)),
+		)
+		db.InstanceSet(parentKey, db.Statement.Context)
+		db.InstanceSet(spanKey, span)
+		db.Statement.Context = ctx
+	}
+}
+
+// end 记录 SQL、表名、影响行数与错误并结束 span
+// 结束后恢复原 context，避免复用同一语句的后续查询挂到已结束的 span 下
+func end(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+	if parent, ok := db.InstanceGet(parentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,314 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"erp_backend/pkg/config"
+	"erp_backend/pkg/database"
+	"erp_backend/pkg/events"
+	"erp_backend/pkg/metrics"
+	"erp_backend/pkg/retry"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// 投递请求头
+const (
+	HeaderEventID   = "X-Webhook-Id"        // 事件ID
+	HeaderEvent     = "X-Webhook-Event"     // 事件类型
+	HeaderDelivery  = "X-Webhook-Delivery"  // 投递记录ID
+	HeaderTimestamp = "X-Webhook-Timestamp" // 签名时间戳（Unix 秒）
+	HeaderSignature = "X-Webhook-Signature" // 签名，格式 sha256=<hex>
+)
+
+// maxResponseBody 投递日志中保留的响应内容长度
+const maxResponseBody = 2048
+
+// dispatcher 全局投递器，用于重新投递后唤醒
+var dispatcher *Dispatcher
+
+// deliveriesTotal 投递结果计数
+var deliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "webhook",
+	Name:      "deliveries_total",
+	Help:      "Webhook 投递次数，result 为 succeeded、retry、dead",
+}, []string{"event", "result"})
+
+func init() {
+	metrics.MustRegister(deliveriesTotal)
+}
+
+// SetDispatcher 设置全局投递器
+func SetDispatcher(d *Dispatcher) {
+	dispatcher = d
+}
+
+// Sign 计算签名：HMAC-SHA256(secret, 时间戳 + "." + 请求体) 的十六进制
+// 接收方用相同方式计算并以常量时间比较，同时校验时间戳防止重放
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher 后台投递待发送的记录，多实例部署时通过 SKIP LOCKED 分摊
+type Dispatcher struct {
+	db     *gorm.DB
+	cfg    *config.WebhookConfig
+	client *http.Client
+	wake   chan struct{}
+}
+
+// NewDispatcher 创建投递器
+func NewDispatcher(db *gorm.DB, cfg *config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		cfg:    cfg,
+		client: newClient(cfg.Timeout, cfg.AllowPrivateNetworks),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Handle 领域事件处理函数，为订阅了该事件的订阅方写入投递记录
+// 投递记录与发件箱事件的处理结果在同一事务中提交，提交后唤醒投递循环
+func (d *Dispatcher) Handle(ctx context.Context, e events.Envelope) error {
+	if !isKnown(e.Event) {
+		return nil
+	}
+	if err := enqueue(ctx, d.db, e); err != nil {
+		return err
+	}
+	database.AfterCommit(ctx, d.Notify)
+	return nil
+}
+
+// Notify 唤醒投递循环，有新记录时无需等到下一次轮询
+func (d *Dispatcher) Notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 循环领取到期的记录并投递，直到 ctx 取消
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		deliveries, err := d.claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Webhook 领取投递记录失败", slog.Any("error", err))
+		}
+
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, max(d.cfg.Workers, 1))
+		for _, delivery := range deliveries {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(delivery Delivery) {
+				defer func() { <-sem; wg.Done() }()
+				d.deliver(ctx, &delivery)
+			}(delivery)
+		}
+		wg.Wait()
+
+		// 领满一批说明可能还有积压，立即继续
+		if len(deliveries) >= d.cfg.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-time.After(d.cfg.PollInterval):
+		}
+	}
+}
+
+// claimSQL 领取到期的待投递记录，并把下次投递时间推后作为租约
+// 实例在租约内退出时，记录到期后会被重新领取
+const claimSQL = `
+UPDATE webhook_deliveries SET next_attempt_at = now() + make_interval(secs => @lease), updated_at = now()
+WHERE id IN (
+	SELECT id FROM webhook_deliveries
+	WHERE status = @status AND next_attempt_at <= now()
+	ORDER BY next_attempt_at, id
+	LIMIT @limit
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// claim 领取一批到期的记录
+func (d *Dispatcher) claim(ctx context.Context) ([]Delivery, error) {
+	var deliveries []Delivery
+	err := d.db.WithContext(ctx).Raw(claimSQL, map[string]interface{}{
+		"lease":  (2 * d.cfg.Timeout).Seconds(),
+		"status": StatusPending,
+		"limit":  d.cfg.BatchSize,
+	}).Scan(&deliveries).Error
+	return deliveries, err
+}
+
+// deliver 发送一次投递并记录结果
+func (d *Dispatcher) deliver(ctx context.Context, delivery *Delivery) {
+	attempt := Attempt{DeliveryID: delivery.ID, Attempt: delivery.Attempts + 1}
+
+	var subscription Subscription
+	err := d.db.WithContext(ctx).First(&subscription, delivery.SubscriptionID).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		attempt.Error = "订阅不存在"
+		d.finish(ctx, delivery, &attempt, StatusDead)
+		return
+	case err != nil:
+		slog.ErrorContext(ctx, "Webhook 读取订阅失败", slog.Uint64("delivery_id", uint64(delivery.ID)), slog.Any("error", err))
+		return
+	case !subscription.IsEnabled:
+		attempt.Error = "订阅已停用"
+		d.finish(ctx, delivery, &attempt, StatusDead)
+		return
+	}
+
+	start := time.Now()
+	attempt.StatusCode, attempt.ResponseBody, err = d.send(ctx, &subscription, delivery)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if ctx.Err() != nil {
+		// 服务关闭中断的投递不计次数，租约到期后重新投递
+		return
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	status := StatusSucceeded
+	if err != nil || attempt.StatusCode < 200 || attempt.StatusCode >= 300 {
+		status = StatusPending
+		if attempt.Attempt >= d.cfg.MaxAttempts {
+			status = StatusDead
+		}
+		if attempt.Error == "" {
+			attempt.Error = fmt.Sprintf("接收方返回 %d", attempt.StatusCode)
+		}
+	}
+	d.finish(ctx, delivery, &attempt, status)
+}
+
+// send 发送签名后的请求，返回响应状态码与截断的响应内容
+func (d *Dispatcher) send(ctx context.Context, subscription *Subscription, delivery *Delivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "erp-webhook/1.0")
+	req.Header.Set(HeaderEventID, delivery.EventID)
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	return resp.StatusCode, string(body), nil
+}
+
+// finish 保存投递日志并更新投递记录；失败且未超过最大次数时按指数退避安排重试
+func (d *Dispatcher) finish(ctx context.Context, delivery *Delivery, attempt *Attempt, status string) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":           status,
+		"attempts":         attempt.Attempt,
+		"last_status_code": attempt.StatusCode,
+		"last_error":       attempt.Error,
+	}
+	result := "retry"
+	switch status {
+	case StatusSucceeded:
+		updates["delivered_at"] = now
+		result = status
+	case StatusDead:
+		result = status
+	default:
+		updates["next_attempt_at"] = now.Add(retry.Backoff(attempt.Attempt, d.cfg.BackoffBase, d.cfg.BackoffMax))
+	}
+
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Delivery{}).Where("id = ?", delivery.ID).Updates(updates).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Webhook 保存投递结果失败", slog.Uint64("delivery_id", uint64(delivery.ID)), slog.Any("error", err))
+		return
+	}
+
+	deliveriesTotal.WithLabelValues(delivery.Event, result).Inc()
+	if status == StatusDead {
+		slog.WarnContext(ctx, "Webhook 投递进入死信",
+			slog.Uint64("delivery_id", uint64(delivery.ID)),
+			slog.String("event", delivery.Event),
+			slog.Int("attempts", attempt.Attempt),
+			slog.String("error", attempt.Error),
+		)
+	}
+}
+
+// Cleanup 定期删除超过保留时间的投递成功记录及其日志，直到 ctx 取消
+func (d *Dispatcher) Cleanup(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			before := time.Now().Add(-d.cfg.Retention)
+			expired := d.db.Model(&Delivery{}).Select("id").Where("status = ? AND delivered_at < ?", StatusSucceeded, before)
+			db := d.db.WithContext(ctx)
+			db.Where("delivery_id IN (?)", expired).Delete(&Attempt{})
+			db.Where("status = ? AND delivered_at < ?", StatusSucceeded, before).Delete(&Delivery{})
+		}
+	}
+}
+
+// Redeliver 将投递记录重置为待投递，投递次数清零，用于死信或需要重新推送的记录
+func Redeliver(ctx context.Context, db *gorm.DB, id uint) (*Delivery, error) {
+	var delivery Delivery
+	if err := db.WithContext(ctx).First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	err := db.WithContext(ctx).Model(&delivery).Updates(map[string]interface{}{
+		"status":          StatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+		"delivered_at":    nil,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+	if d := dispatcher; d != nil {
+		d.Notify()
+	}
+	return &delivery, db.WithContext(ctx).First(&delivery, id).Error
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress 接收地址指向本机、内网或云平台元数据服务
+var ErrForbiddenAddress = errors.New("接收地址指向本机、内网或云平台元数据地址")
+
+// forbiddenPrefixes IsPrivate 等方法未覆盖但同样不允许投递的网段
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // 本网络
+	netip.MustParsePrefix("100.64.0.0/10"), // 运营商级 NAT，部分云平台的元数据服务位于此网段（如 100.100.100.200）
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF 协议分配
+	netip.MustParsePrefix("198.18.0.0/15"), // 基准测试
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64，可映射到任意 IPv4 地址
+}
+
+// forbidden 判断地址是否不允许投递：回环、私有、链路本地（含 169.254.169.254 元数据服务）、组播与未指定地址
+func forbidden(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckURL 解析接收地址的主机名，任一地址不允许投递时返回 ErrForbiddenAddress；allowPrivate 为 true 时不检查
+// 创建订阅时提前拒绝明显的内网地址，投递时仍在建立连接前逐次检查，防止 DNS 解析结果变化后绕过
+func CheckURL(ctx context.Context, rawURL string, allowPrivate bool) error {
+	if allowPrivate {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if forbidden(addr) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("解析接收地址失败: %w", err)
+	}
+	for _, addr := range addrs {
+		if forbidden(addr) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// newClient 创建投递使用的 HTTP 客户端：不跟随重定向、不使用代理，allowPrivate 为 false 时
+// 在建立连接前检查实际连接的地址，拒绝本机、内网与元数据地址
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || forbidden(addrPort.Addr()) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// 重定向的目标未经订阅时的检查，投递按接收方返回的 3xx 记录为失败
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
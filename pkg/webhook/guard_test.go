@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestForbidden(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.100.100.200", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fd00:ec2::254", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"2001:4860:4860::8888", false},
+	}
+	for _, tt := range tests {
+		if got := forbidden(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("forbidden(%s) = %v, 期望 %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	ctx := context.Background()
+	for _, rawURL := range []string{"http://127.0.0.1:8080/hook", "http://[::1]/hook", "http://169.254.169.254/latest/meta-data", "http://localhost/hook"} {
+		if err := CheckURL(ctx, rawURL, false); !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("CheckURL(%s) 错误 = %v, 期望 ErrForbiddenAddress", rawURL, err)
+		}
+	}
+	if err := CheckURL(ctx, "http://127.0.0.1/hook", true); err != nil {
+		t.Errorf("允许内网时 CheckURL() 错误 = %v", err)
+	}
+}
+
+func TestClientRejectsPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/target", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// 连接本机的测试服务在建立连接前被拒绝
+	if _, err := newClient(0, false).Get(server.URL); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("连接本机地址错误 = %v, 期望 ErrForbiddenAddress", err)
+	}
+
+	// 不跟随重定向，返回 3xx 本身
+	resp, err := newClient(0, true).Get(server.URL + "/redirect")
+	if err != nil {
+		t.Fatalf("Get() 错误 = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("重定向响应状态码 = %d, 期望 %d", resp.StatusCode, http.StatusFound)
+	}
+}
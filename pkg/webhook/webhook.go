@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"erp_backend/pkg/events"
+
+	"gorm.io/gorm"
+)
+
+// known 可订阅的事件类型，由 SetEvents 根据各模块声明的领域事件设置
+var known struct {
+	mu     sync.RWMutex
+	events map[string]bool
+}
+
+// SetEvents 设置可订阅的事件类型，格式为 资源.动作；未列出的事件不产生投递记录
+func SetEvents(names []string) {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	known.mu.Lock()
+	known.events = set
+	known.mu.Unlock()
+}
+
+// isKnown 判断是否为可订阅的事件类型
+func isKnown(event string) bool {
+	known.mu.RLock()
+	defer known.mu.RUnlock()
+	return known.events[event]
+}
+
+// Events 返回排序后的全部事件类型
+func Events() []string {
+	known.mu.RLock()
+	list := make([]string, 0, len(known.events))
+	for event := range known.events {
+		list = append(list, event)
+	}
+	known.mu.RUnlock()
+	sort.Strings(list)
+	return list
+}
+
+// ValidPattern 判断订阅的事件类型是否有效：具体事件、资源.* 或 *
+func ValidPattern(pattern string) bool {
+	if pattern == "*" || isKnown(pattern) {
+		return true
+	}
+	resource, ok := strings.CutSuffix(pattern, ".*")
+	if !ok {
+		return false
+	}
+	for _, event := range Events() {
+		if strings.HasPrefix(event, resource+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// 投递状态
+const (
+	StatusPending   = "pending"   // 等待投递或重试
+	StatusSucceeded = "succeeded" // 投递成功
+	StatusDead      = "dead"      // 超过最大投递次数，进入死信
+)
+
+// EventTypes 订阅的事件类型列表，以 JSON 数组存储
+type EventTypes []string
+
+// Value 实现 driver.Valuer 接口
+func (e EventTypes) Value() (driver.Value, error) {
+	b, err := json.Marshal(e)
+	return string(b), err
+}
+
+// Scan 实现 sql.Scanner 接口
+func (e *EventTypes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return errors.New("类型断言失败")
+	}
+}
+
+// Match 判断是否订阅了事件
+func (e EventTypes) Match(event string) bool {
+	for _, pattern := range e {
+		if pattern == "*" || pattern == event {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(pattern, ".*"); ok && strings.HasPrefix(event, resource+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// RawJSON 以 jsonb 存储、按原样输出的 JSON
+type RawJSON json.RawMessage
+
+// Value 实现 driver.Valuer 接口
+func (j RawJSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan 实现 sql.Scanner 接口
+func (j *RawJSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = RawJSON(v)
+	case nil:
+		*j = nil
+	default:
+		return errors.New("类型断言失败")
+	}
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (j RawJSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// Subscription Webhook 订阅
+// @Description Webhook 订阅信息
+type Subscription struct {
+	ID        uint       `gorm:"primarykey" json:"id"`                                                         // 主键ID
+	CreatedAt time.Time  `json:"created_at"`                                                                   // 创建时间
+	UpdatedAt time.Time  `json:"updated_at"`                                                                   // 更新时间
+	DeletedAt *time.Time `gorm:"index" json:"deleted_at"`                                                      // 删除时间
+	Name      string     `gorm:"type:varchar(100);not null;comment:订阅名称" json:"name"`                          // 订阅名称
+	URL       string     `gorm:"type:varchar(500);not null;comment:接收地址" json:"url"`                           // 接收地址
+	Secret    string     `gorm:"type:varchar(100);not null;comment:签名密钥" json:"-"`                             // 签名密钥
+	Events    EventTypes `gorm:"type:jsonb;not null;comment:订阅的事件类型" json:"events" swaggertype:"array,string"` // 订阅的事件类型
+	Remark    string     `gorm:"type:text;comment:备注" json:"remark"`                                           // 备注
+	IsEnabled bool       `gorm:"default:true;comment:是否启用" json:"is_enabled"`                                  // 是否启用
+}
+
+// TableName 表名
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Delivery 一个事件向一个订阅的投递
+// @Description Webhook 投递记录
+type Delivery struct {
+	ID             uint       `gorm:"primarykey" json:"id"`                                                                // 主键ID
+	CreatedAt      time.Time  `json:"created_at"`                                                                          // 创建时间
+	UpdatedAt      time.Time  `json:"updated_at"`                                                                          // 更新时间
+	SubscriptionID uint       `gorm:"not null;index;comment:订阅ID" json:"subscription_id"`                                  // 订阅ID
+	EventID        string     `gorm:"type:varchar(40);not null;index;comment:事件ID" json:"event_id"`                        // 事件ID，同一事件的各订阅投递相同
+	Event          string     `gorm:"type:varchar(100);not null;index;comment:事件类型" json:"event"`                          // 事件类型
+	Payload        RawJSON    `gorm:"type:jsonb;not null;comment:投递内容" json:"payload" swaggertype:"object"`                // 投递内容
+	Status         string     `gorm:"type:varchar(20);not null;index:idx_webhook_deliveries_due,priority:1" json:"status"` // 状态：pending、succeeded、dead
+	Attempts       int        `gorm:"not null;default:0;comment:已投递次数" json:"attempts"`                                    // 已投递次数
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"`         // 下次投递时间
+	LastStatusCode int        `gorm:"comment:最近一次响应状态码" json:"last_status_code"`                                           // 最近一次响应状态码
+	LastError      string     `gorm:"type:text;comment:最近一次错误" json:"last_error"`                                          // 最近一次错误
+	DeliveredAt    *time.Time `gorm:"comment:投递成功时间" json:"delivered_at"`                                                  // 投递成功时间
+	Logs           []Attempt  `gorm:"foreignKey:DeliveryID" json:"logs,omitempty"`                                         // 投递日志
+}
+
+// TableName 表名
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// Attempt 单次投递日志
+// @Description Webhook 单次投递的请求结果
+type Attempt struct {
+	ID           uint      `gorm:"primarykey" json:"id"`                             // 主键ID
+	CreatedAt    time.Time `json:"created_at"`                                       // 投递时间
+	DeliveryID   uint      `gorm:"not null;index;comment:投递记录ID" json:"delivery_id"` // 投递记录ID
+	Attempt      int       `gorm:"not null;comment:第几次投递" json:"attempt"`            // 第几次投递
+	StatusCode   int       `gorm:"comment:响应状态码" json:"status_code"`                 // 响应状态码，请求失败时为 0
+	Error        string    `gorm:"type:text;comment:错误信息" json:"error"`              // 错误信息
+	ResponseBody string    `gorm:"type:text;comment:响应内容（截断）" json:"response_body"`  // 响应内容，最多保留 2KB
+	DurationMs   int64     `gorm:"comment:耗时（毫秒）" json:"duration_ms"`                // 耗时（毫秒）
+}
+
+// TableName 表名
+func (Attempt) TableName() string {
+	return "webhook_attempts"
+}
+
+// Event 投递给订阅方的事件内容
+type Event struct {
+	ID        string          `json:"id"`         // 事件ID，接收方可据此去重
+	Type      string          `json:"type"`       // 事件类型
+	CreatedAt time.Time       `json:"created_at"` // 事件发生时间
+	Data      json.RawMessage `json:"data"`       // 实体数据，删除事件只含 id 及所属实体的ID
+}
+
+// enqueue 为订阅了该事件的订阅方写入投递记录
+func enqueue(ctx context.Context, db *gorm.DB, e events.Envelope) error {
+	var subscriptions []Subscription
+	if err := db.WithContext(ctx).Where("is_enabled = ?", true).Find(&subscriptions).Error; err != nil {
+		return err
+	}
+
+	var deliveries []Delivery
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Events.Match(e.Event) {
+			continue
+		}
+		if payload == nil {
+			var err error
+			if payload, err = json.Marshal(Event{ID: e.ID, Type: e.Event, CreatedAt: e.CreatedAt, Data: e.Payload}); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        e.ID,
+			Event:          e.Event,
+			Payload:        payload,
+			Status:         StatusPending,
+			NextAttemptAt:  time.Now(),
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.WithContext(ctx).Create(&deliveries).Error
+}
+
+// NewSecret 生成签名密钥
+func NewSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import "testing"
+
+func TestValidPattern(t *testing.T) {
+	SetEvents([]string{"product.created", "product.stock_changed", "shop.deleted"})
+	defer SetEvents(nil)
+
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*", true},
+		{"product.created", true},
+		{"product.*", true},
+		{"shop.*", true},
+		{"shop.created", false},
+		{"order.*", false},
+		{"product", false},
+	}
+	for _, tt := range tests {
+		if got := ValidPattern(tt.pattern); got != tt.want {
+			t.Errorf("ValidPattern(%q) = %v, 期望 %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestEventTypesMatch(t *testing.T) {
+	types := EventTypes{"product.*", "shop.deleted"}
+	tests := []struct {
+		event string
+		want  bool
+	}{
+		{"product.created", true},
+		{"product.stock_changed", true},
+		{"shop.deleted", true},
+		{"shop.created", false},
+		{"product_attribute.created", false},
+	}
+	for _, tt := range tests {
+		if got := types.Match(tt.event); got != tt.want {
+			t.Errorf("Match(%q) = %v, 期望 %v", tt.event, got, tt.want)
+		}
+	}
+}